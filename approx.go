@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math"
+	"sort"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// ApproxBuffer is the number of values that a streamDigest buffers
+// before merging them into its centroids.
+const approxBuffer = 10 * compression
+
+// A streamDigest builds a t-digest of a stream of values, for -approx,
+// in memory proportional to the compression, not to the number of values.
+// Values are buffered and merged into the centroids when the buffer is full.
+// The count, mean, and standard deviation are computed exactly as the values pass,
+// by Welford's method, and only the quartiles are estimated.
+type streamDigest struct {
+	d   digest
+	buf []float64
+	// N, mean, and m2 are the count, mean,
+	// and sum of squared differences from the mean of the values.
+	n        int
+	mean, m2 float64
+	// Censored is the number of censored values, counted at their limits.
+	censored int
+}
+
+// Add adds a value to the digest.
+func (s *streamDigest) add(v float64) {
+	if s.n == 0 || v < s.d.min {
+		s.d.min = v
+	}
+	if s.n == 0 || v > s.d.max {
+		s.d.max = v
+	}
+	s.n++
+	delta := v - s.mean
+	s.mean += delta / float64(s.n)
+	s.m2 += delta * (v - s.mean)
+	s.buf = append(s.buf, v)
+	if len(s.buf) >= approxBuffer {
+		s.flush()
+	}
+}
+
+// Flush merges the buffered values into the centroids.
+func (s *streamDigest) flush() {
+	if len(s.buf) == 0 {
+		return
+	}
+	cs := make([]centroid, 0, len(s.d.centroids)+len(s.buf))
+	cs = append(cs, s.d.centroids...)
+	for _, v := range s.buf {
+		cs = append(cs, centroid{mean: v, weight: 1})
+	}
+	sort.Slice(cs, func(i, j int) bool { return cs[i].mean < cs[j].mean })
+	s.d.centroids = mergeCentroids(cs, compression)
+	s.buf = s.buf[:0]
+}
+
+// Box returns a box of the values added to the digest,
+// with its quartiles estimated from the digest.
+func (s *streamDigest) box(name string) box {
+	s.flush()
+	b := digestBox(name, &s.d)
+	b.n = s.n
+	if s.n > 0 {
+		b.mean, b.stddev = s.mean, math.NaN()
+	}
+	if s.n > 1 {
+		b.stddev = math.Sqrt(s.m2 / float64(s.n-1))
+	}
+	if s.censored > 0 {
+		warnf("%s: %d censored values are counted at their limits with -approx", name, s.censored)
+	}
+	return b
+}
+
+// MergeCentroids returns centroids sorted by their means
+// merged according to the k1 scale function, as by newDigest,
+// so there are roughly compression of them.
+func mergeCentroids(cs []centroid, compression float64) []centroid {
+	k := func(q float64) float64 { return compression / (2 * math.Pi) * math.Asin(2*q-1) }
+	n := 0.0
+	for _, c := range cs {
+		n += c.weight
+	}
+	var merged []centroid
+	c := cs[0]
+	left := 0.0
+	for _, x := range cs[1:] {
+		if k((left+c.weight+x.weight)/n)-k(left/n) > 1 {
+			merged = append(merged, c)
+			left += c.weight
+			c = x
+			continue
+		}
+		c.weight += x.weight
+		c.mean += (x.mean - c.mean) * x.weight / c.weight
+	}
+	return append(merged, c)
+}
+
+// ReadApproxBox is like readBox, but, for -approx,
+// it adds the values to a streamDigest instead of keeping them.
+func readApproxBox(scanner tokenScanner) (b box, more bool) {
+	name := boxplot.Unquote(scanner.Text())
+	var s streamDigest
+	for scanner.Scan() {
+		tok := scanner.Bytes()
+		if *sep != "" && string(tok) == *sep {
+			more = scanner.Scan()
+			break
+		}
+		text := string(tok)
+		censored := len(text) > 1 && text[0] == '>'
+		if censored {
+			text = text[1:]
+		}
+		v, err := parseFloat(text)
+		if err != nil {
+			more = true
+			break
+		}
+		if censored {
+			s.censored++
+		}
+		s.add(v)
+	}
+	return s.box(name), more
+}
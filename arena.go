@@ -0,0 +1,59 @@
+package main
+
+// ArenaChunk is the number of values in each chunk of a floatArena.
+const arenaChunk = 64 * 1024
+
+// A floatArena allocates the value slices of data sets
+// that are read one after another from large shared chunks,
+// so reading many values does not repeatedly grow and copy
+// a slice for each data set, nor leave the garbage of doing so.
+//
+// The values of the data set being read are always at the end of the current chunk.
+// When the chunk is full, they are copied to the start of a new one.
+type floatArena struct {
+	buf []float64
+}
+
+// ValueBytes is the fewest bytes of input expected of a value and the white space after it,
+// such as 12 and a space, by which newFloatArena sizes its first chunk.
+const valueBytes = 4
+
+// MaxArenaReserve is the most values that newFloatArena reserves up front,
+// 128MiB of float64s, so that a large input cannot fail the allocation,
+// as it could on a 32-bit system.
+// Larger inputs grow the arena as they are read.
+const maxArenaReserve = 1 << 24
+
+// NewFloatArena returns an arena for the values of an input of size bytes,
+// or of unknown size if 0.
+// If the input is large, the first chunk is large enough
+// for as many values as the input can be expected to hold, up to maxArenaReserve,
+// so that its data sets need not be copied to new chunks as they grow.
+// Memory of the chunk beyond the values read is never touched,
+// so an overestimate costs address space, but not memory.
+func newFloatArena(size int64) *floatArena {
+	var a floatArena
+	n := size / valueBytes
+	if n > maxArenaReserve {
+		n = maxArenaReserve
+	}
+	if n > arenaChunk {
+		a.buf = make([]float64, 0, n)
+	}
+	return &a
+}
+
+// Append appends v to fs, which must be empty
+// or the result of the previous call to append,
+// and returns the extended slice.
+func (a *floatArena) append(fs []float64, v float64) []float64 {
+	if len(a.buf) == cap(a.buf) {
+		n := arenaChunk
+		if 2*len(fs) > n {
+			n = 2 * len(fs)
+		}
+		a.buf = append(make([]float64, 0, n), fs...)
+	}
+	a.buf = append(a.buf, v)
+	return a.buf[len(a.buf)-len(fs)-1 : len(a.buf) : len(a.buf)]
+}
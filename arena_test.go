@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+// TestFloatArenaReserve tests that newFloatArena reserves at most maxArenaReserve values,
+// however large the input, and that the arena grows beyond them as values are appended.
+func TestFloatArenaReserve(t *testing.T) {
+	a := newFloatArena(1 << 40)
+	if n := cap(a.buf); n != maxArenaReserve {
+		t.Fatalf("reserved %d values, want %d", n, maxArenaReserve)
+	}
+	var fs []float64
+	for i := 0; i < maxArenaReserve+10; i++ {
+		fs = a.append(fs, float64(i))
+	}
+	if len(fs) != maxArenaReserve+10 || fs[0] != 0 || fs[len(fs)-1] != maxArenaReserve+9 {
+		t.Errorf("got %d values from %v to %v, want %d from 0 to %d",
+			len(fs), fs[0], fs[len(fs)-1], maxArenaReserve+10, maxArenaReserve+9)
+	}
+}
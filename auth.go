@@ -0,0 +1,55 @@
+package main
+
+import (
+	"crypto/subtle"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// ReadTokens returns the bearer tokens given by a comma-separated list
+// and by a file with one token per line.
+// Blank lines and lines beginning with # in the file are ignored.
+func readTokens(list, path string) ([]string, error) {
+	var tokens []string
+	for _, t := range strings.Split(list, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			tokens = append(tokens, t)
+		}
+	}
+	if path == "" {
+		return tokens, nil
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" && !strings.HasPrefix(line, "#") {
+			tokens = append(tokens, line)
+		}
+	}
+	return tokens, nil
+}
+
+// RequireToken returns a handler that serves requests with h
+// if they carry one of the tokens in an Authorization: Bearer header,
+// and otherwise responds with 401 Unauthorized.
+// Tokens are compared in constant time.
+func requireToken(tokens []string, h http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if strings.HasPrefix(auth, "Bearer ") {
+			got := []byte(strings.TrimPrefix(auth, "Bearer "))
+			for _, t := range tokens {
+				if subtle.ConstantTimeCompare(got, []byte(t)) == 1 {
+					h.ServeHTTP(w, r)
+					return
+				}
+			}
+		}
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		httpStatusError(w, http.StatusUnauthorized, "unauthorized")
+	})
+}
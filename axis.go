@@ -0,0 +1,209 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	// AxisWidth is the width of the strip at the left of a panel
+	// holding the value axis and its tick labels.
+	axisWidth = 0.08
+	// TickLength is the length of the tick marks of the value axis.
+	tickLength = 0.01
+	// AxisTicks is the approximate number of ticks on a value axis.
+	axisTicks = 5
+)
+
+var (
+	// TickValues are the values of the -yticks, if any,
+	// and tickLabels are their labels, from -ytick-labels,
+	// or formatted from the values.
+	tickValues []float64
+	tickLabels []string
+)
+
+// CheckTicks returns an error if -yticks or -ytick-labels is malformed,
+// and otherwise sets tickValues and tickLabels from them.
+func checkTicks() error {
+	if *yTicks == "" {
+		if *yTickLabels != "" {
+			return fmt.Errorf("-ytick-labels needs -yticks")
+		}
+		return nil
+	}
+	tickValues, tickLabels = nil, nil
+	for _, s := range strings.Split(*yTicks, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("Bad -yticks value: %s", s)
+		}
+		tickValues = append(tickValues, v)
+		tickLabels = append(tickLabels, formatValue(v))
+	}
+	if *yTickLabels != "" {
+		labels := strings.Split(*yTickLabels, ",")
+		if len(labels) != len(tickValues) {
+			return fmt.Errorf("-ytick-labels has %d labels for %d -yticks", len(labels), len(tickValues))
+		}
+		tickLabels = labels
+	}
+	return nil
+}
+
+// NiceTicks returns about n tick values spanning min to max,
+// evenly spaced by 1, 2, or 5 times a power of ten,
+// from the first multiple of the spacing at or above min
+// to the last at or below max.
+func niceTicks(min, max float64, n int) []float64 {
+	if !(max > min) || math.IsInf(max-min, 0) {
+		return []float64{min}
+	}
+	rough := (max - min) / float64(n)
+	exp := math.Floor(math.Log10(rough))
+	mult := 10.0
+	for _, m := range []float64{1, 2, 5} {
+		if m*math.Pow(10, exp) >= rough {
+			mult = m
+			break
+		}
+	}
+	// Ticks are multiples of mult·10^exp, computed as k·mult/10^-exp
+	// when exp is negative, so that they are as near as possible
+	// to the decimal values that they are labeled with.
+	tick := func(k float64) float64 {
+		if exp < 0 {
+			return k * mult / math.Pow(10, -exp)
+		}
+		return k * mult * math.Pow(10, exp)
+	}
+	step := tick(1)
+	var ticks []float64
+	for k := math.Ceil(min / step); tick(k) <= max+step*1e-9; k++ {
+		ticks = append(ticks, tick(k))
+	}
+	return ticks
+}
+
+// LogTicks returns tick values spanning min to max on a -log scale:
+// the powers of ten within it, every so many decades
+// so that there are no more than about twice axisTicks,
+// or, if there are fewer than 3, 1, 2, and 5 times the powers of ten,
+// or, if there are still fewer than 3, the niceTicks.
+// Min must be positive.
+func logTicks(min, max float64) []float64 {
+	lo, hi := math.Floor(math.Log10(min)), math.Ceil(math.Log10(max))
+	every := math.Max(1, math.Ceil((hi-lo)/(2*axisTicks)))
+	for _, mults := range [][]float64{{1}, {1, 2, 5}} {
+		var ticks []float64
+		for e := lo; e <= hi; e++ {
+			if len(mults) == 1 && math.Mod(e, every) != 0 {
+				continue
+			}
+			for _, m := range mults {
+				if v := m * math.Pow(10, e); v >= min && v <= max {
+					ticks = append(ticks, v)
+				}
+			}
+		}
+		if len(ticks) >= 3 {
+			return ticks
+		}
+	}
+	return niceTicks(min, max, axisTicks)
+}
+
+// Log2Ticks returns tick values spanning min to max on a -log2 scale:
+// the powers of two within it, every so many doublings
+// so that there are no more than about twice axisTicks,
+// or, if there are fewer than 2, the niceTicks.
+// Min must be positive.
+func log2Ticks(min, max float64) []float64 {
+	lo, hi := math.Ceil(math.Log2(min)), math.Floor(math.Log2(max))
+	every := math.Max(1, math.Ceil((hi-lo+1)/(2*axisTicks)))
+	var ticks []float64
+	for e := lo; e <= hi; e++ {
+		if math.Mod(e, every) == 0 {
+			ticks = append(ticks, math.Exp2(e))
+		}
+	}
+	if len(ticks) < 2 {
+		return niceTicks(min, max, axisTicks)
+	}
+	return ticks
+}
+
+// BinarySuffixes are the suffixes of the powers of 1024, from 1024 up.
+var binarySuffixes = []string{"Ki", "Mi", "Gi", "Ti", "Pi", "Ei"}
+
+// BinaryValue returns a value formatted for a -log2 axis:
+// with a binary suffix, as in 4Ki or 2Mi,
+// if it is a power of two of at least 1024,
+// and otherwise as by formatValue.
+func binaryValue(v float64) string {
+	frac, exp := math.Frexp(v)
+	if v < 1024 || frac != 0.5 {
+		return formatValue(v)
+	}
+	// V is 2^(exp-1).
+	e := exp - 1
+	i := e/10 - 1
+	if i >= len(binarySuffixes) {
+		i = len(binarySuffixes) - 1
+	}
+	return formatValue(math.Exp2(float64(e-10*(i+1)))) + binarySuffixes[i]
+}
+
+// DrawAxis draws a value axis along the right edge of a strip,
+// from bottom to top, with tick marks and labels at nice values,
+// or at powers of ten with -log,
+// or at powers of two with -log2,
+// or at the -yticks within the range, labeled with the -ytick-labels.
+func drawAxis(cv canvas, r rect, bottom, top, min, max float64, tr func(float64) float64) {
+	x := r.x1
+	cv.line("axis", x, bottom, x, top)
+	ticks, labels := axisLabels(min, max)
+	for i, v := range ticks {
+		y := tr(v)
+		cv.line("axis", x-tickLength, y, x, y)
+		cv.text("tick", x-tickLength, y, 'R', labels[i])
+	}
+}
+
+// AxisLabels returns the values and labels of the ticks of a value axis from min to max,
+// as drawAxis draws them.
+// An infinite or NaN end, as of infinite values, has no ticks to generate,
+// and the loops generating them would never end, so only -yticks are drawn.
+func axisLabels(min, max float64) (ticks []float64, labels []string) {
+	finite := !math.IsInf(min, 0) && !math.IsInf(max, 0) && !math.IsNaN(min) && !math.IsNaN(max)
+	if finite {
+		ticks = niceTicks(min, max, axisTicks)
+	}
+	switch {
+	case tickValues != nil || !finite:
+		ticks = nil
+	case *logScale && *logZero == "symlog":
+		ticks = symlogTicks(min, max)
+	case *log2Scale && math.Max(min, logFloor) > 0:
+		ticks = log2Ticks(math.Max(min, logFloor), max)
+	case *logScale && math.Max(min, logFloor) > 0:
+		ticks = logTicks(math.Max(min, logFloor), max)
+	}
+	labels = make([]string, len(ticks))
+	for i, v := range ticks {
+		labels[i] = formatValue(v)
+		if *log2Scale {
+			labels[i] = binaryValue(v)
+		}
+	}
+	for i, v := range tickValues {
+		if v < min || v > max || *logScale && *logZero != "symlog" && v <= logFloor {
+			continue
+		}
+		ticks = append(ticks, v)
+		labels = append(labels, tickLabels[i])
+	}
+	return ticks, labels
+}
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"flag"
+	"math"
+	"testing"
+)
+
+// TestAxisLabelsNonFinite tests that the axis of an infinite range has no ticks
+// instead of generating them forever.
+func TestAxisLabelsNonFinite(t *testing.T) {
+	defer resetFlags()
+	for _, scale := range []string{"", "log", "log2"} {
+		resetFlags()
+		if scale != "" {
+			if err := flag.Set(scale, "true"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, r := range [][2]float64{{1, math.Inf(1)}, {math.Inf(-1), 2}, {math.NaN(), 2}} {
+			if ticks, _ := axisLabels(r[0], r[1]); len(ticks) != 0 {
+				t.Errorf("-%s: axisLabels(%v, %v) has ticks %v, want none", scale, r[0], r[1], ticks)
+			}
+		}
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ReadJMH reads JMH JSON results, with one box per benchmark
+// named by its class, method, and parameters.
+// The box values are the raw iteration scores of the primary metric,
+// or its raw histogram in sample time mode.
+func readJMH(r io.Reader) ([]box, error) {
+	var results []struct {
+		Benchmark     string
+		Params        map[string]string
+		PrimaryMetric struct {
+			RawData          [][]float64
+			RawDataHistogram [][][][2]float64
+		}
+	}
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	var boxes []box
+	for _, res := range results {
+		name := jmhName(res.Benchmark, res.Params)
+		m := res.PrimaryMetric
+		if len(m.RawDataHistogram) > 0 {
+			d := &digest{}
+			for _, fork := range m.RawDataHistogram {
+				for _, iter := range fork {
+					for _, vc := range iter {
+						d.centroids = append(d.centroids, centroid{mean: vc[0], weight: vc[1]})
+					}
+				}
+			}
+			sortCentroids(d)
+			boxes = append(boxes, digestBox(name, d))
+			continue
+		}
+		var vs []float64
+		for _, fork := range m.RawData {
+			vs = append(vs, fork...)
+		}
+		boxes = append(boxes, newBox(name, vs))
+	}
+	return boxes, nil
+}
+
+// JmhName returns a box name for a JMH benchmark:
+// the simple class name and method,
+// followed by the sorted parameters, if any.
+func jmhName(bench string, params map[string]string) string {
+	if i := strings.LastIndex(bench, "."); i >= 0 {
+		if j := strings.LastIndex(bench[:i], "."); j >= 0 {
+			bench = bench[j+1:]
+		}
+	}
+	var ps []string
+	for k, v := range params {
+		ps = append(ps, k+"="+v)
+	}
+	sort.Strings(ps)
+	if len(ps) > 0 {
+		bench += ":" + strings.Join(ps, ",")
+	}
+	return bench
+}
+
+// ReadPytest reads pytest-benchmark JSON results, with one box per benchmark.
+// The box values are the raw timings if they were saved
+// with --benchmark-save-data.
+// Otherwise the box is drawn from the recorded summary statistics.
+func readPytest(r io.Reader) ([]box, error) {
+	var results struct {
+		Benchmarks []struct {
+			Name  string
+			Stats struct {
+				Min, Q1, Median, Q3, Max float64
+				Mean, Stddev             float64
+				Rounds                   int
+				Data                     []float64
+			}
+		}
+	}
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	if results.Benchmarks == nil {
+		return nil, errors.New("no pytest-benchmark benchmarks")
+	}
+	var boxes []box
+	for _, bench := range results.Benchmarks {
+		s := bench.Stats
+		if len(s.Data) > 0 {
+			boxes = append(boxes, newBox(bench.Name, s.Data))
+			continue
+		}
+		boxes = append(boxes, box{
+			name:   bench.Name,
+			min:    s.Min,
+			q1:     s.Q1,
+			q2:     s.Median,
+			q3:     s.Q3,
+			max:    s.Max,
+			n:      s.Rounds,
+			mean:   s.Mean,
+			stddev: s.Stddev,
+		})
+	}
+	return boxes, nil
+}
+
+// ReadHyperfine reads the JSON written by hyperfine --export-json,
+// with one box per command, whose values are the command's run times.
+func readHyperfine(r io.Reader) ([]box, error) {
+	var results struct {
+		Results []struct {
+			Command string
+			Times   []float64
+		}
+	}
+	if err := json.NewDecoder(r).Decode(&results); err != nil {
+		return nil, err
+	}
+	if results.Results == nil {
+		return nil, errors.New("no hyperfine results")
+	}
+	var boxes []box
+	for _, res := range results.Results {
+		boxes = append(boxes, newBox(res.Command, res.Times))
+	}
+	return boxes, nil
+}
+
+// ReadCriterion reads a stream of Criterion.rs JSON objects.
+// Benchmark-complete messages from cargo criterion --message-format=json
+// give a box named by the benchmark ID,
+// and the sample.json files of a criterion benchmark directory
+// give boxes named criterion1, criterion2, and so on.
+// In both cases, the box values are the per-iteration times of each sample.
+// Other messages are ignored.
+// (The estimates.json files only hold point estimates, not distributions.)
+func readCriterion(r io.Reader) ([]box, error) {
+	var boxes []box
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		var msg struct {
+			Reason         string
+			ID             string
+			IterationCount []float64 `json:"iteration_count"`
+			MeasuredValues []float64 `json:"measured_values"`
+			Iters, Times   []float64
+		}
+		if err := dec.Decode(&msg); err != nil {
+			return nil, err
+		}
+		var name string
+		var iters, times []float64
+		switch {
+		case msg.Reason == "benchmark-complete":
+			name, iters, times = msg.ID, msg.IterationCount, msg.MeasuredValues
+		case msg.Iters != nil:
+			name, iters, times = fmt.Sprintf("criterion%d", len(boxes)+1), msg.Iters, msg.Times
+		default:
+			continue
+		}
+		if len(iters) != len(times) {
+			return nil, fmt.Errorf("%s: %d iteration counts for %d times", name, len(iters), len(times))
+		}
+		vs := make([]float64, len(times))
+		for i := range times {
+			vs[i] = times[i] / iters[i]
+		}
+		boxes = append(boxes, newBox(name, vs))
+	}
+	return boxes, nil
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+// CheckSummaries reports an error unless the boxes have the given names
+// and five-number summaries, after summarizing those with pending statistics.
+func checkSummaries(t *testing.T, what string, boxes []box, names []string, want [][5]float64) {
+	t.Helper()
+	summarize(boxes)
+	if len(boxes) != len(names) {
+		t.Errorf("%s: got %d boxes, want %d", what, len(boxes), len(names))
+		return
+	}
+	for i, b := range boxes {
+		got := [5]float64{b.min, b.q1, b.q2, b.q3, b.max}
+		for j := range got {
+			if math.Abs(got[j]-want[i][j]) > 1e-9 {
+				t.Errorf("%s: %s five-number summary %v, want %v", what, b.name, got, want[i])
+				break
+			}
+		}
+		if b.name != names[i] {
+			t.Errorf("%s: box %d named %s, want %s", what, i, b.name, names[i])
+		}
+	}
+}
+
+func TestReadJMH(t *testing.T) {
+	input := `[
+	{
+		"benchmark": "org.example.codec.Bench.parse",
+		"mode": "thrpt",
+		"params": {"size": "10", "kind": "json"},
+		"primaryMetric": {"score": 3, "rawData": [[1, 2, 3], [4, 5]]}
+	},
+	{
+		"benchmark": "org.example.codec.Bench.encode",
+		"mode": "sample",
+		"primaryMetric": {"score": 20, "rawDataHistogram": [[[[10, 1], [20, 1]], [[20, 1], [30, 1]]]]}
+	}
+]`
+	boxes, err := readJMH(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSummaries(t, "JMH", boxes,
+		[]string{"Bench.parse:kind=json,size=10", "Bench.encode"},
+		[][5]float64{{1, 2, 3, 4, 5}, {10, 15, 20, 25, 30}})
+}
+
+func TestReadPytest(t *testing.T) {
+	input := `{
+	"machine_info": {"node": "ci"},
+	"benchmarks": [
+		{"name": "test_parse", "stats": {"min": 1, "max": 5, "mean": 3, "rounds": 5, "data": [5, 4, 3, 2, 1]}},
+		{"name": "test_encode", "stats": {"min": 1, "q1": 2, "median": 2.5, "q3": 4, "max": 9, "mean": 3.5, "stddev": 1.5, "rounds": 40}}
+	]
+}`
+	boxes, err := readPytest(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSummaries(t, "pytest-benchmark", boxes,
+		[]string{"test_parse", "test_encode"},
+		[][5]float64{{1, 2, 3, 4, 5}, {1, 2, 2.5, 4, 9}})
+	if len(boxes) == 2 && boxes[1].n != 40 {
+		t.Errorf("test_encode has %d rounds, want 40", boxes[1].n)
+	}
+}
+
+// TestReadBenchJSONMalformed tests that malformed JMH and pytest-benchmark JSON is an error.
+func TestReadBenchJSONMalformed(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		input  string
+	}{
+		{"jmh", ``},
+		{"jmh", `[{"benchmark": "a.B.c"`},
+		{"jmh", `{"benchmark": "a.B.c"}`},
+		{"jmh", `[{"benchmark": "a.B.c", "primaryMetric": {"rawData": [["x"]]}}]`},
+		{"pytest", ``},
+		{"pytest", `{"benchmarks": [`},
+		{"pytest", `{"machine_info": {}}`},
+		{"pytest", `{"benchmarks": [{"name": "a", "stats": {"data": "x"}}]}`},
+	} {
+		if _, err := readers[test.format](strings.NewReader(test.input)); err == nil {
+			t.Errorf("-format %s %q succeeded, want an error", test.format, test.input)
+		}
+	}
+}
+
+func TestReadHyperfine(t *testing.T) {
+	input := `{"results": [
+	{"command": "gzip -1", "mean": 3, "times": [5, 1, 4, 2, 3], "exit_codes": [0, 0, 0, 0, 0]},
+	{"command": "gzip -9", "mean": 30, "times": [30, 10, 20, 40, 50]}
+]}`
+	boxes, err := readHyperfine(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSummaries(t, "hyperfine", boxes,
+		[]string{"gzip -1", "gzip -9"},
+		[][5]float64{{1, 2, 3, 4, 5}, {10, 20, 30, 40, 50}})
+}
+
+func TestReadCriterion(t *testing.T) {
+	// A benchmark-complete message of cargo criterion, another message,
+	// and a sample.json file, whose values are the times per iteration.
+	input := `{"reason": "benchmark-complete", "id": "parse/10", "iteration_count": [1, 2, 3, 4, 5], "measured_values": [1, 4, 9, 16, 25]}
+{"reason": "group-complete", "group_name": "parse"}
+{"sampling_mode": "Linear", "iters": [2, 4, 6, 8, 10], "times": [20, 60, 120, 200, 300]}
+`
+	boxes, err := readCriterion(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkSummaries(t, "Criterion.rs", boxes,
+		[]string{"parse/10", "criterion2"},
+		[][5]float64{{1, 2, 3, 4, 5}, {10, 15, 20, 25, 30}})
+}
+
+// TestReadHyperfineCriterionMalformed tests that malformed hyperfine
+// and Criterion.rs JSON is an error.
+func TestReadHyperfineCriterionMalformed(t *testing.T) {
+	for _, test := range []struct {
+		format string
+		input  string
+	}{
+		{"hyperfine", ``},
+		{"hyperfine", `{"results": [{"command": "a"`},
+		{"hyperfine", `{"commands": []}`},
+		{"hyperfine", `{"results": [{"command": "a", "times": ["x"]}]}`},
+		{"criterion", `{"reason": "benchmark-complete"`},
+		{"criterion", `{"reason": "benchmark-complete", "id": "a", "iteration_count": [1, 2], "measured_values": [1]}`},
+		{"criterion", `{"iters": [1], "times": [1, 2]}`},
+		{"criterion", `[1, 2]`},
+	} {
+		if _, err := readers[test.format](strings.NewReader(test.input)); err == nil {
+			t.Errorf("-format %s %q succeeded, want an error", test.format, test.input)
+		}
+	}
+}
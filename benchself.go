@@ -0,0 +1,138 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// BenchSelf runs the bench-self command with the given arguments
+// and returns the exit status.
+//
+// Bench-self measures the throughput of box itself
+// on generated corpora of every combination of
+// a total number of values and a number of data sets,
+// by default 1e3, 1e6, and 1e8 values in 2, 50, and 1000 data sets.
+// The corpora are generated from a fixed seed in the tokens format,
+// so they are the same on every run without being checked in;
+// the largest corpus, of 1e8 values, takes about a gigabyte,
+// and -values 1e3,1e6 leaves it out for a quicker run.
+//
+// Each corpus is timed in three phases:
+// parse, reading the data sets with readBoxes, as the tokens format does;
+// stats, computing the summary statistics of each data set;
+// and render, drawing the plot(1) output.
+// The results are printed in the format of go test -bench,
+// so they can be compared across versions with benchstat.
+func benchSelf(args []string) int {
+	fs := flag.NewFlagSet("bench-self", flag.ContinueOnError)
+	valuesList := fs.String("values", "1e3,1e6,1e8", "comma-separated total numbers of values")
+	setsList := fs.String("sets", "2,50,1000", "comma-separated numbers of data sets")
+	minTime := fs.Duration("benchtime", time.Second, "minimum time to run each phase")
+	parseFlags(fs, args)
+	values, err := parseCounts(*valuesList)
+	if err == nil {
+		var sets []int
+		if sets, err = parseCounts(*setsList); err == nil {
+			for _, v := range values {
+				for _, s := range sets {
+					if s <= v {
+						benchCorpus(v, s, *minTime)
+					}
+				}
+			}
+			return 0
+		}
+	}
+	fmt.Fprintf(os.Stderr, "box bench-self: %v\n", err)
+	return 1
+}
+
+// ParseCounts parses a comma-separated list of counts,
+// which may be written in floating point notation, such as 1e6.
+func parseCounts(s string) ([]int, error) {
+	var ns []int
+	for _, f := range strings.Split(s, ",") {
+		v, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || v < 1 {
+			return nil, fmt.Errorf("bad count %q", f)
+		}
+		ns = append(ns, int(v))
+	}
+	return ns, nil
+}
+
+// BenchCorpus generates a corpus of n values in sets data sets
+// and prints the throughput of each phase on it.
+func benchCorpus(n, sets int, minTime time.Duration) {
+	bs := &benchState{input: benchInput(n, sets)}
+	name := fmt.Sprintf("values=%d/sets=%d", n, sets)
+	benchPhase("Parse/"+name, len(bs.input), minTime, bs.parse)
+	benchPhase("Stats/"+name, len(bs.input), minTime, bs.stats)
+	benchPhase("Render/"+name, len(bs.input), minTime, bs.render)
+}
+
+// A benchState is a corpus of bench-self and the result of each phase on it,
+// with which the next phase begins.
+type benchState struct {
+	input []byte
+	boxes []box
+}
+
+// Parse reads the boxes of the corpus with readBoxes, as the tokens format does.
+func (bs *benchState) parse() {
+	bs.boxes, _ = readBoxes(bytes.NewReader(bs.input))
+}
+
+// Stats computes the summary statistics of the boxes with summarize.
+func (bs *benchState) stats() {
+	for i := range bs.boxes {
+		bs.boxes[i].pending = bs.boxes[i].n > 0
+	}
+	summarize(bs.boxes)
+}
+
+// Render draws the plot(1) output of the boxes.
+func (bs *benchState) render() {
+	draw(bs.boxes, "", ioutil.Discard)
+}
+
+// BenchInput returns a tokens-format corpus of n values in sets data sets,
+// each drawn from a log-normal distribution with its own location.
+func benchInput(n, sets int) []byte {
+	rng := rand.New(rand.NewSource(1))
+	var buf bytes.Buffer
+	for i := 0; i < sets; i++ {
+		fmt.Fprintf(&buf, "set%d", i)
+		loc := rng.Float64() * 3
+		for j := i * n / sets; j < (i+1)*n/sets; j++ {
+			buf.WriteByte(' ')
+			v := math.Exp(rng.NormFloat64()*0.5 + loc)
+			buf.WriteString(strconv.FormatFloat(v, 'g', 6, 64))
+		}
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// BenchPhase runs f repeatedly for at least minTime
+// and prints its time per run and its throughput over size bytes of input.
+func benchPhase(name string, size int, minTime time.Duration, f func()) {
+	runs := 0
+	start := time.Now()
+	for runs == 0 || time.Since(start) < minTime {
+		f()
+		runs++
+	}
+	elapsed := time.Since(start)
+	perRun := elapsed / time.Duration(runs)
+	mbps := float64(size) * float64(runs) / 1e6 / elapsed.Seconds()
+	fmt.Printf("BenchmarkBox%s\t%8d\t%12d ns/op\t%8.2f MB/s\n", name, runs, perRun.Nanoseconds(), mbps)
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+// The benchmarks measure the phases of bench-self
+// on its corpus of 1e6 values in 50 data sets.
+const benchValues, benchSets = 1000000, 50
+
+// BenchFloors are the least throughputs, in MB/s of input, of the phases,
+// about a fifth of their throughputs on a laptop,
+// below which TestBenchRegression fails when $BOX_BENCH_FLOORS is set.
+var benchFloors = map[string]float64{
+	"Parse":  15,
+	"Stats":  35,
+	"Render": 5000,
+}
+
+// BenchCorpusState is the corpus of the benchmarks, generated once.
+var benchCorpusState *benchState
+
+// BenchmarkPhase benchmarks a phase of bench-self,
+// running the phases before it once to set it up.
+func benchmarkPhase(b *testing.B, phase string) {
+	if benchCorpusState == nil {
+		benchCorpusState = &benchState{input: benchInput(benchValues, benchSets)}
+	}
+	bs := benchCorpusState
+	phases := []struct {
+		name string
+		run  func()
+	}{{"Parse", bs.parse}, {"Stats", bs.stats}, {"Render", bs.render}}
+	for _, p := range phases {
+		if p.name == phase {
+			b.SetBytes(int64(len(bs.input)))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				p.run()
+			}
+			return
+		}
+		p.run()
+	}
+	b.Fatalf("no phase %s", phase)
+}
+
+func BenchmarkParse(b *testing.B)  { benchmarkPhase(b, "Parse") }
+func BenchmarkStats(b *testing.B)  { benchmarkPhase(b, "Stats") }
+func BenchmarkRender(b *testing.B) { benchmarkPhase(b, "Render") }
+
+// TestBenchRegression tests that no phase of bench-self
+// is slower than its floor in benchFloors.
+// The floors are of wall-clock time, too tight for -race or shared runners,
+// so they are only tested if $BOX_BENCH_FLOORS is set,
+// which, unlike a flag, resetFlags leaves alone.
+func TestBenchRegression(t *testing.T) {
+	if os.Getenv("BOX_BENCH_FLOORS") == "" {
+		t.Skip("the floors are tested only with $BOX_BENCH_FLOORS set")
+	}
+	for phase, floor := range benchFloors {
+		phase := phase
+		r := testing.Benchmark(func(b *testing.B) { benchmarkPhase(b, phase) })
+		if r.N == 0 {
+			t.Fatalf("%s: benchmark failed", phase)
+		}
+		mbps := float64(r.Bytes) * float64(r.N) / 1e6 / r.T.Seconds()
+		if mbps < floor {
+			t.Errorf("%s: %.2f MB/s, want at least %.2f MB/s", phase, mbps, floor)
+		}
+	}
+}
@@ -3,50 +3,1248 @@
 // and outputs a series of box plots for plot(1) on standard output.
 //
 // Example:
-// 	echo "linear 1 2 3 4 5 6 exponential 2 4 8 16 32 64" | box -t Title | plot
+//
+//	echo "linear 1 2 3 4 5 6 exponential 2 4 8 16 32 64" | box -t Title | plot
+//
 // shows two box plots,
 // one labeled "linear", showing the distribution of the numbers 1 2 3 4 5 6,
 // and one labeled "exponential" showing the distribution of 2 4 8 16 32 64.
+//
+// With -run, box starts plot itself and pipes its output into it,
+// so box -run < data shows the plot without typing the pipeline.
+// The -plotcmd flag sets the command, with its arguments separated by spaces,
+// such as -plotcmd '9 plot' to run plan9port's plot, or a viewer of another -o format,
+// and box exits with status 4 if the command cannot be started or fails.
+//
+// A data set ends at the first token that is not a number,
+// or at the separator token set by -sep, -- by default.
+// The token after a separator is always a name,
+// so names that look like numbers can be used by separating the data sets:
+//
+//	echo "1.18 1 2 3 -- 1.19 4 5 6" | box | plot
+//
+// A name may also be in double quotes, so it can contain white space
+// or look like a number; a quoted token is always a name,
+// and \" within it is a quote:
+//
+//	echo '"read latency" 1 2 3 "2018" 4 5 6' | box | plot
+//
+// Alternatively, -names gives a comma-separated list of names,
+// and every token of the input is a value.
+// The values are divided among the names by separators if there are any,
+// and are otherwise split evenly:
+//
+//	echo "1 2 3 4 5 6" | box -names 1,2 | plot
+//
+// Files named as arguments are read in turn in place of standard input,
+// each in the -format, which is detected separately for each file by default.
+// With -basename, the data set of each file is named by its base name
+// without its extension, and every token of a file is a value;
+// the data sets of a file in another format are named <base>.<name>.
+// Runs of a benchmark saved in separate files can be compared directly:
+//
+//	box -basename before.dat after.dat | plot
+//
+// A value of the form >N, such as >5000 for a timeout, is censored:
+// it is known only to be above N.
+// Censored values are counted and drawn at their limits,
+// each limit is marked on the box by a triangle pointing up to it,
+// and the box is noted with the count and with km=, its Kaplan-Meier median,
+// which accounts for the censoring,
+// or km> the largest limit, if more than half the values may lie above it.
+// Box warns that the box itself, drawn with the limits as values, is biased low.
+//
+// With -log, values are drawn on a logarithmic scale,
+// for data spanning several orders of magnitude, such as latencies;
+// the statistics are still those of the raw values,
+// and the -axis ticks are at powers of ten.
+// Every value must then be positive, unless -log-zero says what to do with the others:
+// drop removes them before the statistics are computed, warning how many;
+// epsilon draws them at a floor a decade below the smallest positive value;
+// and symlog draws on a symmetric logarithmic scale, sign(v)·log10(1+|v|/c),
+// where c is the smallest non-zero magnitude,
+// so that zero and negative values have their place,
+// with -axis ticks at zero and at powers of ten on either side.
+//
+// With -log2, values are drawn on a logarithmic scale, as with -log,
+// but the -axis ticks are at powers of two, labeled with binary suffixes, as in 1Ki, 2Ki, and 4Ki,
+// the natural scale for buffer sizes, batch sizes, and thread counts.
+//
+// With -horizontal, the boxes are drawn left to right along a horizontal value scale,
+// one above another, with their names at their left,
+// which leaves room for long names.
+// The value labels of a box are below it, its notes are listed to its right,
+// and the -axis is at the bottom.
+// It does not apply to -html, and cannot be used with -inset.
+//
+// With -axis, each panel has a value axis at its left,
+// with tick marks and labels at round values, spaced by 1, 2, or 5 times a power of ten,
+// so that boxes can be read against a common scale
+// as well as by the labels of their own quartiles.
+//
+// With -yticks, such as -yticks 0,100,250,500, the axis ticks are at the given values instead,
+// for scales with conventions of their own, such as latency thresholds or powers of two,
+// and -ytick-labels, such as -ytick-labels 0,p50,p99,timeout, labels them in order.
+// Ticks outside the range of the values are left out.
+//
+// With -ymin and -ymax, the value range is pinned at either end
+// instead of spanning the values, so that plots made at different times,
+// such as the frames of a benchmark history, share a scale and can be compared.
+// Parts of a box beyond the range are drawn at its edge, and box warns of them.
+//
+// With -robust-range, the ends of the value range not pinned by -ymin or -ymax
+// leave out the lowest and highest 0.5% of the values of each data set,
+// so that a single 10-second garbage collection pause does not squeeze
+// millisecond-scale boxes into a sliver of the plot.
+// An end is only moved if it narrows the range by at least a tenth,
+// and the values beyond it are drawn at its edge and counted in notes,
+// such as 3 above, over their boxes.
+//
+// With -mean-ci, each box also shows its mean as a small circle,
+// with an error bar giving the confidence interval of the mean
+// from Student's t distribution at the -ci-level confidence level, 95% by default.
+//
+// With -notch, each box is notched at its median ± 1.57·IQR/√n,
+// the conventional visual test of medians:
+// boxes whose notches do not overlap have medians that differ, at roughly 95% confidence.
+// A notch reaching past a quartile is cut off at the edge of the box.
+//
+// By default, the quartiles are Tukey's hinges, the medians of the lower and upper halves
+// of the values, as computed by R's fivenum.
+// With -quantile-method, they are instead the quantiles of one of the nine
+// Hyndman-Fan types of R's quantile function, given by number, so that they match other tools:
+// 7 is the default of R, NumPy, and pandas, 8 is the median-unbiased type that Hyndman and Fan recommend,
+// and 2 averages at the discontinuities of the empirical distribution, as in SAS's default.
+// Quartiles of sketches and summaries are as their input gives them, and -exact computes only hinges.
+//
+// With -trend file, box also writes a small companion chart to the file, in the -o format:
+// the median of each data set, in order, joined by a line within a band from its first to its third quartile.
+// With data sets in the order of time or version, such as dates or releases, or sorted by -sort name,
+// it summarizes their box plots as a trend, for pasting into status updates.
+//
+// With -stats, box writes, instead of plotting, a table of the numbers the plot would show:
+// a header row and then a row for each data set, in plotting order,
+// of its name, n, min, q1, median, q3, max, mean, and stddev, as tab-separated values,
+// formatted with -precision, so that -stats -precision -1 gives them in full.
+// With -stats-format json, the table is instead a JSON array of an object for each data set,
+// with the same names as the columns, and the same values as numbers, or null if undefined,
+// for dashboards and tests to read.
+//
+// With -explain <name>, box writes, instead of plotting, how each statistic of the named data set
+// was computed, to settle why it does not match another tool:
+// the 1-based sorted positions and values from which each quartile was taken,
+// with their interpolation weights; the whisker rule and its cutoffs; and the outliers.
+//
+// By default, the whiskers of a box end at its minimum and maximum.
+// With -whiskers tukey, they end at the most extreme values
+// within 1.5 times the interquartile range of the quartiles,
+// or the multiple set by -whisker-k,
+// and the values beyond are drawn as individual outlier points.
+// With -whiskers p5,p95, or any other pair of percentiles,
+// they end at those percentiles of the values,
+// with the values beyond drawn as outlier points.
+// Boxes read from sketches or summaries have no values to test,
+// so they have no outliers, and their tukey whiskers end at their minimum and maximum;
+// percentile whiskers of sketches are estimated from the sketch.
+//
+// With -heat all, each box is hatched more densely
+// the farther its median is from the median of the medians of all boxes,
+// from none for the nearest to densest for the farthest,
+// so that many boxes read as a heat map.
+// With -heat REGEX, the reference is instead the median of the medians
+// of the baseline boxes whose names match the regular expression,
+// as in -heat '^main$'.
+//
+// With -inset REGEX, the data sets whose names match the regular expression
+// are drawn again in an inset panel to the right of the plot,
+// at a scale of their own,
+// and a rectangle on the plot, joined to the inset by lines,
+// shows the range of values that the inset enlarges.
+// This keeps boxes with small values visible beside a much larger one,
+// as in -inset '^(a|b)$'.
+// The inset cannot be used with -matrix.
+//
+// With -runorder, instead of a box,
+// each data set is drawn as a line of its values in input order,
+// in its own panel with a shared scale,
+// which can reveal warm-up trends or drift that a box would hide.
+//
+// With -autocorr r, box warns of, and marks the boxes of,
+// data sets whose lag-1 autocorrelation in input order
+// has a magnitude greater than r.
+// Such samples are not independent,
+// so confidence intervals and significance tests on them are invalid.
+//
+// With -modes, box warns of, and marks the boxes of,
+// data sets whose kernel density estimate has more than one peak,
+// since a box plot cannot show a multimodal distribution.
+//
+// With -lint, box warns of common problems in the data before plotting it,
+// since garbage in makes a confident-looking plot:
+// data sets with the same name, without values, or with every value the same;
+// sample counts differing tenfold or more;
+// and medians differing by about a power of 1000, as when one data set is in nanoseconds
+// and another in microseconds. Each warning says what to check.
+//
+// With -matrix, each data set name is of the form <row>.<column>,
+// and each box is drawn in its own panel of a grid of rows and columns,
+// for showing experiments with two factors.
+// Each panel has its own scale unless -share-y is set.
+// The title and legend are drawn once for the whole figure.
+//
+// The -group-sep flag defines groups of data sets:
+// the group of a data set is the part of its name before the separator.
+// The backgrounds of every other run of consecutive boxes in the same group
+// are shaded, so that group boundaries are visible.
+//
+// Each panel of a -matrix and each group is captioned in its top-right corner.
+// With -captions, the caption includes the total sample count.
+// The -annotations flag names a file of further captions:
+// each line is a panel name, of the form <row>.<column>, or a group name,
+// followed by white space and the caption.
+//
+// The -text flag places a line of text in a named region of the figure,
+// as -text region:text, for example -text 'topright:run #42'.
+// The regions are topleft, top, and topright, in a strip above the title,
+// and bottomleft, bottom, and bottomright, in a strip below the legend.
+// The flag may be repeated; texts in the same region are stacked in order.
+//
+// The -sort flag orders the boxes by name or by a statistic:
+// the sample count (n), median, mean,
+// coefficient of variation (cv, the standard deviation over the mean),
+// or spread (the interquartile range over the median).
+// A leading - sorts in decreasing order,
+// so -sort -cv puts the most variable data set first.
+//
+// Each data set's summary statistics are computed on a copy of its values,
+// reordered by selecting its quartiles, in time linear in the number of values.
+// The -in-place flag reorders the values themselves instead,
+// saving memory for large inputs,
+// but losing the input order, so it cannot be used with -runorder or -autocorr.
+//
+// With -exact, every value must be an integer that fits in 64 bits.
+// The values are read as int64s, and the quartiles are computed
+// from them without conversion to floating point,
+// and the mean and standard deviation from exact sums,
+// so counts above 2^53 do not silently lose precision.
+// The value labels are formatted from the exact statistics,
+// so with -precision -1 they are printed in full.
+// The -exact flag applies to the tokens, lines, records, csv, and tsv formats.
+//
+// With -mmap, if the input is a regular file,
+// such as when standard input is redirected from one,
+// it is memory-mapped, and tokens of the default format are split in place in the mapping
+// instead of being copied through the usual buffers.
+// This saves only the copying and the read system calls, not the parsing of the values,
+// so the gain is modest: about 8% on a file of 4 million values.
+// Other formats are read from the mapping through the usual buffers.
+// On platforms without memory mapping, or that are not 64-bit,
+// the input is read as usual.
+//
+// With -budget, such as -budget 2s, box makes a best-effort plot
+// within about the given time of starting to read its input.
+// The budget covers reading and summarizing, but only summarizing is cut short:
+// every value is still read, and counted in the minimum, maximum, mean, and standard deviation,
+// so the budget saves the time of finding the quartiles of large data sets,
+// and a budget spent by reading leaves the smallest samples.
+// If summarizing the data sets in the time left is expected to take longer,
+// the quartiles of each of the largest data sets
+// are estimated from a uniform random sample of its values,
+// as large as fits the budget, but of at least 1000 values.
+// Box reports each sampled data set on standard error,
+// with the 95% confidence bound on the error in the rank of its quartiles.
+//
+// With -consume nats://host/subject, box subscribes to a NATS subject,
+// and with -consume kafka://broker/topic, it consumes every partition of a Kafka topic,
+// beginning with the next message, or with the first if the URL ends in ?offset=earliest.
+// Box consumes Kafka topics without a consumer group, so it commits no offsets,
+// and it stops if the leader of a partition changes;
+// it reads messages compressed with gzip, but not with other codecs.
+// It plots the measurements in the messages, rewriting the plot
+// every -consume-every until the subscription ends.
+// With -o term on a terminal, the plot is instead redrawn in place as measurements arrive,
+// every quarter of a second unless -consume-every is set, for a live view of a long benchmark run over ssh;
+// -q keeps warnings from scrolling it.
+// With -consume stdin:, the messages are lines of the input,
+// so messages from other systems can be piped to box.
+// A message is a JSON object or a line of InfluxDB line protocol.
+// Its value is the -consume-field member or field, value by default,
+// and the data set it belongs to is named by the -consume-key member or tag,
+// name by default, or by the measurement of a line without the tag.
+// As with serve, the input is limited, so that no stream can exhaust the memory of box:
+// messages to -consume-max-message bytes, 1 MiB by default,
+// and the data sets to -consume-max-datasets, 10000 by default,
+// of at most -consume-max-values values each, 10 million by default; 0 is no limit.
+// A message beyond a limit ends the consumption with an error, as does a malformed one.
+// On SIGUSR1, box also writes the current plot to a new file,
+// named by the -snapshot prefix, the time in UTC, and the output format,
+// such as box-snapshot-20240102T150405.000Z.plot,
+// so a long-running collection can be inspected without stopping it.
+// Signals are not supported on Windows, which takes snapshots only by time:
+// with -snapshot-every, box writes a snapshot at that interval as well.
+// With -keep n, box removes all but the newest n snapshots,
+// so that the snapshots of a long run do not fill the disk.
+// With -html, box also writes index.html beside the snapshots,
+// linking to each of them, newest first.
+//
+// Values in the output are rounded to 3 significant digits,
+// or the number set by -precision, with ties rounded half to even.
+//
+// By default, the input format is detected from the start of the input,
+// and the -format flag selects a specific input format.
+// Lines of every format may end with CRLF, as on Windows, as well as LF.
+// With -format lines, or -lines, each input line is a data set:
+// the first field is its name, even if it looks like a number, such as a year,
+// and the rest are its values.
+// With -format records, the input is a sequence of records
+// of the form <name>: <number>* ;
+// where the name is everything before the colon,
+// so it may contain spaces or look like a number.
+// With -line-records, a newline also ends a record.
+// With -format csv or -format tsv, the input is comma- or tab-separated values.
+// By default, or with -pivot columns, each column is a data set
+// named by a header row, and each following row is a trial.
+// With -pivot rows, each row is a data set
+// whose first cell is its name and whose remaining cells are its values.
+// The -csv flag is short for -format csv,
+// as in box -csv -pivot rows < results.csv.
+// With -id-column, the named column is not a data set,
+// but holds an identifier of each row, such as a request ID or test name,
+// and each outlier point is labeled with the identifier of its row:
+// in a tooltip in SVG and HTML output,
+// and after tip= in -plan output and as the tooltip of -geometry json.
+// With -meta, such as -meta host,commit, the named columns are not data sets either,
+// but metadata carried to the output, so that one CSV file of enriched rows can drive a figure.
+// Each column is split into a data set for each combination of the metadata values,
+// named <values>/<column>, as in a/f00d/latency,
+// and, with -pivot rows, each row's metadata is that of its data set.
+// The data sets are grouped by their metadata, unless -group-sep is set,
+// and each box is labeled with its metadata, as in host=a commit=f00d:
+// in a tooltip in SVG and HTML output,
+// and after tip= in -plan output and as the tooltip of -geometry json.
+// With -group-by, the data sets are named by an expression over the columns of each row:
+// column names and double-quoted strings joined by +, as in -group-by 'host + "/" + config',
+// so that names need not be concatenated before box reads them.
+// The columns of the expression are not data sets,
+// but split the other columns as -meta columns do, with the expression naming each part,
+// and, if there is only one other column, naming the data set itself.
+// With -pivot rows, the expression names the data set of each row instead of its first cell.
+// An expression that does not parse is a usage error, with status 1, before any input is read.
+// With -format tdigest or -format ddsketch,
+// each input line is of the form <name> <sketch>,
+// where sketch is a base64-encoded t-digest (in the verbose encoding
+// of the reference Java MergingDigest) or DDSketch protocol buffer,
+// and each sketch gives the distribution of a box.
+// With -format hdr, the input is HdrHistogram output:
+// either percentile distribution tables,
+// each giving the distribution of a box named hdr1, hdr2, and so on,
+// or histogram interval logs,
+// in which the intervals of each tag are merged into a box named by the tag.
+// With -format jmh, pytest, hyperfine, or criterion, the input is the JSON results
+// of JMH, pytest-benchmark, hyperfine --export-json,
+// or Criterion.rs (cargo criterion messages or sample.json files),
+// and each benchmark or command is a box.
+// With -format otlp, the input is an OpenTelemetry OTLP/JSON export request,
+// as sent to an OTLP/HTTP receiver's /v1/traces or /v1/metrics.
+// For traces, each span name is a box of the span durations in milliseconds,
+// or, with -otlp-group <attribute>, each value of a span or resource attribute.
+// For metrics, each histogram or exponential histogram metric is a box
+// drawn from its merged buckets, and with -otlp-group,
+// its data points are split by the attribute into boxes named <metric>:<value>.
+// With -format gobench, or -bench, the input is the output of go test -bench,
+// the benchmark result lines that benchstat reads,
+// and there is a box for each benchmark and metric,
+// such as ns/op, B/op, or allocs/op, named as in Encode-8 ns/op,
+// of the values of the metric from each run of the benchmark:
+//
+//	go test -bench . -count 10 | box -bench | plot
+//
+// Such inputs, and CSV files with several value columns whose rows are split by -meta or -group-by,
+// measure several metrics, which plotted on one scale hide each other.
+// With -metric B/op, box plots only the data sets of that metric, named without it,
+// and with -all-metrics, it plots a panel for each metric, one above another,
+// labeled with the metric, and each with its own scale unless -share-y is set;
+// -o gnuplot, vega, and term draw a single panel, so -all-metrics is a usage error with them.
+//
+// The -plugin flag, which may be repeated, registers an external program
+// that adds input formats or statistics, so that niche formats
+// can be read without changing box.
+// Box runs the program for each request, writing a JSON request to its standard input
+// and reading a JSON response from its standard output.
+// The request {"op": "describe"} is answered by {"formats": [...], "stats": [...]},
+// the names of the formats it adds to -format and the statistics it adds to -sort.
+// The request {"op": "parse", "format": f, "input": text} is answered by
+// {"datasets": [{"name": name, "values": [...]}, ...]}, and
+// {"op": "stat", "stat": s, "datasets": [{"name": name, "values": [...], "n": n}, ...]}
+// by {"values": [...]}, with the value of the statistic for each data set.
+// A response with an error member reports an error.
+//
+// With -script file.star, box runs hooks defined as functions
+// in a Starlark script, if the script defines them.
+// ingest(name, values) is called for each data set with raw values
+// before it is summarized, and returns its new values, or None to drop it.
+// annotate(stats) is called with a dict of each data set's name, n,
+// min, q1, median, q3, max, mean, and stddev after it is summarized,
+// and returns a string, or list of strings, to draw above its box.
+// label(stats) is called before drawing, and returns the label of the box,
+// or None to keep its name.
+// Scripts need box built with -tags starlark, and go.starlark.net.
+//
+// The -o flag selects the output format of the plot:
+// plot(1) commands by default, or, with -o svg, an SVG image,
+// which needs no plotting program to view.
+// Each shape in the image has its role as its class,
+// and the shapes of each box are grouped with its name as their data-name.
+// With -o png, it is a PNG image, drawn by box itself with a built-in bitmap font.
+// It uses no fonts of the host, so the same input and flags give
+// the same PNG image, byte for byte, on every machine.
+// With -o eps, it is an Encapsulated PostScript image, -width by -height pixels at 96 per inch,
+// with text in Helvetica, for including in LaTeX papers with \includegraphics.
+// With -o pic, it is a pic(1) picture, between .PS and .PE, of the same size,
+// for including in troff documents, drawn in black, as by plot(1),
+// with dashed and dotted lines by -style, and text in the current troff font.
+// With -o gnuplot, it is a self-contained gnuplot script, for gnuplot 5 or later,
+// drawing the boxes with its candlesticks style, for systems without plot(1);
+// it draws a single panel of vertical boxes without notches, error bars, or -text,
+// so -matrix, -inset, -horizontal, -notch, -mean-ci, and -text are usage errors with it.
+// With -o vega, it is a Vega-Lite specification, with the statistics inline,
+// for drawing the boxes interactively, with tooltips, in notebooks and browsers;
+// like -o gnuplot, it draws a single panel of vertical boxes, and rejects the same flags.
+// With -o term, it is text for a terminal, each box drawn on three rows
+// with Unicode box-drawing characters across a horizontal value axis,
+// as wide as the terminal, or $COLUMNS if the output is not a terminal, or 80,
+// for looking at distributions over ssh without any graphics;
+// its boxes are always horizontal, and it rejects the other flags that -o gnuplot rejects.
+// The -width and -height flags set the size of SVG and PNG images in pixels,
+// 800 by 600 by default, and -dpi scales the lines and text of PNG images
+// from the default of 96, so that -width 1600 -height 1200 -dpi 192
+// draws the default image at twice the resolution.
+//
+// The -style flag styles the boxes whose names match a regular expression,
+// as in -style 'baseline:color=gray,line=dashed'.
+// The color is one of black, gray, red, green, blue, yellow, orange, purple, brown,
+// magenta, or cyan, and the line is solid, dashed, or dotted.
+// The flag may be repeated, and the rules apply in order,
+// so the keys of later matching rules replace those of earlier rules.
+// With -style-file, the rules are read from a file, one per line,
+// ignoring blank lines and lines beginning with #,
+// so a team can share conventions as a theme file.
+// Styles apply to every output: plot(1) colors and pens, SVG, PNG, HTML,
+// and the color and line members of the boxes of -geometry json.
+//
+// The -geometry json flag writes, in place of the plot,
+// a JSON description of everything that would be drawn,
+// in plot coordinates from 0 to 1 with the origin at the bottom left:
+// the shapes of each box, such as its rectangle, whiskers, and labels,
+// and the shapes outside of boxes, such as the title and legend.
+// Each shape has a role naming what it depicts.
+//
+// The -html flag writes, in place of the plot,
+// a self-contained HTML page that draws the boxes in SVG
+// from their statistics, which are embedded in the page.
+// The value axis can be zoomed with the mouse wheel, panned by dragging,
+// and re-windowed to a range selected by shift-dragging;
+// the labels are regenerated for each view,
+// so dense plots can be explored without re-running box.
+//
+// The -plan flag writes, in place of the plot,
+// a plan of everything that would be drawn, independent of the output backend:
+// one line per shape, giving the box it belongs to, its role, kind,
+// and points, rounded to 4 decimal places, and any text,
+// with the lines sorted.
+// Plans are meant for diffing, for example in continuous integration,
+// to catch unintended visual changes between versions of box or of the data.
+//
+// The -export tdigest flag writes the data sets as t-digest sketch lines
+// in place of the box plots.
+//
+// The command box selftest renders each case of a corpus of data sets,
+// testdata/selftest by default, through every output backend,
+// and compares the results to golden outputs checked in beside the cases.
+// Selftest -update rewrites the golden outputs.
+// The plot(1) output of each case is also parsed,
+// to check that every line is a well-formed plot(1) command.
+// Since plot(1) strings have no escapes,
+// quotes in titles and names are drawn as apostrophes.
+// Selftest also checks properties of the quartile computation,
+// such as ordering and agreement with R's fivenum and quantile,
+// over random inputs, for each -quantile-method.
+//
+// The command box serve runs an HTTP server, on -addr, that plots data.
+// A POST to /v1/plot plots the request body,
+// with flags given by the query parameters,
+// such as /v1/plot?t=Latency&mean-ci=true,
+// and responds with the output.
+// Flags that would read or write the server's files or run its programs,
+// such as -manifest, -trend, and -script, are refused with status 400.
+// A POST to /v1/collections/<name>/data appends the data sets of the body
+// to the values of the data sets of the same names in the named collection,
+// and a GET of /v1/collections/<name>/plot plots the collection so far,
+// so that many jobs can send results to one server and plots fetched later.
+// Sketches and histograms appended to a collection are merged by name.
+// Collections are kept in memory, and expire after -ttl without an append.
+// The server is also a lightweight OTLP/HTTP receiver for development:
+// an OpenTelemetry exporter pointed at it, with the JSON encoding,
+// POSTs to /v1/traces and /v1/metrics, which append the spans or histograms
+// to the collection named by -otlp-collection, otlp by default,
+// so /v1/collections/otlp/plot plots their distributions.
+// Query parameters of the exporter's endpoint set the input flags,
+// as in /v1/traces?otlp-group=http.route.
+// With -ui, the server also serves a web page at /
+// with a text area, or file drop, for data and controls for the main flags,
+// previewing the -html output as they change.
+// The server also serves a gRPC service, defined in proto/box.proto,
+// with a Plot method like /v1/plot and a client-streaming StreamSamples method
+// that plots the samples streamed to it, merged by name.
+// A Go client is in the package github.com/eaburns/box/proto/boxpb,
+// written by hand, without generated code or dependencies beyond the standard library,
+// and clients in other languages may be generated from the definition with protoc.
+// With -tls-cert and -tls-key, the server serves HTTPS,
+// and with -tokens or -token-file, a list of bearer tokens,
+// requests to /v1/ and gRPC requests must carry one of them
+// in an Authorization: Bearer header.
+// The input of each request is limited, so that no client can exhaust the server's memory:
+// request bodies to -max-body bytes, 32 MiB by default,
+// and the data sets of a request or collection to -max-datasets, 10000 by default,
+// of at most -max-values values each, 10 million by default; 0 is no limit.
+// Input beyond a limit is refused with status 413 and a JSON body such as
+// {"error": "input exceeds -max-values 5", "limit": "max-values", "max": 5},
+// or, over gRPC, with status RESOURCE_EXHAUSTED.
+//
+// The command box drill FILE -group-by col1,col2 reads a CSV file
+// of one row per request, with a header row, and writes linked HTML pages,
+// as by -html, to the -o directory, drill by default:
+// index.html plots the -value column, the last by default,
+// for each value of the first grouping column, and the name of each box
+// links to a page plotting its rows by the second column, and so on,
+// such as from endpoints down to the status codes of each endpoint.
+//
+// The command box dashboard -dir results/ reads a directory of result files,
+// each with a date in its name, such as 2024-01-02.txt or bench-20240102T150405Z.txt,
+// and writes a static HTML dashboard to the -o directory, dashboard by default.
+// Its index.html lists each data set name as a metric,
+// with its latest median, the change from the date before, and a sparkline of its medians,
+// and links to a page, as by -html, with a box of the metric for each date.
+// The data sets of the files of the same date are merged by name,
+// and only the pages that change are rewritten, so the dashboard can be
+// regenerated after each new result file, in continuous integration, with little churn.
+//
+// The command box report OLD NEW -o report.html compares two directories
+// of result files, in any input format, merging the data sets of each by name.
+// Each data set in both is compared by the change in its median,
+// Cohen's d, and the p-value of a Mann-Whitney U test,
+// or Welch's t test for sketches, and is a regression or an improvement
+// if the p-value is below -alpha, 0.05 by default;
+// larger values are worse unless -higher-better is set.
+// The report is a self-contained HTML page with a summary of the regressions,
+// a table of the comparisons, and a plot of each old and new pair.
+//
+// With -equivalence 5%, or an absolute margin such as -equivalence 0.25, report also asserts
+// that each new mean is within the margin of the old, by two one-sided t tests (TOST) at -alpha,
+// printing a PASS or FAIL line for each data set and adding the results to the report.
+// It then exits with status 5 if any data set is not shown to be equivalent,
+// rather than if any regresses, so that a significant change within the margin passes,
+// and too few or too noisy values to show equivalence fail.
+// With -non-inferiority 5% in its place, report asserts only that each new mean
+// is less than the margin worse than the old, by a one-sided t test, with worse by -higher-better,
+// so that any improvement, however large, also passes, as a "not worse than 5%" gate needs.
+//
+// The command box compare OLD NEW -quantiles 50,90,99 reads old and new results,
+// each a file or a directory of files, and prints a table of the ratios of the new to the old
+// quantiles of each data set of the same name, each with a bootstrap confidence interval,
+// as in 1.39 [1.13, 1.77], so that a change in the tail that leaves the median alone is evident.
+// The intervals are at the -confidence level, 0.95 by default, from -resamples resamples,
+// 1000 by default, drawn with a fixed seed, so the table is the same from run to run,
+// and the quantiles are by -quantile-method, or its type 7 for Tukey's hinges.
+// A quantile whose interval lies entirely more than -threshold, 5% by default, above 1,
+// or below 1 with -higher-better, is a regression: it is listed below the table,
+// and compare exits with status 5, so that it can gate continuous integration.
+//
+// The command box power [FILE] -effect 5% reads data sets,
+// from the file or standard input, and prints, for each,
+// the number of samples needed to detect a change of the -effect in its mean,
+// a percentage of the mean or an absolute value,
+// with a two-sided t test at the -alpha significance level, 0.05 by default,
+// and with -power probability, 0.8 by default,
+// estimated from its standard deviation,
+// along with the smallest change its own sample size can detect,
+// to tell whether a benchmark has enough runs before its plot is trusted.
+//
+// With -manifest <file>, box also writes a JSON manifest of what is needed to reproduce the figure:
+// the version of box, the value of every flag, the SHA-256 of each input,
+// the seed of -budget sampling, and a digest of the computed statistics.
+// The command box render -manifest <file> replays it:
+// it sets the flags as recorded, checks that the inputs are unchanged,
+// reading standard input if that was the input, or files given in place of the recorded ones,
+// and writes the figure, failing with status 5 if the statistics differ from the manifest.
+//
+// With -spec <file>, box reads a figure from a spec file, a subset of TOML,
+// so that a complex figure drawn again and again is versioned with its data
+// and redrawn with one command:
+//
+//	[input]
+//	files = ["old.txt", "new.txt"]
+//	basename = true
+//
+//	[style]
+//	style = ["old:color=gray,line=dashed", "new:color=blue"]
+//
+//	[annotate]
+//	t = "Encode latency"
+//	axis = true
+//
+//	[[output]]
+//	file = "latency.svg"
+//
+//	[[output]]
+//	file = "latency.png"
+//	width = 1600
+//
+// The keys of its tables, input, transform, group, style, and annotate,
+// are flags, and an array sets a flag once for each element, as repeating it does.
+// The files key lists the input files, and each output table names a file,
+// in the format of its extension, with flags of its own.
+// Without output tables, the figure is written on standard output.
+// Flags and files on the command line override those of the spec,
+// and with -o or -html, the figure is written on standard output instead of to the output files,
+// so box -spec fig.toml -o term previews it in a terminal.
+//
+// The exit status of box tells the kind of failure apart, so scripts can branch on it:
+// 0 on success, 1 for bad flags or arguments, 2 for input that cannot be read,
+// 3 for input without any values, 4 for failing to render or write the output,
+// and 5 when box report finds a regression, or, with -equivalence or -non-inferiority, a data set that fails the test,
+// after writing the report, or box compare finds a regression,
+// or box render computes statistics that differ from its manifest.
+// Errors are printed on standard output, where a plotting program shows them,
+// and warnings, such as of correlated samples, on standard error.
+// With -q, warnings are not printed.
+// With -porcelain, errors are printed on standard error too,
+// so standard output carries only the requested output,
+// and programs can parse it without checking for messages.
+//
+// Programs can summarize data sets as box does without running it with the boxplot package,
+// with which box itself computes its statistics:
+// boxplot.Stats5Method and boxplot.Quantile take any -quantile-method,
+// and boxplot.Select finds the order statistics they need in linear time.
+// Its boxplot.ScanTokens and boxplot.Unquote split the default input format.
+//
+// With -approx, each data set is summarized as it is read by a streaming t-digest,
+// instead of keeping its values, so that box can summarize billions of values from a pipe
+// in constant memory. The count, mean, and standard deviation are exact,
+// and the quartiles are estimates, typically within a fraction of a percent in rank.
+// It applies to the default input format, without -names,
+// and cannot be used with the flags that need the values, such as -runorder or -exact.
+//
+// The command box bench-self measures the throughput of box itself,
+// in parsing, computing statistics, and rendering,
+// on generated corpora of 1e3, 1e6, and 1e8 values in 2, 50, and 1000 data sets,
+// or the sizes given by -values and -sets.
+// It prints the results in the format of go test -bench,
+// so that benchstat can compare them across versions.
+// The same phases, on the corpus of 1e6 values in 50 data sets, are the benchmarks of go test -bench,
+// and go test fails if any is slower than a floor of about a fifth of its usual throughput.
 package main
 
 import (
-	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"io"
-	"math"
 	"os"
-	"sort"
-	"strconv"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eaburns/box/boxplot"
 )
 
-var title = flag.String("t", "", "plot title")
+var (
+	title          = flag.String("t", "", "plot title")
+	ciLevel        = flag.Float64("ci-level", 0.95, "confidence level of confidence intervals")
+	meanCI         = flag.Bool("mean-ci", false, "draw the mean and its confidence interval as an error bar")
+	runOrder       = flag.Bool("runorder", false, "plot the values of each data set in input order instead of boxes")
+	autocorr       = flag.Float64("autocorr", 0, "warn of and mark data sets with lag-1 autocorrelation above this magnitude")
+	modes          = flag.Bool("modes", false, "warn of and mark data sets that appear multimodal")
+	groupSep       = flag.String("group-sep", "", "separator between the group and the rest of data set names")
+	matrix         = flag.Bool("matrix", false, "draw a grid of panels with rows and columns named by <row>.<column>")
+	shareY         = flag.Bool("share-y", false, "use the same value scale for every panel of a -matrix")
+	autoCaptions   = flag.Bool("captions", false, "caption each panel and group with its sample count")
+	annotFile      = flag.String("annotations", "", "`file` of panel and group captions")
+	geometry       = flag.String("geometry", "", "write the layout of the plot instead of plotting: json")
+	exact          = flag.Bool("exact", false, "read values as int64s and compute statistics without rounding")
+	mmap           = flag.Bool("mmap", false, "memory-map the input if it is a regular file, splitting tokens in place")
+	budget         = flag.Duration("budget", 0, "time budget for a best-effort plot, from the start of reading, sampling large data sets to meet it; 0 for no budget")
+	consumeURL     = flag.String("consume", "", "plot messages from a `url`: nats://host/subject, kafka://broker/topic, or stdin:")
+	consumeKey     = flag.String("consume-key", "name", "message member or tag naming the data set of a -consume message")
+	consumeField   = flag.String("consume-field", "value", "message member or field giving the value of a -consume message")
+	consumeEvery   = flag.Duration("consume-every", 10*time.Second, "interval between plots of -consume messages")
+	consumeMaxMsg  = flag.Int64("consume-max-message", 1<<20, "largest -consume message in `bytes`; 0 for no limit")
+	consumeMaxSets = flag.Int("consume-max-datasets", 10000, "most data sets of -consume messages; 0 for no limit")
+	consumeMaxVals = flag.Int("consume-max-values", 10000000, "most values of a data set of -consume messages; 0 for no limit")
+	otlpGroup      = flag.String("otlp-group", "", "group OTLP spans and histogram data points by the `attribute`")
+	scriptFile     = flag.String("script", "", "Starlark `file` of ingest, annotate, and label hooks")
+	outFormat      = flag.String("o", "plot", "output `format`: plot, for plot(1), svg, png, eps, pic, gnuplot, vega, or term, for a terminal")
+	width          = flag.Int("width", 800, "width of svg and png output in `pixels`")
+	height         = flag.Int("height", 600, "height of svg and png output in `pixels`")
+	dpi            = flag.Float64("dpi", 96, "`resolution` of png output, scaling its lines and text")
+	snapshot       = flag.String("snapshot", "box-snapshot", "`prefix` of the timestamped files of the plot written on SIGUSR1 with -consume")
+	snapshotEvery  = flag.Duration("snapshot-every", 0, "`interval` between snapshots of -consume plots, or 0 for only on SIGUSR1")
+	keep           = flag.Int("keep", 0, "keep only the newest `n` snapshots, or 0 for all")
+	whiskerRule    = flag.String("whiskers", "minmax", "whisker `rule`: minmax; tukey, ending within -whisker-k IQRs of the quartiles; or pLO,pHI percentiles, such as p5,p95; values beyond are drawn as outliers")
+	whiskerK       = flag.Float64("whisker-k", 1.5, "`multiple` of the interquartile range within which -whiskers tukey whiskers end")
+	quiet          = flag.Bool("q", false, "do not print warnings on standard error")
+	porcelain      = flag.Bool("porcelain", false, "write only the requested output on standard output, and errors on standard error")
+	heat           = flag.String("heat", "", "hatch each box more densely the farther its median is from the median of `all` boxes, or of the baseline boxes matching a regular expression")
+	inset          = flag.String("inset", "", "draw an inset panel zooming in on the data sets whose names match the regular `expression`, at a scale of their own")
+	baseName       = flag.Bool("basename", false, "name the data set of each file argument by the file's base name; all tokens are values")
+	axis           = flag.Bool("axis", false, "draw a value axis with ticks at round values")
+	horizontal     = flag.Bool("horizontal", false, "draw the boxes horizontally, one above another, with their names at the left")
+	logScale       = flag.Bool("log", false, "draw values on a logarithmic scale; the statistics are of the raw values")
+	logZero        = flag.String("log-zero", "error", "with -log, what to do with values at or below zero: error; drop them; epsilon, drawing them a decade below the smallest positive value; or symlog, a scale linear near zero")
+	yTicks         = flag.String("yticks", "", "comma-separated `values` at which to draw the value axis ticks, instead of round values; implies -axis")
+	yTickLabels    = flag.String("ytick-labels", "", "comma-separated `labels` for the -yticks, in order")
+	yMin           = flag.String("ymin", "", "bottom `value` of the value range, instead of the smallest value")
+	yMax           = flag.String("ymax", "", "top `value` of the value range, instead of the largest value")
+	log2Scale      = flag.Bool("log2", false, "draw values on a logarithmic scale with -axis ticks at powers of two, labeled 1Ki, 2Ki, and so on; implies -log")
+	notched        = flag.Bool("notch", false, "notch each box at the median ± 1.57·IQR/√n; boxes whose notches do not overlap have medians that differ")
+	quantileMethod = flag.String("quantile-method", "tukey", "quartile `method`: tukey, for Tukey's hinges, as R's fivenum; or a Hyndman-Fan type from 1 to 9, as R's quantile, such as 7, the default of R, NumPy, and pandas")
+	lintData       = flag.Bool("lint", false, "warn of suspicious data: duplicate names, empty or constant data sets, very different sample counts, and probable unit mismatches")
+	explainName    = flag.String("explain", "", "write how each statistic of the data set with this `name` was computed instead of plotting")
+	manifestFile   = flag.String("manifest", "", "write a JSON manifest to `file` of the version, flags, input hashes, and statistics, with which box render reproduces the figure")
+	approx         = flag.Bool("approx", false, "summarize each data set with a streaming t-digest instead of keeping its values, estimating the quartiles in constant memory")
+	statsOnly      = flag.Bool("stats", false, "write a table of the statistics of each data set instead of plotting")
+	statsFormat    = flag.String("stats-format", "tsv", "`format` of -stats: tsv or json")
+	trendFile      = flag.String("trend", "", "also write a chart of the median and interquartile range of each data set, in order, to this `file`")
+	metricName     = flag.String("metric", "", "plot only the data sets of this `metric`, such as B/op, named without it")
+	allMetrics     = flag.Bool("all-metrics", false, "plot a panel for each metric, such as ns/op and B/op, with its own scale")
+	robustRange    = flag.Bool("robust-range", false, "fit the value range to all but the lowest and highest 0.5% of the values of each data set, drawing the others at its edges")
+	runPlot        = flag.Bool("run", false, "pipe the output into the -plotcmd instead of writing it on standard output")
+	plotCmd        = flag.String("plotcmd", "plot", "`command` run by -run, with its arguments separated by spaces")
+	specFile       = flag.String("spec", "", "read the inputs, flags, and outputs of a figure from a spec `file`; flags on the command line override it")
+	inPlace        = flag.Bool("in-place", false, "reorder values in place to save memory, losing the input order that -runorder and -autocorr need")
+	html           = flag.Bool("html", false, "write an interactive HTML page instead of plotting")
+	plan           = flag.Bool("plan", false, "write a sorted, normalized description of what would be drawn instead of plotting")
+	sortKey        = flag.String("sort", "", "sort boxes by name, n, median, mean, cv, spread, or a plugin statistic; prefix - for descending")
+	precision      = flag.Int("precision", 3, "significant digits of output values, or -1 for the fewest that are exact")
+	format         = flag.String("format", "auto", "input format: auto, tokens, lines, records, csv, tsv, tdigest, ddsketch, hdr, jmh, pytest, hyperfine, criterion, otlp, gobench, or a plugin format")
+	pivot          = flag.String("pivot", "columns", "CSV and TSV data set orientation: columns or rows")
+	idColumn       = flag.String("id-column", "", "CSV and TSV `column` of sample identifiers, such as request IDs, with which to label outliers")
+	metaCols       = flag.String("meta", "", "comma-separated CSV and TSV `columns` of data set metadata, such as host or commit, which split, group, and label the data sets")
+	groupBy        = flag.String("group-by", "", "CSV and TSV `expression` naming the data sets from the columns of each row, as in host + \"/\" + config")
+	export         = flag.String("export", "", "write sketches instead of plotting: tdigest")
+
+	names       = flag.String("names", "", "comma-separated data set `names`; all tokens are values")
+	sep         = flag.String("sep", "--", "token ending a data set, making the next token a name")
+	lines       = flag.Bool("lines", false, "read one data set per line; short for -format lines")
+	csvInput    = flag.Bool("csv", false, "read comma-separated values; short for -format csv")
+	goBench     = flag.Bool("bench", false, "read go test -bench output; short for -format gobench")
+	lineRecords = flag.Bool("line-records", false, "end records at newlines as well as semicolons")
+)
+
+// Readers maps input format names to their readers.
+var readers = map[string]func(io.Reader) ([]box, error){
+	"tokens":    readBoxes,
+	"lines":     readLines,
+	"records":   readRecords,
+	"csv":       readCSV,
+	"tsv":       readTSV,
+	"tdigest":   readTDigests,
+	"ddsketch":  readDDSketches,
+	"hdr":       readHDR,
+	"jmh":       readJMH,
+	"pytest":    readPytest,
+	"hyperfine": readHyperfine,
+	"criterion": readCriterion,
+	"otlp":      readOTLP,
+	"gobench":   readGoBench,
+}
 
 func main() {
-	flag.Parse()
-	boxes, err := readBoxes(os.Stdin)
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	parseFlags(flag.CommandLine, os.Args[1:])
+	if flag.NArg() > 0 && flag.Arg(0) == "selftest" {
+		os.Exit(selftest(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "bench-self" {
+		os.Exit(benchSelf(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "drill" {
+		os.Exit(drill(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "dashboard" {
+		os.Exit(dashboard(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "report" {
+		os.Exit(report(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "compare" {
+		os.Exit(compare(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "power" {
+		os.Exit(power(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "render" {
+		os.Exit(renderManifest(flag.Args()[1:]))
+	}
+	if flag.NArg() > 0 && flag.Arg(0) == "serve" {
+		os.Exit(serve(flag.Args()[1:]))
+	}
+	inputFiles = flag.Args()
+	var sp *spec
+	if *specFile != "" {
+		var err error
+		if sp, err = readSpec(*specFile); err == nil {
+			given := make(map[string]bool)
+			flag.Visit(func(f *flag.Flag) { given[f.Name] = true })
+			err = sp.apply(given)
+		}
+		if err != nil {
+			printError(err)
+			os.Exit(exitUsage)
+		}
+	}
+	plot := func(out io.Writer) error { return run(os.Stdin, out) }
+	if *consumeURL != "" {
+		if len(inputFiles) > 0 {
+			printError(fmt.Errorf("-consume reads standard input, not files"))
+			os.Exit(exitUsage)
+		}
+		plot = func(out io.Writer) error { return consume(*consumeURL, os.Stdin, out) }
+	}
+	var err error
+	switch {
+	case sp != nil && sp.writesOutputs():
+		if *consumeURL != "" || *runPlot {
+			printError(fmt.Errorf("-consume and -run write standard output, not the -spec outputs"))
+			os.Exit(exitUsage)
+		}
+		err = sp.writeOutputs()
+	case *runPlot:
+		err = runPlotCmd(plot)
+	default:
+		err = plot(os.Stdout)
+	}
 	if err != nil {
-		fmt.Println("Read failed: ", err)
+		printError(err)
+		os.Exit(exitStatus(err))
+	}
+}
+
+// PrintError prints an error that ends box
+// on standard output, or, with -porcelain, on standard error,
+// so that standard output carries only the requested output.
+func printError(err error) {
+	if *porcelain {
+		fmt.Fprintf(os.Stderr, "box: %v\n", err)
 		return
 	}
-	draw(boxes, *title, os.Stdout)
+	fmt.Println(err)
+}
+
+// Warnf prints a warning on standard error, unless -q is set.
+func warnf(format string, args ...interface{}) {
+	if !*quiet {
+		fmt.Fprintf(os.Stderr, "box: "+format+"\n", args...)
+	}
+}
+
+// Run reads data sets from in, or from the inputFiles if there are any,
+// according to the flags, and writes the plot, or the other requested output, to out.
+// Its errors have the exit status of their kind; see exitStatus.
+func run(in io.Reader, out io.Writer) error {
+	start := time.Now()
+	if err := prepareRun(); err != nil {
+		return err
+	}
+	var boxes []box
+	var err error
+	var inputs []manifestInput
+	if len(inputFiles) > 0 {
+		boxes, err = readFiles(inputFiles)
+		for _, path := range inputFiles {
+			if *manifestFile == "" || err != nil {
+				break
+			}
+			input := manifestInput{Name: path}
+			input.SHA256, err = hashFile(path)
+			inputs = append(inputs, input)
+		}
+	} else {
+		h := sha256.New()
+		if *manifestFile != "" {
+			in = io.TeeReader(in, h)
+		}
+		boxes, err = readInput(in)
+		inputs = []manifestInput{{Name: "-", SHA256: hex.EncodeToString(h.Sum(nil))}}
+	}
+	if err != nil {
+		return withStatus(exitParse, err)
+	}
+	return runBoxes(boxes, inputs, out, start)
+}
+
+// PrepareRun checks the flags that are needed before reading
+// and reads the -annotations file.
+func prepareRun() error {
+	if err := checkTrend(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if _, err := groupByExpr(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if *annotFile != "" {
+		var err error
+		if annotations, err = readAnnotations(*annotFile); err != nil {
+			return withStatus(exitParse, fmt.Errorf("Read failed: %v", err))
+		}
+	}
+	return nil
+}
+
+// RunBoxes checks the boxes read by run against the limits,
+// then writes their output and the -manifest of the inputs.
+func runBoxes(boxes []box, inputs []manifestInput, out io.Writer, start time.Time) error {
+	if err := limits.check(boxes); err != nil {
+		return withStatus(exitParse, err)
+	}
+	empty := true
+	for _, b := range boxes {
+		empty = empty && b.n == 0
+	}
+	if empty {
+		return withStatus(exitEmpty, errEmpty)
+	}
+	if *manifestFile != "" {
+		recording = newManifest()
+		recording.Inputs = inputs
+	}
+	if err := output(boxes, out, start); err != nil {
+		return withStatus(exitOutput, err)
+	}
+	if *manifestFile != "" {
+		if err := writeManifest(*manifestFile, recording); err != nil {
+			return withStatus(exitOutput, fmt.Errorf("Write failed: %v", err))
+		}
+	}
+	return nil
+}
+
+// ReadInput reads data sets from in, according to the flags.
+// With -format auto, the format is detected anew for each call,
+// and the -format flag is left as it was.
+// The statistics of the boxes are pending until they are output.
+func readInput(in io.Reader) ([]box, error) {
+	size := inputSize(in)
+	var mapped []byte
+	if f, ok := in.(*os.File); ok && *mmap {
+		if data, unmap := mapFile(f); data != nil {
+			defer unmap()
+			in, mapped = bytes.NewReader(data), data
+		}
+	}
+	inFormat := *format
+	if *lines {
+		inFormat = "lines"
+	}
+	if *csvInput {
+		inFormat = "csv"
+	}
+	if *goBench {
+		inFormat = "gobench"
+	}
+	if *inPlace && (*runOrder || *autocorr > 0) {
+		return nil, withStatus(exitUsage, fmt.Errorf("-in-place loses the input order needed by -runorder and -autocorr"))
+	}
+	if *inPlace && *idColumn != "" {
+		return nil, withStatus(exitUsage, fmt.Errorf("-in-place loses the order of the values needed by -id-column"))
+	}
+	if inFormat == "auto" {
+		inFormat, in = detectFormat(in)
+	}
+	read, ok := readers[inFormat]
+	if !ok {
+		return nil, withStatus(exitUsage, fmt.Errorf("Unknown format: %s", inFormat))
+	}
+	if *approx && (inFormat != "tokens" || *names != "") {
+		return nil, withStatus(exitUsage, fmt.Errorf("-approx is supported only for format tokens, without -names"))
+	}
+	if *approx && (*exact || *runOrder || *autocorr > 0 || *modes) {
+		return nil, withStatus(exitUsage, fmt.Errorf("-approx keeps no values for -exact, -runorder, -autocorr, or -modes"))
+	}
+	if *exact && !exactFormats[inFormat] {
+		return nil, withStatus(exitUsage, fmt.Errorf("-exact is not supported for format %s", inFormat))
+	}
+	var boxes []box
+	var err error
+	switch {
+	case mapped != nil && inFormat == "tokens":
+		boxes, err = scanBoxes(&byteTokens{data: mapped}, size)
+	case inFormat == "tokens":
+		// The size is of the input before any detectFormat buffer.
+		boxes, err = scanBoxes(newTokenScanner(in), size)
+	default:
+		boxes, err = read(in)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Read failed: %v", err)
+	}
+	return boxes, nil
+}
+
+// Output summarizes the boxes and writes the plot,
+// or the other requested output, to out.
+// Start is the time that reading began, from which -budget is measured.
+func output(boxes []box, out io.Writer, start time.Time) error {
+	var sc *script
+	if *scriptFile != "" {
+		var err error
+		if sc, err = loadScript(*scriptFile); err != nil {
+			return withStatus(exitUsage, err)
+		}
+		if boxes, err = sc.ingest(boxes); err != nil {
+			return err
+		}
+	}
+	if *log2Scale {
+		*logScale = true
+	}
+	if err := checkWhiskers(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkQuantileMethod(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkCILevel(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkTicks(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkFormat(); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	boxes, err := selectMetric(boxes)
+	if err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if *statsFormat != "tsv" && *statsFormat != "json" {
+		return withStatus(exitUsage, fmt.Errorf("Unknown stats format: %s", *statsFormat))
+	}
+	dropLogZero(boxes)
+	if *budget > 0 {
+		sampleForBudget(boxes, *budget-time.Since(start))
+	}
+	summarize(boxes)
+	if *lintData {
+		for _, w := range lint(boxes) {
+			warnf("lint: %s", w)
+		}
+	}
+	if err := setHeat(boxes); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkInset(boxes); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkLog(boxes); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if err := checkRange(boxes); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	if sc != nil {
+		if err := sc.annotate(boxes); err != nil {
+			return err
+		}
+	}
+	for _, b := range boxes {
+		if b.correlated() {
+			warnf("%s: lag-1 autocorrelation %s; samples are not independent",
+				b.name, formatValue(b.autocorr()))
+		}
+		if b.multimodal() {
+			warnf("%s: %d modes; the box plot hides its shape", b.name, b.modes())
+		}
+		if len(b.censored) > 0 {
+			warnf("%s: %d censored values; the box is drawn with them at their limits, so it is biased low",
+				b.name, len(b.censored))
+		}
+	}
+	if *sortKey != "" {
+		if err := sortBoxes(boxes, *sortKey); err != nil {
+			return err
+		}
+	}
+	if sc != nil {
+		if err := sc.label(boxes); err != nil {
+			return err
+		}
+	}
+	switch *export {
+	case "":
+		switch *geometry {
+		case "":
+			if *statsOnly {
+				err = writeStats(boxes, out)
+				break
+			}
+			if *explainName != "" {
+				err = writeExplain(boxes, *explainName, out)
+				if err != nil {
+					return withStatus(exitUsage, err)
+				}
+				break
+			}
+			if *plan {
+				err = drawCanvas(boxes, *title, &planCanvas{w: out})
+				break
+			}
+			if *html {
+				err = writeHTML(boxes, *title, out)
+				break
+			}
+			if err := checkSize(); err != nil {
+				return withStatus(exitUsage, err)
+			}
+			switch *outFormat {
+			case "plot":
+				err = draw(boxes, *title, out)
+			case "svg":
+				err = drawCanvas(boxes, *title, &svgCanvas{w: out})
+			case "png":
+				err = drawCanvas(boxes, *title, newPNGCanvas(out))
+			case "eps":
+				err = drawCanvas(boxes, *title, &epsCanvas{w: out})
+			case "pic":
+				err = drawCanvas(boxes, *title, &picCanvas{w: out})
+			case "gnuplot":
+				err = writeGnuplot(boxes, *title, out)
+			case "vega":
+				err = writeVega(boxes, *title, out)
+			case "term":
+				err = writeTerm(boxes, *title, out, termColumns(out))
+			default:
+				return withStatus(exitUsage, fmt.Errorf("Unknown output format: %s", *outFormat))
+			}
+		case "json":
+			err = drawCanvas(boxes, *title, &geometryCanvas{w: out})
+		default:
+			return withStatus(exitUsage, fmt.Errorf("Unknown geometry format: %s", *geometry))
+		}
+	case "tdigest":
+		err = writeSketches(boxes, out)
+	default:
+		return withStatus(exitUsage, fmt.Errorf("Unknown export format: %s", *export))
+	}
+	if err != nil {
+		return fmt.Errorf("Write failed: %v", err)
+	}
+	if *trendFile != "" {
+		if err := writeTrend(boxes, *title); err != nil {
+			return err
+		}
+	}
+	if recording != nil {
+		if err := recording.record(boxes); err != nil {
+			return fmt.Errorf("Write failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// UndrawnFlags maps each -o format that writes the boxes for another program,
+// instead of drawing them with the layout of the figure,
+// to the flags that it cannot draw.
+var undrawnFlags = map[string][]string{
+	"gnuplot": {"matrix", "inset", "horizontal", "notch", "mean-ci", "text"},
+	"vega":    {"matrix", "inset", "horizontal", "notch", "mean-ci", "text"},
+	"term":    {"matrix", "inset", "notch", "mean-ci", "text"},
+}
+
+// CheckFormat returns an error if a flag is set that the -o format cannot draw,
+// instead of leaving it out of the plot.
+func checkFormat() error {
+	for _, name := range undrawnFlags[*outFormat] {
+		if f := flag.Lookup(name); f.Value.String() != f.DefValue {
+			return fmt.Errorf("-o %s cannot draw -%s", *outFormat, name)
+		}
+	}
+	return nil
 }
 
 type box struct {
 	name                 string
 	values               []float64
 	min, q1, q2, q3, max float64
+	n                    int
+	mean, stddev         float64
+
+	// Digest is non-nil for boxes read from a sketch
+	// instead of from raw values.
+	digest *digest
+
+	// Exact is non-nil for boxes read with -exact.
+	exact *exactStats
+
+	// Pending is whether the summary statistics
+	// are yet to be computed by summarize.
+	pending bool
+	// Sample, if non-nil, is a sample of the values
+	// from which summarize estimates the quartiles, to meet the -budget.
+	sample []float64
+	// ModeCount is the number of modes, if hasModes is set.
+	modeCount int
+	hasModes  bool
+	// ScriptNotes are the notes added by the -script annotate hook.
+	scriptNotes []string
+	// Heat is the relative distance of the median from the -heat reference.
+	heat float64
+	// Censored are the limits of the censored values, such as >5000,
+	// which are among the values at their limits.
+	censored []float64
+	// IDs are the -id-column identifiers of the values, in the same order,
+	// or nil if the values have none.
+	ids []string
+	// Meta are the values of the -meta columns of the data set, in order,
+	// or nil if it has none.
+	meta []string
+	// Group is the group of the data set from its -meta or -group-by columns,
+	// if any.
+	group string
+	// Metric is the quantity that the data set measures, if its input has several,
+	// such as the unit of a Go benchmark result, like ns/op or B/op,
+	// or the column of a CSV file whose rows are split by -meta or -group-by.
+	// The name of the data set ends with it.
+	metric string
 }
 
 func readBoxes(r io.Reader) ([]box, error) {
-	scanner := bufio.NewScanner(r)
-	scanner.Split(bufio.ScanWords)
+	return scanBoxes(newTokenScanner(r), inputSize(r))
+}
+
+// ScanBoxes reads data sets in the default format from a tokenScanner,
+// as readBoxes, of an input of size bytes, or of unknown size if 0;
+// see newFloatArena.
+func scanBoxes(scanner tokenScanner, size int64) ([]box, error) {
+	if *approx {
+		size = 0 // No values are kept.
+	}
+	arena := newFloatArena(size)
+	if *names != "" {
+		return readNamed(scanner, strings.Split(*names, ","), arena)
+	}
 	var boxes []box
 	if !scanner.Scan() {
-		return boxes, nil
+		return boxes, scanner.Err()
+	}
+	if *sep != "" && scanner.Text() == *sep && !scanner.Scan() {
+		return boxes, scanner.Err()
 	}
 	for {
-		b, more := readBox(scanner)
+		b, more := readBox(scanner, arena)
 		boxes = append(boxes, b)
 		if !more {
 			break
@@ -55,127 +1253,150 @@ func readBoxes(r io.Reader) ([]box, error) {
 	return boxes, scanner.Err()
 }
 
-// ReadBox reads a box from a word-splitting *bufio.Scanner and returns it.
+// ReadNamed reads data sets named by the -names flag
+// from a tokenScanner.
+// Every token other than the -sep separator must be a number.
+// If there are separators, they divide the values into one data set per name.
+// Otherwise, the values are split evenly among the names.
+func readNamed(scanner tokenScanner, names []string, arena *floatArena) ([]box, error) {
+	groups := []valueList{{arena: arena}}
+	for scanner.Scan() {
+		tok := scanner.Bytes()
+		if *sep != "" && string(tok) == *sep {
+			groups = append(groups, valueList{arena: arena})
+			continue
+		}
+		if err := groups[len(groups)-1].parseToken(tok); err != nil {
+			return nil, fmt.Errorf("bad value %q", scanner.Text())
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(groups) > 1 && groups[0].len() == 0 {
+		groups = groups[1:]
+	}
+	if len(groups) > 1 && groups[len(groups)-1].len() == 0 {
+		groups = groups[:len(groups)-1]
+	}
+	if len(groups) == 1 {
+		vs := groups[0]
+		if vs.len()%len(names) != 0 {
+			return nil, fmt.Errorf("%d values do not split evenly among %d names", vs.len(), len(names))
+		}
+		k := vs.len() / len(names)
+		groups = make([]valueList, len(names))
+		for i := range groups {
+			groups[i] = vs.slice(i*k, (i+1)*k)
+		}
+	}
+	if len(groups) != len(names) {
+		return nil, fmt.Errorf("%d data sets for %d names", len(groups), len(names))
+	}
+	boxes := make([]box, len(names))
+	for i, name := range names {
+		boxes[i] = groups[i].box(name)
+	}
+	return boxes, nil
+}
+
+// ReadBox reads a box from a tokenScanner and returns it.
 //
 // The current Text() of the scanner is interpreted as the name of the box.
-// Following tokens that are parsable by strconv.ParseFloat with 64-bits
-// are interpreted as the box data.
-// Data is scanned until the the scanner is empty or ParseFloat fails.
+// Following tokens that are parsable by strconv.ParseFloat with 64-bits,
+// or with -exact by strconv.ParseInt, are interpreted as the box data.
+// Data is scanned until the the scanner is empty, parsing fails,
+// or the token is the -sep separator.
+// A separator is consumed, so the token following it is always a name,
+// even if it looks like a number.
 //
 // The return value more indicates whether the scanner contains more tokens.
 // If so, the current Text() of scanner after readBox returns
 // is the first token that was not used by the readBox call,
 // i.e., the next token for subsequent scanning.
-func readBox(scanner *bufio.Scanner) (b box, more bool) {
-	b.name = scanner.Text()
+func readBox(scanner tokenScanner, arena *floatArena) (b box, more bool) {
+	if *approx {
+		return readApproxBox(scanner)
+	}
+	name := boxplot.Unquote(scanner.Text())
+	vs := valueList{arena: arena}
 	for scanner.Scan() {
-		v, err := strconv.ParseFloat(scanner.Text(), 64)
-		if err != nil {
+		// The token is compared and parsed as bytes,
+		// which need no copy of it as a string.
+		tok := scanner.Bytes()
+		if *sep != "" && string(tok) == *sep {
+			more = scanner.Scan()
+			break
+		}
+		if err := vs.parseToken(tok); err != nil {
 			more = true
 			break
 		}
-		b.values = append(b.values, v)
 	}
-	if len(b.values) > 0 {
-		b.min, b.q1, b.q2, b.q3, b.max = stats5(b.values)
+	return vs.box(name), more
+}
+
+// NewBox returns a box with the given name and values.
+// Its summary statistics are computed later, by summarize.
+func newBox(name string, vs []float64) box {
+	return box{name: name, values: vs, n: len(vs), pending: len(vs) > 0}
+}
+
+// Summarize computes the summary statistics of the boxes
+// whose statistics are pending and, with -modes, the modes of every box.
+// The boxes are independent, so they are summarized
+// by a pool of GOMAXPROCS goroutines.
+// The values are left in their original order,
+// unless -in-place is set, in which case they are reordered.
+func summarize(boxes []box) {
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < runtime.GOMAXPROCS(0); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				boxes[i].summarize()
+			}
+		}()
+	}
+	for i := range boxes {
+		next <- i
 	}
-	return b, more
+	close(next)
+	wg.Wait()
 }
 
-// Stats5 returns a five statistic summary of the values.
-// The summary includes:
-// the minimum value,
-// the first quartile,
-// the second quartile (a.k.a., the median),
-// the third quartile,
-// and the maximum value.
-// Stats5 sorts the input slice.
-func stats5(vs []float64) (min, q1, q2, q3, max float64) {
-	sort.Float64s(vs)
-	if len(vs) == 1 {
-		return vs[0], vs[0], vs[0], vs[0], vs[0]
-	}
-	min = vs[0]
-	q1 = median(vs[:len(vs)/2])
-	q2 = median(vs)
-	q3 = median(vs[len(vs)/2:])
-	max = vs[len(vs)-1]
-	return min, q1, q2, q3, max
-}
-
-// Median returns the median of a sorted float64 slice.
-func median(vs []float64) float64 {
-	if len(vs) == 1 {
-		return vs[0]
-	}
-	med := vs[len(vs)/2]
-	if len(vs)%2 == 0 {
-		med += vs[len(vs)/2-1]
-		med /= 2
-	}
-	return med
-}
-
-func draw(boxes []box, title string, w io.Writer) {
-	const (
-		yPad    = 0.05
-		yText   = 0.02
-		yBottom = yPad + yText
-	)
-	yTop := 1.0 - yPad
-	if title != "" {
-		fmt.Fprintf(w, "m %f %f\nt \"\\C%s\"\n", 0.5, 1.0-yText, title)
-		yTop -= yText
-	}
-
-	n := float64(len(boxes))
-	pad := (1.0 / n) / 3.0
-	width := (1.0-(n+1)*pad) / n
-	capWidth := width / 4.0
-
-	x := pad
-	yMin, yMax := minMax(boxes)
-	tr := makeTr(yMin, yMax, yBottom, yTop)
-	for _, b := range boxes {
-		c := x + width/2.0
-		fmt.Fprintf(w, "m %f %f\nt \"\\C%s\"\n", c, yText, b.name)
-		bottom, top := tr(b.q1), tr(b.q3)
-		fmt.Fprintf(w, "bo %f %f %f %f\n", x, bottom, x+width, top)
-		fmt.Fprintf(w, "m %f %f\nt \"\\R%.3g\"\n", x, bottom, b.q1)
-		fmt.Fprintf(w, "m %f %f\nt \"\\R%.3g\"\n", x, top, b.q3)
-		med := tr(b.q2)
-		fmt.Fprintf(w, "li %f %f %f %f\n", x, med, x+width, med)
-		fmt.Fprintf(w, "m %f %f\nt \"\\R%.3g\"\n", x, med, b.q2)
-		min := tr(b.min)
-		fmt.Fprintf(w, "li %f %f %f %f\n", c-capWidth, min, c+capWidth, min)
-		fmt.Fprintf(w, "li %f %f %f %f\n", c, bottom, c, min)
-		fmt.Fprintf(w, "m %f %f\nt \"\\R%.3g\"\n", c-capWidth, min, b.min)
-		max := tr(b.max)
-		fmt.Fprintf(w, "li %f %f %f %f\n", c-capWidth, max, c+capWidth, max)
-		fmt.Fprintf(w, "li %f %f %f %f\n", c, top, c, max)
-		fmt.Fprintf(w, "m %f %f\nt \"\\R%.3g\"\n", c-capWidth, max, b.max)
-		x += width + pad
-	}
-	fmt.Fprintf(w, "cl\n")
-}
-
-func minMax(boxes []box) (min, max float64) {
-	min, max = math.Inf(1), math.Inf(-1)
-	for _, b := range boxes {
-		if b.min < min {
-			min = b.min
-		}
-		if b.max > max {
-			max = b.max
+// Summarize computes the summary statistics of a box if they are pending
+// and, with -modes, its modes.
+func (b *box) summarize() {
+	if b.pending {
+		switch {
+		case b.sample != nil:
+			_, b.q1, b.q2, b.q3, _ = stats5InPlace(b.sample)
+			b.min, b.max = minMaxValues(b.values)
+		case *inPlace:
+			b.min, b.q1, b.q2, b.q3, b.max = stats5InPlace(b.values)
+		default:
+			b.min, b.q1, b.q2, b.q3, b.max = stats5(b.values)
 		}
+		b.mean, b.stddev = boxplot.MeanStddev(b.values)
+		b.pending = false
+	}
+	if *modes {
+		b.modeCount, b.hasModes = b.kdeModes(), true
 	}
-	return min, max
 }
 
-// MakeTr returns a function that applies a linear transform to its value
-// such that the range [min0, max0] → [min1, max1].
-func makeTr(min0, max0, min1, max1 float64) func(float64) float64 {
-	d0 := max0 - min0
-	d1 := max1 - min1
-	return func(v float64) float64 { return ((v-min0)/d0)*d1 + min1 }
+// Stats5 returns the five-number summary of the values,
+// with the quartiles by -quantile-method, as by boxplot.Stats5Method.
+// Stats5 reorders a copy of the input slice, leaving it unchanged.
+func stats5(vs []float64) (min, q1, q2, q3, max float64) {
+	return boxplot.Stats5Method(vs, quantileMethodOf())
+}
+
+// Stats5InPlace is like stats5, but it reorders the input slice
+// instead of a copy of it.
+func stats5InPlace(vs []float64) (min, q1, q2, q3, max float64) {
+	return boxplot.Stats5InPlace(vs, quantileMethodOf())
 }
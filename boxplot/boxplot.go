@@ -0,0 +1,125 @@
+// Package boxplot computes the statistics of box plots
+// and splits the tokens of their input, as the box command does,
+// for programs that summarize data sets as box plots without running box.
+//
+// The box command computes its statistics with this package:
+// Stats5 and Stats5Method summarize values with Tukey's hinges or another Method,
+// Quantile computes the quantiles of the Hyndman-Fan types,
+// and Select finds the order statistics that they need without sorting.
+// ScanTokens and Unquote split and unquote the tokens of its default input format.
+package boxplot
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Stats5 returns a five statistic summary of the values:
+// the minimum value,
+// the first quartile,
+// the second quartile (a.k.a., the median),
+// the third quartile,
+// and the maximum value.
+// The quartiles are Tukey's hinges, the medians of the lower and upper halves,
+// where the halves both include the median when there are an odd number of values.
+// This matches R's fivenum.
+// Stats5 reorders a copy of the input slice, leaving it unchanged.
+// There must be at least one value.
+func Stats5(values []float64) (min, q1, q2, q3, max float64) {
+	return Stats5Method(values, Tukey)
+}
+
+// Stats5Method is like Stats5, but with the quartiles by a quantile method.
+func Stats5Method(values []float64, m Method) (min, q1, q2, q3, max float64) {
+	return Stats5InPlace(append([]float64(nil), values...), m)
+}
+
+// Stats5InPlace is like Stats5Method, but it reorders the input slice
+// instead of a copy of it.
+// Rather than sorting the values, it selects the order statistics it needs,
+// so it takes linear time; see Select.
+func Stats5InPlace(vs []float64, m Method) (min, q1, q2, q3, max float64) {
+	if len(vs) == 1 {
+		return vs[0], vs[0], vs[0], vs[0], vs[0]
+	}
+	Select(vs, QuartileRanks(len(vs), m))
+	min, max = vs[0], vs[0]
+	for _, v := range vs[1:] {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	q1, q2, q3 = Quartiles(vs, m)
+	return min, q1, q2, q3, max
+}
+
+// Median returns the median of a sorted float64 slice.
+func Median(vs []float64) float64 {
+	if len(vs) == 1 {
+		return vs[0]
+	}
+	med := vs[len(vs)/2]
+	if len(vs)%2 == 0 {
+		med += vs[len(vs)/2-1]
+		med /= 2
+	}
+	return med
+}
+
+// MeanStddev returns the mean and sample standard deviation of the values.
+// The standard deviation of a single value is NaN.
+func MeanStddev(vs []float64) (mean, sd float64) {
+	for _, v := range vs {
+		mean += v
+	}
+	mean /= float64(len(vs))
+	for _, v := range vs {
+		sd += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sd / float64(len(vs)-1))
+}
+
+// ScanTokens is a bufio.SplitFunc that splits white-space separated tokens,
+// as bufio.ScanWords, except that a token beginning with a double quote
+// extends to the closing double quote, including any white space,
+// with backslash escaping a quote within it.
+// A quoted token keeps its quotes, so it never parses as a number.
+func ScanTokens(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for start < len(data) {
+		r, w := utf8.DecodeRune(data[start:])
+		if !unicode.IsSpace(r) {
+			break
+		}
+		start += w
+	}
+	if start == len(data) || data[start] != '"' {
+		return bufio.ScanWords(data, atEOF)
+	}
+	for i := start + 1; i < len(data); i++ {
+		switch data[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, data[start : i+1], nil
+		}
+	}
+	if atEOF {
+		return 0, nil, fmt.Errorf("unterminated quoted name %.20s", bytes.TrimSpace(data[start:]))
+	}
+	return start, nil, nil
+}
+
+// Unquote returns a name token without its quotes, if it is quoted.
+func Unquote(tok string) string {
+	if len(tok) < 2 || tok[0] != '"' {
+		return tok
+	}
+	if name, err := strconv.Unquote(tok); err == nil {
+		return name
+	}
+	return tok[1 : len(tok)-1]
+}
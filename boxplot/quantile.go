@@ -0,0 +1,126 @@
+package boxplot
+
+import (
+	"math"
+	"sort"
+)
+
+// A Method is a method of computing quantiles:
+// Tukey's hinges, or a Hyndman-Fan type from 1 to 9, as R's quantile function,
+// such as Method(7), the default of R, NumPy, and pandas.
+type Method int
+
+// Tukey is the method of Tukey's hinges, as R's fivenum.
+const Tukey Method = 0
+
+// Quartiles returns the quartiles of sorted values,
+// or of values in which only those at QuartileRanks are in their sorted places,
+// by a quantile method.
+func Quartiles(vs []float64, m Method) (q1, q2, q3 float64) {
+	if m == Tukey {
+		half := (len(vs) + 1) / 2
+		return Median(vs[:half]), Median(vs), Median(vs[len(vs)-half:])
+	}
+	return Quantile(vs, 0.25, m), Quantile(vs, 0.5, m), Quantile(vs, 0.75, m)
+}
+
+// QuartileRanks returns the 0-based ranks, in increasing order,
+// of the order statistics of n values from which Quartiles computes the quartiles
+// by a quantile method.
+func QuartileRanks(n int, m Method) []int {
+	var ranks []int
+	if m == Tukey {
+		half := (n + 1) / 2
+		for _, off := range []int{0, n - half} {
+			ranks = append(ranks, off+(half-1)/2, off+half/2)
+		}
+		ranks = append(ranks, (n-1)/2, n/2)
+	} else {
+		for _, p := range []float64{0.25, 0.5, 0.75} {
+			j, _ := QuantilePosition(n, p, m)
+			for _, i := range []float64{j, j + 1} {
+				ranks = append(ranks, int(math.Max(1, math.Min(float64(n), i)))-1)
+			}
+		}
+	}
+	sort.Ints(ranks)
+	return ranks
+}
+
+// Quantile returns the p-quantile of sorted values
+// by a Hyndman-Fan quantile type t, from 1 to 9,
+// as computed by R's quantile(x, p, type = t).
+// Types 1 to 3 are discontinuous:
+// 1 is the inverse of the empirical distribution function,
+// 2 is the same but averages at its discontinuities,
+// and 3 is the nearest even order statistic, as in SAS.
+// Types 4 to 9 interpolate linearly between order statistics
+// at the position a + p(n+1-a-b), with a and b depending on the type:
+// 7, the default of R, NumPy, and pandas, has a = b = 1,
+// and 8, which Hyndman and Fan recommend as median-unbiased, has a = b = 1/3.
+func Quantile(vs []float64, p float64, t Method) float64 {
+	j, h := QuantilePosition(len(vs), p, t)
+	n := float64(len(vs))
+	// X returns the 1-based ith order statistic, clamped to the values.
+	x := func(i float64) float64 {
+		return vs[int(math.Max(1, math.Min(n, i)))-1]
+	}
+	switch h {
+	case 0:
+		return x(j)
+	case 1:
+		return x(j + 1)
+	}
+	// Interpolating as lo + h(hi-lo), within lo and hi,
+	// keeps the quantiles of equal values equal to them
+	// and the quantiles in order despite rounding.
+	lo, hi := x(j), x(j+1)
+	return math.Max(lo, math.Min(hi, lo+h*(hi-lo)))
+}
+
+// QuantilePosition returns the position of the p-quantile of n sorted values
+// by the Hyndman-Fan quantile type t:
+// the quantile is (1-h)·x[j] + h·x[j+1],
+// where x is 1-based and clamped to x[1] and x[n].
+func QuantilePosition(n int, p float64, t Method) (j, h float64) {
+	pos := QuantileRawPosition(n, p, t)
+	// As in R, positions within a few ulps of an order statistic are at it.
+	const fuzz = 4 * 2.220446049250313e-16
+	j = math.Floor(pos + fuzz)
+	h = pos - j
+	switch t {
+	case 1:
+		h = step(h > 0, 1, 0)
+	case 2:
+		h = step(h > 0, 1, 0.5)
+	case 3:
+		h = step(h != 0 || math.Mod(j, 2) != 0, 1, 0)
+	default:
+		if math.Abs(h) < fuzz {
+			h = 0
+		}
+	}
+	return j, h
+}
+
+// QuantileRawPosition returns the real-valued position of the p-quantile
+// of n sorted values by the Hyndman-Fan quantile type t,
+// from which QuantilePosition finds the order statistics and weight.
+func QuantileRawPosition(n int, p float64, t Method) float64 {
+	switch t {
+	case 1, 2:
+		return float64(n) * p
+	case 3:
+		return float64(n)*p - 0.5
+	}
+	ab := [...][2]float64{4: {0, 1}, 5: {0.5, 0.5}, 6: {0, 0}, 7: {1, 1}, 8: {1.0 / 3, 1.0 / 3}, 9: {3.0 / 8, 3.0 / 8}}[t]
+	return ab[0] + p*(float64(n)+1-ab[0]-ab[1])
+}
+
+// Step returns a if cond is true, and otherwise b.
+func step(cond bool, a, b float64) float64 {
+	if cond {
+		return a
+	}
+	return b
+}
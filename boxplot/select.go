@@ -0,0 +1,154 @@
+package boxplot
+
+import (
+	"math"
+	"sort"
+)
+
+// SelectCutoff is the length of a range below which
+// selectRank and selectFloat64 sort it instead of partitioning it.
+const selectCutoff = 12
+
+// SelectRanks reorders data so that the element at each of the given 0-based ranks,
+// which must be in increasing order, is the one that would be there
+// if data were sorted, with no greater elements before it and no lesser after it.
+// It takes O(n) time for a fixed number of ranks, instead of the O(n log n) of sorting,
+// so the quartiles at the QuartileRanks of data can be found without sorting it,
+// as the box command does for integers with -exact.
+// Select is the same for a []float64, as used by Stats5,
+// without the cost of calls through sort.Interface.
+func SelectRanks(data sort.Interface, ranks []int) {
+	lo := 0
+	for _, k := range ranks {
+		if k < lo {
+			continue // A repeated rank is already in place.
+		}
+		selectRank(data, lo, data.Len(), k)
+		lo = k + 1
+	}
+}
+
+// SelectRank reorders data[lo:hi] so that data[k] is in its sorted place,
+// by quickselect with a median-of-three pivot and a three-way partition,
+// so runs of equal values, common in integer data, take linear time.
+// Quickselect is quadratic on adversarial inputs,
+// so after as many partitions as introsort allows,
+// it falls back to sorting what remains of the range.
+func selectRank(data sort.Interface, lo, hi, k int) {
+	budget := selectBudget(hi - lo)
+	for hi-lo > selectCutoff {
+		if budget == 0 {
+			sort.Sort(subrange{data, lo, hi - lo})
+			return
+		}
+		budget--
+		medianOfThree(data, lo, lo+(hi-lo)/2, hi-1)
+		// Partition about the pivot at lt, so that
+		// data[lo:lt] < pivot, data[lt:i] = pivot, and data[gt:hi] > pivot.
+		lt, i, gt := lo, lo+1, hi
+		for i < gt {
+			switch {
+			case data.Less(i, lt):
+				data.Swap(lt, i)
+				lt++
+				i++
+			case data.Less(lt, i):
+				gt--
+				data.Swap(i, gt)
+			default:
+				i++
+			}
+		}
+		switch {
+		case k < lt:
+			hi = lt
+		case k >= gt:
+			lo = gt
+		default:
+			return
+		}
+	}
+	for i := lo + 1; i < hi; i++ {
+		for j := i; j > lo && data.Less(j, j-1); j-- {
+			data.Swap(j, j-1)
+		}
+	}
+}
+
+// Select is SelectRanks for a []float64.
+// The ranks are selected together: each partition of a range
+// serves every rank within it, so nearby ranks, such as the quartiles,
+// share the partitions of the whole slice rather than repeating them.
+func Select(vs []float64, ranks []int) {
+	selectFloat64(vs, 0, len(vs), ranks, selectBudget(len(vs)))
+}
+
+// SelectFloat64 is selectRank for a []float64, for each of the ranks,
+// which must be in increasing order and within vs[lo:hi],
+// with budget partitions left before it sorts what remains of the range.
+// Rather than partitioning three ways, it partitions in two by Hoare's scheme,
+// with the pivot held by value, which swaps far fewer values.
+// Values equal to the pivot stop the scans from both ends,
+// so they are split evenly between the parts,
+// and runs of equal values still take linear time.
+func selectFloat64(vs []float64, lo, hi int, ranks []int, budget int) {
+	for len(ranks) > 0 && hi-lo > selectCutoff {
+		if budget == 0 {
+			sort.Float64s(vs[lo:hi])
+			return
+		}
+		budget--
+		mid := lo + (hi-lo)/2
+		medianOfThree(sort.Float64Slice(vs), mid, lo, hi-1)
+		// Partition so that vs[lo:j+1] <= pivot <= vs[j+1:hi].
+		// The pivot is not the last value, so neither part is empty.
+		pivot := vs[mid]
+		i, j := lo-1, hi
+		for {
+			for i++; vs[i] < pivot; i++ {
+			}
+			for j--; vs[j] > pivot; j-- {
+			}
+			if i >= j {
+				break
+			}
+			vs[i], vs[j] = vs[j], vs[i]
+		}
+		split := sort.SearchInts(ranks, j+1)
+		selectFloat64(vs, lo, j+1, ranks[:split], budget)
+		lo, ranks = j+1, ranks[split:]
+	}
+	if len(ranks) > 0 {
+		sort.Float64s(vs[lo:hi])
+	}
+}
+
+// SelectBudget returns the number of partitions that selectRank
+// makes of a range of n elements before falling back to sorting it.
+func selectBudget(n int) int {
+	return 2 * int(math.Ceil(math.Log2(float64(n+1))))
+}
+
+// MedianOfThree moves the median of data[a], data[b], and data[c] to data[a].
+func medianOfThree(data sort.Interface, a, b, c int) {
+	if data.Less(b, a) {
+		data.Swap(a, b)
+	}
+	if data.Less(c, b) {
+		data.Swap(b, c)
+		if data.Less(b, a) {
+			data.Swap(a, b)
+		}
+	}
+	data.Swap(a, b)
+}
+
+// A subrange is the n elements of data beginning at off.
+type subrange struct {
+	data   sort.Interface
+	off, n int
+}
+
+func (s subrange) Len() int           { return s.n }
+func (s subrange) Less(i, j int) bool { return s.data.Less(s.off+i, s.off+j) }
+func (s subrange) Swap(i, j int)      { s.data.Swap(s.off+i, s.off+j) }
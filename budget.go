@@ -0,0 +1,135 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"runtime"
+	"time"
+)
+
+// BudgetSeed is the seed of the random sampling of -budget,
+// fixed so that a sampled plot is reproducible.
+const budgetSeed = 1
+
+// MinSample is the smallest sample that -budget takes of a data set.
+const minSample = 1000
+
+// SampleForBudget chooses samples of the pending boxes
+// so that summarizing them is expected to take no longer than the budget,
+// which is what is left of -budget after reading them.
+// The cost of summarizing is estimated by timing the summary of a sample,
+// assuming that it grows linearly, since stats5 selects rather than sorts.
+// If the boxes cannot be summarized in time,
+// the quartiles of every box with more than m values are estimated
+// from a uniform random sample of m of them,
+// for the largest m that is expected to fit the budget, but at least minSample.
+// SampleForBudget reports each sampled box and its estimation error on standard error.
+func sampleForBudget(boxes []box, budget time.Duration) {
+	largest := -1
+	for i, b := range boxes {
+		if b.pending && (largest < 0 || b.n > boxes[largest].n) {
+			largest = i
+		}
+	}
+	if largest < 0 || boxes[largest].n <= minSample {
+		return
+	}
+	rng := rand.New(rand.NewSource(budgetSeed))
+	// Take the fastest of a few timings, to discount pauses.
+	s := sample(rng, boxes[largest].values, minSample)
+	perOp := math.Inf(1)
+	for i := 0; i < 5; i++ {
+		start := time.Now()
+		stats5(s)
+		perOp = math.Min(perOp, float64(time.Since(start))/cost(minSample))
+	}
+	procs := float64(runtime.GOMAXPROCS(0))
+	estimate := func(m int) float64 {
+		total := 0.0
+		for _, b := range boxes {
+			if b.pending {
+				total += cost(minInt(b.n, m))
+			}
+		}
+		return total * perOp / procs
+	}
+	if estimate(boxes[largest].n) <= float64(budget) {
+		return
+	}
+	lo, hi := minSample, boxes[largest].n
+	for lo < hi {
+		m := (lo + hi + 1) / 2
+		if estimate(m) <= float64(budget) {
+			lo = m
+		} else {
+			hi = m - 1
+		}
+	}
+	for i := range boxes {
+		b := &boxes[i]
+		if !b.pending || b.n <= lo {
+			continue
+		}
+		b.sample = sample(rng, b.values, lo)
+		warnf("%s: summarized from a sample of %d of %d values; quartile ranks within ±%s%%",
+			b.name, lo, b.n, formatValue(100*quartileRankError(lo)))
+	}
+}
+
+// QuartileRankError returns the half width of the 95% confidence interval
+// of the rank, as a fraction of the data set, of a quartile estimated
+// from a uniform random sample of m values.
+func quartileRankError(m int) float64 {
+	return 1.96 * math.Sqrt(0.25*0.75/float64(m))
+}
+
+// Cost returns the relative cost of summarizing n values.
+func cost(n int) float64 {
+	return float64(n)
+}
+
+// Sample returns a uniform random sample of m of the values, chosen without replacement
+// by Li's Algorithm L, which skips over the values that it does not choose,
+// drawing O(m(1+log(n/m))) random numbers for n values, instead of one for each.
+// The sample is not in the order of the values.
+func sample(rng *rand.Rand, vs []float64, m int) []float64 {
+	if len(vs) <= m {
+		return append([]float64(nil), vs...)
+	}
+	// U is uniform on (0, 1], so that its logarithm is finite.
+	u := func() float64 { return 1 - rng.Float64() }
+	s := append([]float64(nil), vs[:m]...)
+	w := math.Exp(math.Log(u()) / float64(m))
+	for i := m - 1; ; {
+		skip := math.Floor(math.Log(u()) / math.Log(1-w))
+		if float64(i)+skip+1 >= float64(len(vs)) {
+			return s
+		}
+		i += int(skip) + 1
+		s[rng.Intn(m)] = vs[i]
+		w *= math.Exp(math.Log(u()) / float64(m))
+	}
+}
+
+// MinMaxValues returns the minimum and maximum of a non-empty slice.
+func minMaxValues(vs []float64) (min, max float64) {
+	min, max = vs[0], vs[0]
+	for _, v := range vs[1:] {
+		min, max = math.Min(min, v), math.Max(max, v)
+	}
+	return min, max
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+)
+
+// TestSampleUniform tests that a sample has m distinct values
+// whose median is within the expected error of the median of all of the values.
+func TestSampleUniform(t *testing.T) {
+	const n, m = 1000000, 1000
+	vs := make([]float64, n)
+	for i := range vs {
+		vs[i] = float64(i)
+	}
+	s := sample(rand.New(rand.NewSource(budgetSeed)), vs, m)
+	if len(s) != m {
+		t.Fatalf("sample of %d values, want %d", len(s), m)
+	}
+	sort.Float64s(s)
+	for i := 1; i < len(s); i++ {
+		if s[i] == s[i-1] {
+			t.Fatalf("value %v sampled twice", s[i])
+		}
+	}
+	// The rank of the sample median is within ±4 standard errors.
+	if med, tol := s[m/2]/n, 4*math.Sqrt(0.25/m); med < 0.5-tol || med > 0.5+tol {
+		t.Errorf("sample median at rank %.3f, want 0.5±%.3f", med, tol)
+	}
+}
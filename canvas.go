@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// A canvas is a target for drawing a figure.
+// Coordinates range from 0 to 1, with the origin at the bottom left.
+// Each drawing method takes a role describing what is drawn,
+// such as "whisker" or "median".
+type canvas interface {
+	// Line draws a line segment from x0, y0 to x1, y1.
+	line(role string, x0, y0, x1, y1 float64)
+	// Box draws the outline of a rectangle with corners x0, y0 and x1, y1.
+	box(role string, x0, y0, x1, y1 float64)
+	// Circle draws the outline of a circle.
+	circle(role string, x, y, r float64)
+	// Polyline draws connected line segments through the points.
+	polyline(role string, xs, ys []float64)
+	// Text draws a line of text at x, y,
+	// aligned by one of the plot(1) alignments L, C, or R.
+	text(role string, x, y float64, align byte, s string)
+	// Tooltip attaches text to the shape drawn last,
+	// to be shown when the pointer is over it.
+	// Outputs that are not interactive record it or ignore it.
+	tooltip(text string)
+	// Group marks the beginning of the drawing of the named box.
+	// The empty name marks the end of a box.
+	group(name string)
+	// Close finishes drawing.
+	close() error
+}
+
+// A plotCanvas draws commands for plot(1).
+type plotCanvas struct {
+	w io.Writer
+}
+
+func (c plotCanvas) line(_ string, x0, y0, x1, y1 float64) {
+	fmt.Fprintf(c.w, "li %f %f %f %f\n", x0, y0, x1, y1)
+}
+
+func (c plotCanvas) box(_ string, x0, y0, x1, y1 float64) {
+	fmt.Fprintf(c.w, "bo %f %f %f %f\n", x0, y0, x1, y1)
+}
+
+func (c plotCanvas) circle(_ string, x, y, r float64) {
+	fmt.Fprintf(c.w, "ci %f %f %f\n", x, y, r)
+}
+
+func (c plotCanvas) polyline(_ string, xs, ys []float64) {
+	fmt.Fprintf(c.w, "m %f %f\n", xs[0], ys[0])
+	for i := range xs[1:] {
+		fmt.Fprintf(c.w, "v %f %f\n", xs[i+1], ys[i+1])
+	}
+}
+
+func (c plotCanvas) text(_ string, x, y float64, align byte, s string) {
+	fmt.Fprintf(c.w, "m %f %f\nt \"\\%c%s\"\n", x, y, align, plotText(s))
+}
+
+func (c plotCanvas) tooltip(string) {}
+
+// Group sets the color and pen of the shapes of the box
+// to those of its style, if there are -style rules,
+// and resets them to black and solid at the end of the box.
+func (c plotCanvas) group(name string) {
+	if len(styles) == 0 {
+		return
+	}
+	var st style
+	if name != "" {
+		st = styleOf(name)
+	}
+	color, pen := "black", "solid"
+	if st.color != "" {
+		color = st.color
+	}
+	if st.line != "" {
+		pen = st.line
+	}
+	fmt.Fprintf(c.w, "co %s\npe %s\n", color, pen)
+}
+
+func (c plotCanvas) close() error {
+	_, err := fmt.Fprintf(c.w, "cl\n")
+	return err
+}
+
+// A geometryCanvas records what is drawn
+// and writes it as JSON when closed.
+type geometryCanvas struct {
+	w        io.Writer
+	geometry struct {
+		// Shapes are the shapes that are not part of any box.
+		Shapes []shape     `json:"shapes"`
+		Boxes  []boxShapes `json:"boxes"`
+	}
+	inBox bool
+}
+
+// BoxShapes are the shapes drawn for a box.
+type boxShapes struct {
+	Name string `json:"name"`
+	// Color and Line are the style of the box, if it is not the default.
+	Color  string  `json:"color,omitempty"`
+	Line   string  `json:"line,omitempty"`
+	Shapes []shape `json:"shapes"`
+}
+
+// A shape is a recorded drawing operation.
+// Lines have two points, their ends;
+// boxes have two points, their corners;
+// circles have one point, their center;
+// polylines have their points;
+// and texts have one point, their anchor.
+type shape struct {
+	Role   string       `json:"role"`
+	Kind   string       `json:"kind"`
+	Points [][2]float64 `json:"points"`
+	R      float64      `json:"r,omitempty"`
+	Align  string       `json:"align,omitempty"`
+	Text   string       `json:"text,omitempty"`
+	// Tooltip is the text attached to the shape by tooltip.
+	Tooltip string `json:"tooltip,omitempty"`
+}
+
+func (c *geometryCanvas) add(s shape) {
+	if c.inBox {
+		b := &c.geometry.Boxes[len(c.geometry.Boxes)-1]
+		b.Shapes = append(b.Shapes, s)
+		return
+	}
+	c.geometry.Shapes = append(c.geometry.Shapes, s)
+}
+
+func (c *geometryCanvas) line(role string, x0, y0, x1, y1 float64) {
+	c.add(shape{Role: role, Kind: "line", Points: [][2]float64{{x0, y0}, {x1, y1}}})
+}
+
+func (c *geometryCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.add(shape{Role: role, Kind: "box", Points: [][2]float64{{x0, y0}, {x1, y1}}})
+}
+
+func (c *geometryCanvas) circle(role string, x, y, r float64) {
+	c.add(shape{Role: role, Kind: "circle", Points: [][2]float64{{x, y}}, R: r})
+}
+
+func (c *geometryCanvas) polyline(role string, xs, ys []float64) {
+	s := shape{Role: role, Kind: "polyline"}
+	for i := range xs {
+		s.Points = append(s.Points, [2]float64{xs[i], ys[i]})
+	}
+	c.add(s)
+}
+
+func (c *geometryCanvas) text(role string, x, y float64, align byte, str string) {
+	c.add(shape{Role: role, Kind: "text", Points: [][2]float64{{x, y}}, Align: string(align), Text: str})
+}
+
+func (c *geometryCanvas) tooltip(text string) {
+	shapes := c.geometry.Shapes
+	if c.inBox {
+		shapes = c.geometry.Boxes[len(c.geometry.Boxes)-1].Shapes
+	}
+	if len(shapes) > 0 {
+		shapes[len(shapes)-1].Tooltip = text
+	}
+}
+
+func (c *geometryCanvas) group(name string) {
+	c.inBox = name != ""
+	if c.inBox {
+		st := styleOf(name)
+		c.geometry.Boxes = append(c.geometry.Boxes, boxShapes{Name: name, Color: st.color, Line: st.line})
+	}
+}
+
+// Close writes the recorded shapes as JSON, one shape per line.
+func (c *geometryCanvas) close() error {
+	var buf bytes.Buffer
+	buf.WriteString("{\"shapes\": [")
+	writeShapes(&buf, c.geometry.Shapes, "\n\t")
+	buf.WriteString("\n],\n\"boxes\": [")
+	for i, b := range c.geometry.Boxes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(b.Name)
+		fmt.Fprintf(&buf, "\n\t{\"name\": %s, ", name)
+		if b.Color != "" {
+			fmt.Fprintf(&buf, "\"color\": %q, ", b.Color)
+		}
+		if b.Line != "" {
+			fmt.Fprintf(&buf, "\"line\": %q, ", b.Line)
+		}
+		buf.WriteString("\"shapes\": [")
+		writeShapes(&buf, b.Shapes, "\n\t\t")
+		buf.WriteString("\n\t]}")
+	}
+	buf.WriteString("\n]}\n")
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
+
+// WriteShapes writes a comma-separated list of JSON shapes,
+// each preceded by the prefix.
+func writeShapes(buf *bytes.Buffer, shapes []shape, prefix string) {
+	for i, s := range shapes {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		data, _ := json.Marshal(s)
+		buf.WriteString(prefix)
+		buf.Write(data)
+	}
+}
+
+// A planCanvas records what is drawn
+// and writes it as a plan when closed:
+// a canonical line of text for each shape, sorted,
+// with coordinates rounded to 4 decimal places,
+// so that plans can be diffed to find changes in what is drawn
+// without noise from drawing order or floating point rounding.
+//
+// Each line is the name of the box that the shape belongs to, or - if none,
+// followed by the role, the kind, the points, the radius of circles,
+// and the alignment and quoted text of texts,
+// and then tip= and the quoted tooltip of shapes with one.
+type planCanvas struct {
+	w       io.Writer
+	boxName string
+	lines   []string
+}
+
+func (c *planCanvas) add(role, kind string, xs, ys []float64, extra string) {
+	name := c.boxName
+	if name == "" {
+		name = "-"
+	}
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%q %s %s", name, role, kind)
+	for i := range xs {
+		fmt.Fprintf(&buf, " %s,%s", planCoord(xs[i]), planCoord(ys[i]))
+	}
+	buf.WriteString(extra)
+	c.lines = append(c.lines, buf.String())
+}
+
+// PlanCoord returns a coordinate rounded to 4 decimal places,
+// with negative zero written as zero.
+func planCoord(v float64) string {
+	s := strconv.FormatFloat(v, 'f', 4, 64)
+	if s == "-0.0000" {
+		s = "0.0000"
+	}
+	return s
+}
+
+func (c *planCanvas) line(role string, x0, y0, x1, y1 float64) {
+	c.add(role, "line", []float64{x0, x1}, []float64{y0, y1}, "")
+}
+
+func (c *planCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.add(role, "box", []float64{x0, x1}, []float64{y0, y1}, "")
+}
+
+func (c *planCanvas) circle(role string, x, y, r float64) {
+	c.add(role, "circle", []float64{x}, []float64{y}, " r="+planCoord(r))
+}
+
+func (c *planCanvas) polyline(role string, xs, ys []float64) {
+	c.add(role, "polyline", xs, ys, "")
+}
+
+func (c *planCanvas) text(role string, x, y float64, align byte, s string) {
+	c.add(role, "text", []float64{x}, []float64{y}, fmt.Sprintf(" %c %q", align, s))
+}
+
+func (c *planCanvas) tooltip(text string) {
+	if len(c.lines) > 0 {
+		c.lines[len(c.lines)-1] += fmt.Sprintf(" tip=%q", text)
+	}
+}
+
+func (c *planCanvas) group(name string) { c.boxName = name }
+
+// Close writes the sorted plan lines.
+func (c *planCanvas) close() error {
+	sort.Strings(c.lines)
+	var buf bytes.Buffer
+	for _, l := range c.lines {
+		buf.WriteString(l)
+		buf.WriteByte('\n')
+	}
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Annotations maps panel and group names to captions
+// read from the -annotations file.
+var annotations map[string]string
+
+// ReadAnnotations reads an annotations file.
+// Each non-blank line is a panel or group name
+// followed by white space and the caption text.
+// Lines beginning with # are comments.
+func readAnnotations(path string) (map[string]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	as := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fs := strings.SplitN(line, " ", 2)
+		if len(fs) == 1 {
+			fs = strings.SplitN(line, "\t", 2)
+		}
+		if len(fs) == 1 {
+			return nil, fmt.Errorf("%s: missing caption for %s", path, fs[0])
+		}
+		as[fs[0]] = strings.TrimSpace(fs[1])
+	}
+	return as, scanner.Err()
+}
+
+// Caption returns the caption of the panel or group with the given name
+// and boxes: the total sample count, if -captions is set,
+// followed by any caption from the annotations file.
+func caption(name string, boxes []box) string {
+	var parts []string
+	if *autoCaptions {
+		n := 0
+		for _, b := range boxes {
+			n += b.n
+		}
+		parts = append(parts, fmt.Sprintf("n=%d", n))
+	}
+	if a, ok := annotations[name]; ok {
+		parts = append(parts, a)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+// KmQuantile returns the p-quantile of the Kaplan–Meier estimate
+// of the distribution of the values of a box with censored values:
+// the smallest value at which the estimated fraction of values above it
+// falls to 1-p or below.
+// It returns false if the estimate never falls that far,
+// because too many of the largest values are censored,
+// in which case the quantile is only known to be above
+// the largest censoring limit.
+//
+// Censored values count as above every uncensored value equal to their limit.
+func (b box) kmQuantile(p float64) (float64, bool) {
+	vs := append([]float64(nil), b.values...)
+	sort.Float64s(vs)
+	censored := make(map[float64]int)
+	for _, v := range b.censored {
+		censored[v]++
+	}
+	surv := 1.0
+	atRisk := len(vs)
+	for i := 0; i < len(vs); {
+		t := vs[i]
+		j := i
+		for j < len(vs) && vs[j] == t {
+			j++
+		}
+		if events := j - i - censored[t]; events > 0 {
+			surv *= 1 - float64(events)/float64(atRisk)
+			if surv <= 1-p+1e-12 {
+				return t, true
+			}
+		}
+		atRisk -= j - i
+		i = j
+	}
+	return math.NaN(), false
+}
+
+// CensorNotes returns the notes for the censored values of a box:
+// their count and the Kaplan–Meier median,
+// or the largest censoring limit if the median is above it.
+func censorNotes(b box) []string {
+	if len(b.censored) == 0 {
+		return nil
+	}
+	ns := []string{fmt.Sprintf("censored=%d", len(b.censored))}
+	if m, ok := b.kmQuantile(0.5); ok {
+		ns = append(ns, "km="+formatValue(m))
+	} else {
+		_, max := minMaxValues(b.censored)
+		ns = append(ns, "km>"+formatValue(max))
+	}
+	return ns
+}
+
+// DrawCensored marks each distinct censoring limit of a box
+// with an upward-pointing triangle centered at x, with its tip at the limit,
+// showing that the values there are known only to be at least that large.
+func drawCensored(cv canvas, b box, x, w float64, tr func(float64) float64) {
+	seen := make(map[float64]bool)
+	for _, v := range b.censored {
+		if seen[v] {
+			continue
+		}
+		seen[v] = true
+		y := tr(v)
+		cv.polyline("censored", []float64{x - w, x, x + w, x - w}, []float64{y - 2*w, y, y - 2*w, y - 2*w})
+	}
+}
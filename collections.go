@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"flag"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Collections are named sets of data sets that are kept by the server,
+// so that many jobs can append their results to one
+// and the plot of the results so far can be fetched at any time.
+// A collection is created by its first append,
+// and it expires if it is not appended to within the ttl.
+// Collections are kept in memory, so they last
+// at most as long as the server.
+type collections struct {
+	ttl  time.Duration
+	sets map[string]*collection
+}
+
+// A collection is the data sets appended to a named collection.
+type collection struct {
+	// Names are the names of the data sets in order of first appearance.
+	names  []string
+	values map[string][]float64
+	// Digests are the merged sketches appended to the data sets, if any,
+	// with the least minimum and greatest maximum of the sketches.
+	digests map[string]*digest
+	updated time.Time
+}
+
+// ServeHTTP handles requests to /v1/collections/<name>/data,
+// which append to the collection,
+// and /v1/collections/<name>/plot, which plot it.
+// The query parameters give flags, as for /v1/plot:
+// the input flags for appends, and the output flags for plots.
+// Appended data sets are merged with the data sets of the same name:
+// raw values are appended, and sketches and histograms merge their centroids,
+// but data sets of summary statistics alone cannot be appended.
+func (cs *collections) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/v1/collections/")
+	i := strings.LastIndexByte(path, '/')
+	if i <= 0 {
+		httpStatusError(w, http.StatusNotFound, "not found")
+		return
+	}
+	name, op := path[:i], path[i+1:]
+
+	renderMu.Lock()
+	defer renderMu.Unlock()
+	cs.expire()
+	switch {
+	case op == "data" && r.Method == http.MethodPost:
+		input, err := readBody(r.Body)
+		if err == nil {
+			err = setQueryFlags(r.URL.Query())
+		}
+		if err == nil {
+			err = cs.append(name, input)
+		}
+		if err != nil {
+			httpError(w, err)
+		}
+	case op == "plot" && r.Method == http.MethodGet:
+		c, ok := cs.sets[name]
+		if !ok {
+			httpStatusError(w, http.StatusNotFound, "not found")
+			return
+		}
+		if err := setQueryFlags(r.URL.Query()); err != nil {
+			httpError(w, err)
+			return
+		}
+		writePlot(w, func(out io.Writer) error { return output(c.boxes(), out, time.Now()) })
+	default:
+		httpStatusError(w, http.StatusMethodNotAllowed, "method not allowed")
+	}
+}
+
+// ReceiveOTLP returns a handler for the OTLP/HTTP receiver endpoints,
+// /v1/traces and /v1/metrics, which appends the spans or histograms
+// of OTLP/JSON export requests to the named collection.
+// Only the JSON encoding is supported, optionally gzipped.
+// The query parameters give flags, as for appends,
+// so an exporter may be pointed at /v1/traces?otlp-group=http.route.
+func (cs *collections) receiveOTLP(name string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			httpStatusError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/json") {
+			httpStatusError(w, http.StatusUnsupportedMediaType, "only OTLP/JSON is supported")
+			return
+		}
+		body := io.Reader(r.Body)
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			z, err := gzip.NewReader(r.Body)
+			if err != nil {
+				httpError(w, err)
+				return
+			}
+			body = z
+		}
+		input, err := readBody(body)
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+
+		renderMu.Lock()
+		defer renderMu.Unlock()
+		cs.expire()
+		err = setQueryFlags(r.URL.Query())
+		if err == nil {
+			err = flag.Set("format", "otlp")
+		}
+		if err == nil {
+			err = cs.append(name, input)
+		}
+		if err != nil {
+			httpError(w, err)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, "{}")
+	}
+}
+
+// Append reads data sets from input, according to the flags,
+// and appends their values to the named collection.
+// Nothing is appended if the collection would exceed the limits.
+func (cs *collections) append(name string, input []byte) error {
+	boxes, err := readInput(bytes.NewReader(input))
+	if err != nil {
+		return err
+	}
+	for _, b := range boxes {
+		if b.digest == nil && b.values == nil && b.n > 0 {
+			return errBadAppend
+		}
+	}
+	c, ok := cs.sets[name]
+	if !ok {
+		c = &collection{values: make(map[string][]float64), digests: make(map[string]*digest)}
+	}
+	if err := c.checkAppend(boxes); err != nil {
+		return err
+	}
+	cs.sets[name] = c
+	for _, b := range boxes {
+		if _, ok := c.values[b.name]; !ok {
+			c.names = append(c.names, b.name)
+		}
+		c.values[b.name] = append(c.values[b.name], b.values...)
+		if b.digest != nil {
+			d := c.digests[b.name]
+			if d == nil {
+				d = &digest{}
+				c.digests[b.name] = d
+			}
+			if len(b.digest.centroids) > 0 {
+				if len(d.centroids) == 0 {
+					d.min, d.max = b.digest.min, b.digest.max
+				}
+				d.min, d.max = math.Min(d.min, b.digest.min), math.Max(d.max, b.digest.max)
+				d.centroids = append(d.centroids, b.digest.centroids...)
+			}
+		}
+	}
+	c.updated = time.Now()
+	return nil
+}
+
+// CheckAppend returns a limitError if appending the boxes
+// would take the collection beyond the limits.
+func (c *collection) checkAppend(boxes []box) error {
+	sets := len(c.names)
+	added := make(map[string]int)
+	for _, b := range boxes {
+		if _, ok := c.values[b.name]; !ok {
+			if _, ok := added[b.name]; !ok {
+				sets++
+			}
+		}
+		added[b.name] += len(b.values) + centroidCount(b)
+	}
+	if limits.sets > 0 && sets > limits.sets {
+		return &limitError{limit: limits.prefix + "max-datasets", max: int64(limits.sets)}
+	}
+	for name, n := range added {
+		n += len(c.values[name])
+		if d := c.digests[name]; d != nil {
+			n += len(d.centroids)
+		}
+		if err := limits.checkValues(n); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+var errBadAppend = errors.New("data sets of summary statistics cannot be appended to a collection")
+
+// Expire deletes the collections that have not been updated within the ttl.
+func (cs *collections) expire() {
+	for name, c := range cs.sets {
+		if time.Since(c.updated) > cs.ttl {
+			delete(cs.sets, name)
+		}
+	}
+}
+
+// Boxes returns a box for each data set of the collection,
+// with a copy of its values, so summarizing does not change the collection.
+// A data set with appended sketches is drawn from a digest
+// of their centroids and a unit-weight centroid for each value,
+// whose minimum and maximum are the extremes of the sketches and values,
+// not of the centroid means.
+func (c *collection) boxes() []box {
+	var boxes []box
+	for _, name := range c.names {
+		if d := c.digests[name]; d != nil {
+			merged := &digest{centroids: append([]centroid(nil), d.centroids...)}
+			for _, v := range c.values[name] {
+				merged.centroids = append(merged.centroids, centroid{mean: v, weight: 1})
+			}
+			sortCentroids(merged)
+			if len(d.centroids) > 0 {
+				merged.min, merged.max = math.Min(merged.min, d.min), math.Max(merged.max, d.max)
+			}
+			boxes = append(boxes, digestBox(name, merged))
+			continue
+		}
+		boxes = append(boxes, newBox(name, append([]float64(nil), c.values[name]...)))
+	}
+	return boxes
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"testing"
+)
+
+// TestCollectionSketchExtremes tests that a data set drawn from sketches
+// appended to a collection keeps their minimum and maximum,
+// rather than the means of their extreme centroids.
+func TestCollectionSketchExtremes(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+	if err := flag.Set("format", "tdigest"); err != nil {
+		t.Fatal(err)
+	}
+	cs := &collections{sets: make(map[string]*collection)}
+	for _, d := range []*digest{
+		{min: 0, max: 50, centroids: []centroid{{mean: 20, weight: 5}, {mean: 30, weight: 5}}},
+		{min: 40, max: 100, centroids: []centroid{{mean: 60, weight: 5}, {mean: 70, weight: 5}}},
+	} {
+		input := "a " + base64.StdEncoding.EncodeToString(encodeTDigest(d)) + "\n"
+		if err := cs.append("c", []byte(input)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	boxes := cs.sets["c"].boxes()
+	if len(boxes) != 1 || boxes[0].min != 0 || boxes[0].max != 100 {
+		t.Errorf("got %+v, want one data set with min 0 and max 100", boxes)
+	}
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// Compare runs the compare command with the given arguments
+// and returns the exit status.
+//
+// Compare reads old and new results, each a file or a directory of files,
+// as report reads them, and prints a table of the ratios of the new to the old
+// -quantiles of each data set of the same name,
+// with a bootstrap confidence interval on each ratio,
+// so that a change in the tail, which the median hides, is evident.
+// The quantiles are by -quantile-method, or its type 7 for tukey,
+// whose hinges are only quartiles.
+// The intervals are percentile intervals from -resamples resamples
+// of the old and new values, drawn with a fixed seed,
+// so the table is the same from run to run.
+// Data sets read from sketches, which have no raw values,
+// have ratios of the quantiles of their sketches but no intervals,
+// and data sets of summary statistics alone have no ratios.
+// A ratio shown to be more than -threshold worse, by its interval,
+// or by the ratio itself if it has none, is a regression,
+// and the exit status is exitRegression if there are any.
+func compare(args []string) int {
+	fs := flag.NewFlagSet("compare", flag.ContinueOnError)
+	qs := fs.String("quantiles", "50,90,99", "comma-separated `percentiles` to compare")
+	resamples := fs.Int("resamples", 1000, "number of bootstrap resamples")
+	confidence := fs.Float64("confidence", 0.95, "confidence level of the intervals")
+	threshold := fs.String("threshold", "5%", "ratio `percentage` beyond which a worse quantile is a regression")
+	higherBetter := fs.Bool("higher-better", false, "treat increases as improvements")
+	parseFlags(fs, args)
+	// The results may come before the flags, as in box compare old.txt new.txt -quantiles 99.
+	var paths []string
+	for fs.NArg() > 0 && len(paths) < 2 {
+		paths = append(paths, fs.Arg(0))
+		parseFlags(fs, fs.Args()[1:])
+	}
+	ps, err := parseQuantiles(*qs)
+	if err == nil && !(*confidence > 0 && *confidence < 1) {
+		err = fmt.Errorf("-confidence must be between 0 and 1")
+	}
+	if err == nil && *resamples < 1 {
+		err = fmt.Errorf("-resamples must be at least 1")
+	}
+	if err == nil {
+		err = checkQuantileMethod()
+	}
+	var thresh float64
+	if err == nil {
+		thresh, err = parseThreshold(*threshold)
+	}
+	if len(paths) != 2 || fs.NArg() > 0 || err != nil {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "box compare: %v\n", err)
+		}
+		fmt.Fprintln(os.Stderr, "usage: box compare OLD NEW [-quantiles 50,90,99] [-resamples 1000] [-confidence 0.95] [-threshold 5%] [-higher-better]")
+		return exitUsage
+	}
+	old, err := readResults(paths[0])
+	var new []box
+	if err == nil {
+		new, err = readResults(paths[1])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box compare: %v\n", err)
+		return exitStatus(withStatus(exitParse, err))
+	}
+	t := quantileMethodOf()
+	if t == boxplot.Tukey {
+		t = 7
+	}
+	q := quantileComparer{
+		ps:           ps,
+		t:            t,
+		resamples:    *resamples,
+		confidence:   *confidence,
+		threshold:    thresh,
+		higherBetter: *higherBetter,
+	}
+	regressions, err := q.write(os.Stdout, compareAll(old, new, 0, false))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box compare: %v\n", err)
+		return exitOutput
+	}
+	if regressions > 0 {
+		return exitRegression
+	}
+	return exitOK
+}
+
+// ParseThreshold parses a -threshold value, a percentage, and returns it as a fraction.
+func parseThreshold(s string) (float64, error) {
+	t, err := strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || !strings.HasSuffix(s, "%") || !(t >= 0) || math.IsInf(t, 0) {
+		return 0, fmt.Errorf("Bad threshold: %s", s)
+	}
+	return t / 100, nil
+}
+
+// ParseQuantiles parses a -quantiles value, a comma-separated list of percentiles,
+// and returns them as fractions.
+func parseQuantiles(s string) ([]float64, error) {
+	var ps []float64
+	for _, f := range strings.Split(s, ",") {
+		p, err := strconv.ParseFloat(strings.TrimSpace(f), 64)
+		if err != nil || !(p > 0 && p <= 100) {
+			return nil, fmt.Errorf("Bad quantile: %s", f)
+		}
+		ps = append(ps, p/100)
+	}
+	return ps, nil
+}
+
+// ReadResults reads the results of a file, as box reads its input,
+// or of a directory, as by readResultDir, and returns their summarized boxes.
+func readResults(path string) ([]box, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return readResultDir(path)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	boxes, err := readInput(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	summarize(boxes)
+	return boxes, nil
+}
+
+// A quantileComparer compares the quantiles of old and new boxes.
+type quantileComparer struct {
+	// Ps are the quantiles to compare, as fractions.
+	ps []float64
+	// T is the Hyndman-Fan quantile type.
+	t          boxplot.Method
+	resamples  int
+	confidence float64
+	// Threshold is the fraction by which a ratio must be shown to be worse than 1
+	// for it to be a regression.
+	threshold float64
+	// HigherBetter is whether a ratio below 1 is worse, instead of one above.
+	higherBetter bool
+}
+
+// CompareSeed seeds the resampling of compare, so its intervals are reproducible.
+const compareSeed = 1
+
+// Write writes the table of the quantile ratios of the comparisons,
+// followed by a line for each regression,
+// and returns the number of regressions.
+func (q quantileComparer) write(w io.Writer, cs []comparison) (int, error) {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(tw, "# ratios of new to old quantiles, with %s%% bootstrap intervals from %d resamples\n",
+		strconv.FormatFloat(100*q.confidence, 'g', -1, 64), q.resamples)
+	header := []string{"name", "old n", "new n"}
+	for _, p := range q.ps {
+		header = append(header, "p"+strconv.FormatFloat(100*p, 'g', -1, 64))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	rng := rand.New(rand.NewSource(compareSeed))
+	var regressions []string
+	for _, c := range cs {
+		row := []string{c.name, "-", "-"}
+		if c.old != nil {
+			row[1] = strconv.Itoa(c.old.n)
+		}
+		if c.new != nil {
+			row[2] = strconv.Itoa(c.new.n)
+		}
+		switch {
+		case c.old == nil:
+			row = append(row, "only in new")
+		case c.new == nil:
+			row = append(row, "only in old")
+		default:
+			for i, r := range q.ratios(rng, *c.old, *c.new) {
+				row = append(row, r.String())
+				if q.worse(r) {
+					regressions = append(regressions, fmt.Sprintf("# regression: %s %s %s is more than %s%% worse",
+						c.name, header[3+i], r, formatValue(100*q.threshold)))
+				}
+			}
+		}
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return 0, err
+	}
+	for _, r := range regressions {
+		if _, err := fmt.Fprintln(w, r); err != nil {
+			return 0, err
+		}
+	}
+	return len(regressions), nil
+}
+
+// A quantileRatio is the ratio of a new to an old quantile
+// with the ends of its confidence interval, which are NaN if it has none.
+type quantileRatio struct {
+	r, lo, hi float64
+}
+
+// String returns the ratio formatted with its interval, as in 1.12 [1.05, 1.2],
+// or - if it is NaN.
+func (qr quantileRatio) String() string {
+	switch {
+	case math.IsNaN(qr.r):
+		return "-"
+	case math.IsNaN(qr.lo):
+		return formatValue(qr.r)
+	}
+	return fmt.Sprintf("%s [%s, %s]", formatValue(qr.r), formatValue(qr.lo), formatValue(qr.hi))
+}
+
+// Worse returns whether a ratio is shown to be more than the threshold worse:
+// whether its interval, or the ratio itself if it has none,
+// is entirely beyond 1 plus the threshold, or, with higherBetter, below 1 minus it.
+func (q quantileComparer) worse(qr quantileRatio) bool {
+	lo, hi := qr.lo, qr.hi
+	if math.IsNaN(lo) {
+		lo, hi = qr.r, qr.r
+	}
+	if q.higherBetter {
+		return hi < 1-q.threshold
+	}
+	return lo > 1+q.threshold
+}
+
+// Ratios returns the ratio of the new to the old box at each quantile,
+// with its confidence interval.
+func (q quantileComparer) ratios(rng *rand.Rand, old, new box) []quantileRatio {
+	rs := make([]quantileRatio, len(q.ps))
+	if old.values == nil || new.values == nil {
+		for i, p := range q.ps {
+			rs[i] = quantileRatio{r: math.NaN(), lo: math.NaN(), hi: math.NaN()}
+			if old.digest != nil && new.digest != nil {
+				rs[i].r = new.digest.quantile(p) / old.digest.quantile(p)
+			}
+		}
+		return rs
+	}
+	ov, nv := sortedCopy(old.values), sortedCopy(new.values)
+	boot := q.bootstrap(rng, ov, nv)
+	a := (1 - q.confidence) / 2
+	for i, p := range q.ps {
+		r := boxplot.Quantile(nv, p, q.t) / boxplot.Quantile(ov, p, q.t)
+		sort.Float64s(boot[i])
+		lo, hi := boxplot.Quantile(boot[i], a, 7), boxplot.Quantile(boot[i], 1-a, 7)
+		rs[i] = quantileRatio{r: r, lo: lo, hi: hi}
+	}
+	return rs
+}
+
+// Bootstrap returns, for each quantile, the ratios of the new to the old quantile
+// of the resamples of the sorted old and new values.
+func (q quantileComparer) bootstrap(rng *rand.Rand, old, new []float64) [][]float64 {
+	boot := make([][]float64, len(q.ps))
+	ob, nb := make([]float64, len(old)), make([]float64, len(new))
+	for k := 0; k < q.resamples; k++ {
+		resample(rng, old, ob)
+		resample(rng, new, nb)
+		for i, p := range q.ps {
+			boot[i] = append(boot[i], boxplot.Quantile(nb, p, q.t)/boxplot.Quantile(ob, p, q.t))
+		}
+	}
+	return boot
+}
+
+// Resample fills dst with values drawn from vs with replacement, and sorts it.
+func resample(rng *rand.Rand, vs, dst []float64) {
+	for i := range dst {
+		dst[i] = vs[rng.Intn(len(vs))]
+	}
+	sort.Float64s(dst)
+}
+
+// SortedCopy returns a sorted copy of the values.
+func sortedCopy(vs []float64) []float64 {
+	s := append([]float64(nil), vs...)
+	sort.Float64s(s)
+	return s
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestCompareExitStatus tests that box compare exits with exitRegression
+// if a quantile is shown to be worse than the -threshold, and only then.
+func TestCompareExitStatus(t *testing.T) {
+	defer resetFlags()
+	dir := t.TempDir()
+	old, new := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.txt")
+	ov, nv := []byte("a"), []byte("a")
+	for i := 1; i <= 100; i++ {
+		ov = append(ov, " "+formatValue(float64(i))...)
+		nv = append(nv, " "+formatValue(float64(2*i))...)
+	}
+	if err := ioutil.WriteFile(old, ov, 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(new, nv, 0666); err != nil {
+		t.Fatal(err)
+	}
+	null, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer null.Close()
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	os.Stdout = null
+	for _, test := range []struct {
+		args []string
+		want int
+	}{
+		{[]string{old, new}, exitRegression},
+		{[]string{old, old}, exitOK},
+		{[]string{new, old}, exitOK},
+		{[]string{new, old, "-higher-better"}, exitRegression},
+		{[]string{old, new, "-threshold", "150%"}, exitOK},
+		{[]string{old, new, "-threshold", "5"}, exitUsage},
+	} {
+		resetFlags()
+		if got := compare(test.args); got != test.want {
+			t.Errorf("box compare %q: exit status %d, want %d", test.args, got, test.want)
+		}
+	}
+}
+
+// TestReadResultsMixedFormats tests that the new results
+// are not read in the format detected for the old results.
+func TestReadResultsMixedFormats(t *testing.T) {
+	defer resetFlags()
+	dir := t.TempDir()
+	old, new := filepath.Join(dir, "old.txt"), filepath.Join(dir, "new.csv")
+	if err := ioutil.WriteFile(old, []byte("a 1 2 3 4 5\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(new, []byte("a,b\n1,2\n2,3\n3,4\n4,5\n5,6\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{old, new} {
+		boxes, err := readResults(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(boxes) == 0 || boxes[0].name != "a" || boxes[0].n != 5 {
+			t.Errorf("%s: got %d data sets, want a first with n=5", path, len(boxes))
+		}
+	}
+}
@@ -0,0 +1,260 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Consume plots the measurements of a message stream,
+// named by a URL of the form nats://host[:port]/subject,
+// kafka://broker[:port]/topic[?offset=earliest], or stdin:,
+// rewriting the plot to out every -consume-every,
+// or, with -o term on a terminal, redrawing it in place
+// every termRefreshEvery unless -consume-every is set,
+// and writing it to a new snapshot file on SIGUSR1
+// and every -snapshot-every, if set.
+// The measurements are merged into a data set for each
+// value of the -consume-key of the messages; see parseMeasurement.
+// A message beyond the -consume-max limits is an error.
+func consume(rawURL string, stdin io.Reader, out io.Writer) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return err
+	}
+	limits = serveLimits{prefix: "consume-", body: *consumeMaxMsg, sets: *consumeMaxSets, values: *consumeMaxVals}
+	msgs := make(chan []byte)
+	errs := make(chan error, 1)
+	switch u.Scheme {
+	case "nats":
+		host := u.Host
+		if u.Port() == "" {
+			host = net.JoinHostPort(u.Hostname(), "4222")
+		}
+		go func() { errs <- subscribeNATS(host, strings.TrimPrefix(u.Path, "/"), msgs) }()
+	case "stdin":
+		go func() { errs <- readMessages(stdin, msgs) }()
+	case "kafka":
+		broker := u.Host
+		if u.Port() == "" {
+			broker = net.JoinHostPort(u.Hostname(), "9092")
+		}
+		var earliest bool
+		switch offset := u.Query().Get("offset"); offset {
+		case "", "latest":
+		case "earliest":
+			earliest = true
+		default:
+			return fmt.Errorf("Unknown Kafka offset: %s", offset)
+		}
+		topic := strings.TrimPrefix(u.Path, "/")
+		go func() { errs <- subscribeKafka(broker, topic, earliest, msgs) }()
+	default:
+		return fmt.Errorf("unsupported -consume scheme %q", u.Scheme)
+	}
+
+	c := &collection{values: make(map[string][]float64)}
+	every := *consumeEvery
+	refresh := newTermRefresher(out)
+	if refresh != nil && !flagGiven("consume-every") {
+		every = termRefreshEvery
+	}
+	// Changed is whether there are measurements not yet plotted.
+	changed := false
+	plot := func() error {
+		changed = false
+		if refresh == nil {
+			return output(c.boxes(), out, time.Now())
+		}
+		if err := output(c.boxes(), refresh, time.Now()); err != nil {
+			return err
+		}
+		return refresh.flush()
+	}
+	tick := time.NewTicker(every)
+	defer tick.Stop()
+	snap := notifySnapshot()
+	var snapTick <-chan time.Time
+	if *snapshotEvery > 0 {
+		t := time.NewTicker(*snapshotEvery)
+		defer t.Stop()
+		snapTick = t.C
+	}
+	for {
+		select {
+		case msg := <-msgs:
+			if err := checkMessageSize(int64(len(msg))); err != nil {
+				return err
+			}
+			name, v, err := parseMeasurement(msg)
+			if err != nil {
+				return fmt.Errorf("bad message %q: %v", msg, err)
+			}
+			if err := c.checkAppend([]box{{name: name, values: []float64{v}}}); err != nil {
+				return err
+			}
+			if _, ok := c.values[name]; !ok {
+				c.names = append(c.names, name)
+			}
+			c.values[name] = append(c.values[name], v)
+			changed = true
+		case <-tick.C:
+			if len(c.names) > 0 && (changed || refresh == nil) {
+				if err := plot(); err != nil {
+					return err
+				}
+			}
+		case <-snap:
+			if err := takeSnapshot(c.boxes(), time.Now()); err != nil {
+				return err
+			}
+		case <-snapTick:
+			if len(c.names) > 0 {
+				if err := takeSnapshot(c.boxes(), time.Now()); err != nil {
+					return err
+				}
+			}
+		case err := <-errs:
+			if err == nil && len(c.names) > 0 {
+				err = plot()
+			}
+			return err
+		}
+	}
+}
+
+// CheckMessageSize returns a limitError if a message of n bytes
+// is larger than the -consume-max-message limit.
+func checkMessageSize(n int64) error {
+	if limits.body > 0 && n > limits.body {
+		return &limitError{limit: limits.prefix + "max-message", max: limits.body}
+	}
+	return nil
+}
+
+// ReadMessages sends each line of r as a message.
+// A line longer than the message limit is an error.
+func readMessages(r io.Reader, msgs chan<- []byte) error {
+	scanner := bufio.NewScanner(r)
+	if limits.body > 0 && limits.body < math.MaxInt32 {
+		scanner.Buffer(nil, int(limits.body)+1)
+	} else {
+		scanner.Buffer(nil, 64*1024*1024)
+	}
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			msgs <- []byte(line)
+		}
+	}
+	if scanner.Err() == bufio.ErrTooLong && limits.body > 0 {
+		return checkMessageSize(limits.body + 1)
+	}
+	return scanner.Err()
+}
+
+// SubscribeNATS subscribes to a NATS subject
+// and sends the payload of each message received.
+// It speaks the NATS client protocol directly.
+func subscribeNATS(host, subject string, msgs chan<- []byte) error {
+	conn, err := net.Dial("tcp", host)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	r := bufio.NewReader(conn)
+	if _, err := fmt.Fprintf(conn, "CONNECT {\"verbose\":false,\"pedantic\":false}\r\nSUB %s 1\r\n", subject); err != nil {
+		return err
+	}
+	for {
+		line, err := r.ReadString('\n')
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		fs := strings.Fields(line)
+		if len(fs) == 0 {
+			continue
+		}
+		switch fs[0] {
+		case "PING":
+			if _, err := io.WriteString(conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case "-ERR":
+			return errors.New(strings.TrimSpace(line))
+		case "MSG":
+			// MSG <subject> <sid> [reply-to] <size>
+			n, err := strconv.Atoi(fs[len(fs)-1])
+			if err != nil || len(fs) < 4 {
+				return fmt.Errorf("bad NATS message header %q", line)
+			}
+			if err := checkMessageSize(int64(n)); err != nil {
+				return err
+			}
+			payload := make([]byte, n+2)
+			if _, err := io.ReadFull(r, payload); err != nil {
+				return err
+			}
+			msgs <- payload[:n]
+		}
+	}
+}
+
+// ParseMeasurement returns the data set name and value of a message.
+// A message is either a JSON object or a line of InfluxDB line protocol:
+// <measurement>[,<tag>=<value>]* <field>=<value>[,<field>=<value>]* [<timestamp>].
+// The name is the value of the -consume-key member of a JSON object,
+// or tag of a line, defaulting to the measurement name of a line.
+// The value is the -consume-field member or field.
+func parseMeasurement(msg []byte) (name string, v float64, err error) {
+	if len(msg) > 0 && msg[0] == '{' {
+		var m map[string]interface{}
+		if err := json.Unmarshal(msg, &m); err != nil {
+			return "", 0, err
+		}
+		f, ok := m[*consumeField].(float64)
+		if !ok {
+			return "", 0, fmt.Errorf("no number %s", *consumeField)
+		}
+		key, ok := m[*consumeKey]
+		if !ok {
+			return "", 0, fmt.Errorf("no key %s", *consumeKey)
+		}
+		return fmt.Sprint(key), f, nil
+	}
+	fs := strings.Fields(string(msg))
+	if len(fs) < 2 {
+		return "", 0, errors.New("expected line protocol")
+	}
+	tags := strings.Split(fs[0], ",")
+	name = tags[0]
+	for _, t := range tags[1:] {
+		if kv := strings.SplitN(t, "=", 2); len(kv) == 2 && kv[0] == *consumeKey {
+			name = kv[1]
+		}
+	}
+	for _, f := range strings.Split(fs[1], ",") {
+		if kv := strings.SplitN(f, "=", 2); len(kv) == 2 && kv[0] == *consumeField {
+			v, err := strconv.ParseFloat(strings.TrimSuffix(kv[1], "i"), 64)
+			return name, v, err
+		}
+	}
+	return "", 0, fmt.Errorf("no field %s", *consumeField)
+}
+
+// FlagGiven returns whether the command-line flag of a name was given.
+func flagGiven(name string) bool {
+	given := false
+	flag.Visit(func(f *flag.Flag) { given = given || f.Name == name })
+	return given
+}
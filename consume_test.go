@@ -0,0 +1,41 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestConsumeLimits tests that -consume ends with a limitError
+// naming the -consume-max flag of a limit that its messages exceed.
+func TestConsumeLimits(t *testing.T) {
+	defer resetFlags()
+	defer func(l serveLimits) { limits = l }(limits)
+	tests := []struct {
+		flag, value string
+		input       string
+		want        string
+	}{
+		{"consume-max-message", "16", `{"name": "a", "value": 1, "padding": "xxxxxxxx"}`, "consume-max-message"},
+		{"consume-max-message", "16", strings.Repeat("x", 100), "consume-max-message"},
+		{"consume-max-datasets", "2", "a value=1\nb value=2\nc value=3\n", "consume-max-datasets"},
+		{"consume-max-values", "2", "a value=1\na value=2\na value=3\n", "consume-max-values"},
+		{"consume-max-values", "3", "a value=1\na value=2\na value=3\n", ""},
+	}
+	for _, test := range tests {
+		resetFlags()
+		if err := flag.Set(test.flag, test.value); err != nil {
+			t.Fatal(err)
+		}
+		err := consume("stdin:", strings.NewReader(test.input), ioutil.Discard)
+		var le *limitError
+		switch {
+		case test.want == "" && err != nil:
+			t.Errorf("-%s %s: %v, want no error", test.flag, test.value, err)
+		case test.want != "" && (!errors.As(err, &le) || le.limit != test.want):
+			t.Errorf("-%s %s: %v, want input exceeds -%s", test.flag, test.value, err, test.want)
+		}
+	}
+}
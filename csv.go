@@ -0,0 +1,227 @@
+package main
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func readCSV(r io.Reader) ([]box, error) { return readDelimited(r, ',') }
+
+func readTSV(r io.Reader) ([]box, error) { return readDelimited(r, '\t') }
+
+// ReadDelimited reads delimiter-separated values,
+// with the data sets laid out according to the -pivot flag.
+// Empty cells are ignored, so data sets may have different numbers of values.
+func readDelimited(r io.Reader, comma rune) ([]box, error) {
+	cr := csv.NewReader(r)
+	cr.Comma = comma
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	switch *pivot {
+	case "columns":
+		return csvColumns(rows)
+	case "rows":
+		if *idColumn != "" {
+			return nil, fmt.Errorf("-id-column needs -pivot columns")
+		}
+		return csvRows(rows)
+	}
+	return nil, fmt.Errorf("unknown pivot %q", *pivot)
+}
+
+// CsvColumns returns a box for each column,
+// named by the header row,
+// with the remaining rows giving the values of each trial.
+// With -id-column, the named column is not a box,
+// but holds the identifier of each row, such as a request ID,
+// which becomes the ID of each of the values in the row.
+// With -meta, the named columns are not boxes either,
+// but split each column into a box for each combination of their values,
+// named <values>/<column>, with the values joined by slashes,
+// in order of their first appearance,
+// and grouped by their values.
+// With -group-by, the columns of the expression split the columns too,
+// and the <values> are those of the expression, or, if there is only one column of values,
+// the whole name, in which case the boxes are not grouped.
+func csvColumns(rows [][]string) ([]box, error) {
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	idCol := -1
+	if *idColumn != "" {
+		for j, name := range rows[0] {
+			if strings.TrimSpace(name) == *idColumn {
+				idCol = j
+			}
+		}
+		if idCol < 0 {
+			return nil, fmt.Errorf("no -id-column %s", *idColumn)
+		}
+	}
+	metaIdx, err := metaColumns(rows[0])
+	if err != nil {
+		return nil, err
+	}
+	expr, err := groupByExpr()
+	if err != nil {
+		return nil, err
+	}
+	exprIdx, err := expr.bind(rows[0])
+	if err != nil {
+		return nil, err
+	}
+	valueCol := func(j int) bool {
+		return j != idCol && !isMeta(j, metaIdx) && !isMeta(j, exprIdx)
+	}
+	nValues := 0
+	for j := range rows[0] {
+		if valueCol(j) {
+			nValues++
+		}
+	}
+	split := metaIdx != nil || expr != nil
+	type part struct {
+		meta  []string
+		label string
+		cols  []valueList
+		ids   [][]string
+	}
+	var parts []*part
+	byKey := make(map[string]*part)
+	for i, row := range rows[1:] {
+		var id string
+		if idCol >= 0 && idCol < len(row) {
+			id = strings.TrimSpace(row[idCol])
+		}
+		meta := metaValues(row, metaIdx)
+		label := metaKey(meta)
+		if expr != nil {
+			label = expr.eval(row)
+		}
+		key := label + "\x00" + metaKey(meta)
+		p := byKey[key]
+		if p == nil {
+			p = &part{label: label, cols: make([]valueList, len(rows[0])), ids: make([][]string, len(rows[0]))}
+			if metaIdx != nil {
+				p.meta = meta
+			}
+			byKey[key] = p
+			parts = append(parts, p)
+		}
+		for j, cell := range row {
+			if !valueCol(j) || strings.TrimSpace(cell) == "" {
+				continue
+			}
+			if j >= len(p.cols) {
+				return nil, fmt.Errorf("row %d: column %d has no header", i+2, j+1)
+			}
+			if err := parseCell(&p.cols[j], cell); err != nil {
+				return nil, fmt.Errorf("row %d: %v", i+2, err)
+			}
+			if idCol >= 0 {
+				p.ids[j] = append(p.ids[j], id)
+			}
+		}
+	}
+	if len(parts) == 0 {
+		parts = []*part{{cols: make([]valueList, len(rows[0])), ids: make([][]string, len(rows[0]))}}
+	}
+	var boxes []box
+	for _, p := range parts {
+		for i, name := range rows[0] {
+			if !valueCol(i) || split && p.cols[i].len() == 0 {
+				continue
+			}
+			name = strings.TrimSpace(name)
+			var group, metric string
+			switch {
+			case expr != nil && nValues == 1:
+				name = p.label
+			case split:
+				name, metric = p.label+"/"+name, name
+				group = p.label
+			}
+			b := p.cols[i].box(name)
+			if nValues > 1 {
+				b.metric = metric
+			}
+			b.ids = p.ids[i]
+			b.meta = p.meta
+			b.group = group
+			boxes = append(boxes, b)
+		}
+	}
+	return boxes, nil
+}
+
+// CsvRows returns a box for each row,
+// named by its first cell,
+// with the remaining cells giving the values of each trial.
+// If the values of the first row do not parse as numbers,
+// it is taken to be a header and skipped.
+// With -meta or -group-by, the first row must be a header,
+// and the cells of the named columns of each row are not values,
+// but the metadata of its box, by which it is grouped,
+// or the columns of the expression that names it.
+func csvRows(rows [][]string) ([]box, error) {
+	var metaIdx, exprIdx []int
+	expr, err := groupByExpr()
+	if err != nil {
+		return nil, err
+	}
+	first := 0
+	if (*metaCols != "" || expr != nil) && len(rows) > 0 {
+		if metaIdx, err = metaColumns(rows[0]); err != nil {
+			return nil, err
+		}
+		if exprIdx, err = expr.bind(rows[0]); err != nil {
+			return nil, err
+		}
+		first = 1
+	}
+	var boxes []box
+	var arena floatArena
+	for i := first; i < len(rows); i++ {
+		row := rows[i]
+		vs := valueList{arena: &arena}
+		header := false
+		for j, cell := range row[1:] {
+			if strings.TrimSpace(cell) == "" || isMeta(j+1, metaIdx) || isMeta(j+1, exprIdx) {
+				continue
+			}
+			err := parseCell(&vs, cell)
+			if err != nil && i == 0 {
+				header = true
+				break
+			}
+			if err != nil {
+				return nil, fmt.Errorf("row %d: %v", i+1, err)
+			}
+		}
+		if header {
+			continue
+		}
+		name := strings.TrimSpace(row[0])
+		if expr != nil {
+			name = expr.eval(row)
+		}
+		b := vs.box(name)
+		if metaIdx != nil {
+			b.meta = metaValues(row, metaIdx)
+			b.group = metaKey(b.meta)
+		}
+		boxes = append(boxes, b)
+	}
+	return boxes, nil
+}
+
+// ParseCell parses the value of a cell and appends it to a list.
+func parseCell(vs *valueList, cell string) error {
+	return vs.parse(strings.TrimSpace(cell))
+}
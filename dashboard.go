@@ -0,0 +1,277 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html/template"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Dashboard runs the dashboard command with the given arguments
+// and returns the exit status.
+//
+// Dashboard reads a directory of dated result files,
+// each named with the date of its results, as in results/2024-01-02.txt
+// or results/bench-20240102T150405Z.txt, and each read as box reads its input.
+// The data sets of the files of the same date are merged by name, as by report,
+// and each name is a metric tracked over time.
+// Files without a date in their name are skipped.
+//
+// The dashboard is a static site written to the -o directory:
+// index.html has a row for each metric with its latest median,
+// its change from the date before, and a sparkline of its medians over time,
+// and links to the page of the metric, which is that of -html
+// with a box for each date, oldest first.
+// A page is only written if it has changed,
+// so rerunning dashboard after adding a file leaves the pages
+// of the metrics it does not touch, and their modification times, as they were.
+// The flags of box itself, such as -precision and -log, apply to every page.
+func dashboard(args []string) int {
+	fs := flag.NewFlagSet("dashboard", flag.ContinueOnError)
+	dir := fs.String("dir", "", "`directory` of dated result files")
+	out := fs.String("o", "dashboard", "`directory` to write the pages to")
+	parseFlags(fs, args)
+	if *dir == "" || fs.NArg() > 0 {
+		fmt.Fprintln(os.Stderr, "usage: box dashboard -dir results/ [-o dashboard]")
+		return exitUsage
+	}
+	d, err := readDashboard(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box dashboard: %v\n", err)
+		return exitParse
+	}
+	err = os.MkdirAll(*out, 0777)
+	if err == nil {
+		err = d.write(*out)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box dashboard: %v\n", err)
+		return exitOutput
+	}
+	return exitOK
+}
+
+// DashboardDate matches the date, and optional time, in the name of a result file:
+// 2024-01-02, 20240102, 2024-01-02T15:04:05Z, or 20240102T150405.000Z.
+var dashboardDate = regexp.MustCompile(`\d{4}-?\d{2}-?\d{2}(T\d{2}:?\d{2}(:?\d{2}(\.\d+)?)?Z?)?`)
+
+// A dashboardData is the results of a directory, by date and metric.
+type dashboardData struct {
+	// Dates are the dates of the results, oldest first, as named by the first of their files.
+	dates []string
+	// Metrics are the names of the metrics, in order of first appearance.
+	metrics []string
+	// Boxes are the summarized boxes of each metric by date.
+	boxes map[string]map[string]box
+}
+
+// ReadDashboard reads the dated result files of a directory,
+// except hidden files, in the order of their dates.
+func readDashboard(dir string) (*dashboardData, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	// Keys normalize the dates, so 2024-01-02 and 20240102 are the same date,
+	// named as by the first of its files.
+	key := func(date string) string { return strings.NewReplacer("-", "", ":", "").Replace(date) }
+	cs := &collections{sets: make(map[string]*collection)}
+	var dates []string
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		date := dashboardDate.FindString(info.Name())
+		if date == "" {
+			warnf("%s: no date in the file name; skipping it", filepath.Join(dir, info.Name()))
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := cs.sets[key(date)]; !ok {
+			dates = append(dates, date)
+		}
+		if err := cs.append(key(date), data); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	if len(dates) == 0 {
+		return nil, fmt.Errorf("%s: no dated result files", dir)
+	}
+	sort.SliceStable(dates, func(i, j int) bool { return key(dates[i]) < key(dates[j]) })
+	d := &dashboardData{dates: dates, boxes: make(map[string]map[string]box)}
+	for _, date := range dates {
+		boxes := cs.sets[key(date)].boxes()
+		summarize(boxes)
+		for _, b := range boxes {
+			if b.n == 0 {
+				continue
+			}
+			if d.boxes[b.name] == nil {
+				d.metrics = append(d.metrics, b.name)
+				d.boxes[b.name] = make(map[string]box)
+			}
+			d.boxes[b.name][date] = b
+		}
+	}
+	return d, nil
+}
+
+// PageFiles returns the name of the file of the page of each metric:
+// metric- and its name, with any character other than a letter, digit, - or _ replaced by _.
+// Distinct metrics whose names are replaced alike are numbered in order.
+func (d *dashboardData) pageFiles() map[string]string {
+	files := make(map[string]string)
+	used := make(map[string]bool)
+	for _, m := range d.metrics {
+		slug := strings.Map(func(r rune) rune {
+			if r == '-' || r == '_' || r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' {
+				return r
+			}
+			return '_'
+		}, m)
+		file := "metric-" + slug + ".html"
+		for i := 2; used[file]; i++ {
+			file = fmt.Sprintf("metric-%s-%d.html", slug, i)
+		}
+		used[file] = true
+		files[m] = file
+	}
+	return files
+}
+
+// A dashboardRow is a metric formatted for the index of the dashboard.
+type dashboardRow struct {
+	Name, Href, Latest, Change string
+	// Spark is the points of the sparkline of the medians,
+	// and Last is the point of the latest.
+	Spark        string
+	LastX, LastY float64
+}
+
+// Dimensions of the sparklines.
+const sparkW, sparkH, sparkPad = 120.0, 24.0, 3.0
+
+// Write writes the pages of the dashboard to a directory,
+// except those whose files are already up to date.
+func (d *dashboardData) write(dir string) error {
+	files := d.pageFiles()
+	var rows []dashboardRow
+	for _, m := range d.metrics {
+		var boxes []box
+		var xs, medians []float64
+		for i, date := range d.dates {
+			b, ok := d.boxes[m][date]
+			if !ok {
+				continue
+			}
+			b.name = date
+			boxes = append(boxes, b)
+			xs = append(xs, float64(i))
+			medians = append(medians, b.q2)
+		}
+		heading := m
+		if *title != "" {
+			heading = *title + ": " + m
+		}
+		nav := []htmlLink{{Text: "dashboard", Href: "index.html"}, {Text: m}}
+		var buf bytes.Buffer
+		if err := writeLinkedHTML(boxes, heading, nav, nil, &buf); err != nil {
+			return fmt.Errorf("Write failed: %v", err)
+		}
+		if err := writeIfChanged(filepath.Join(dir, files[m]), buf.Bytes()); err != nil {
+			return err
+		}
+		rows = append(rows, newDashboardRow(m, files[m], len(d.dates), xs, medians))
+	}
+	var buf bytes.Buffer
+	err := dashboardPage.Execute(&buf, struct {
+		Title          string
+		First, Last    string
+		Rows           []dashboardRow
+		SparkW, SparkH float64
+	}{*title, d.dates[0], d.dates[len(d.dates)-1], rows, sparkW, sparkH})
+	if err != nil {
+		return fmt.Errorf("Write failed: %v", err)
+	}
+	return writeIfChanged(filepath.Join(dir, "index.html"), buf.Bytes())
+}
+
+// NewDashboardRow returns the index row of a metric
+// with the given medians at the given indices of the dates,
+// of which there are n.
+func newDashboardRow(name, href string, n int, xs, medians []float64) dashboardRow {
+	r := dashboardRow{Name: name, Href: href, Change: "-"}
+	last := len(medians) - 1
+	r.Latest = formatValue(medians[last])
+	if last > 0 {
+		r.Change = fmt.Sprintf("%+.1f%%", 100*(medians[last]-medians[last-1])/math.Abs(medians[last-1]))
+	}
+	lo, hi := medians[0], medians[0]
+	for _, m := range medians {
+		lo, hi = math.Min(lo, m), math.Max(hi, m)
+	}
+	x := func(i float64) float64 {
+		if n == 1 {
+			return sparkW / 2
+		}
+		return sparkPad + (sparkW-2*sparkPad)*i/float64(n-1)
+	}
+	y := func(v float64) float64 {
+		if lo == hi {
+			return sparkH / 2
+		}
+		return sparkPad + (sparkH-2*sparkPad)*(hi-v)/(hi-lo)
+	}
+	var pts []string
+	for i, m := range medians {
+		pts = append(pts, fmt.Sprintf("%.1f,%.1f", x(xs[i]), y(m)))
+	}
+	r.Spark = strings.Join(pts, " ")
+	r.LastX, r.LastY = x(xs[last]), y(medians[last])
+	return r
+}
+
+// WriteIfChanged writes data to a file,
+// unless the file already holds exactly that data.
+func writeIfChanged(path string, data []byte) error {
+	if old, err := ioutil.ReadFile(path); err == nil && bytes.Equal(old, data) {
+		return nil
+	}
+	return ioutil.WriteFile(path, data, 0666)
+}
+
+var dashboardPage = template.Must(template.New("dashboard").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with .Title}}{{.}}{{else}}box dashboard{{end}}</title>
+<style>
+body { font: 13px sans-serif; margin: 1em; }
+table { border-collapse: collapse; }
+th, td { padding: 0.2em 0.6em; text-align: right; border-bottom: 1px solid #ddd; }
+th:first-child, td:first-child { text-align: left; }
+svg polyline { stroke: black; fill: none; }
+svg circle { fill: black; }
+</style>
+</head>
+<body>
+<h2>{{with .Title}}{{.}}{{else}}box dashboard{{end}}</h2>
+<p>Results from {{.First}} to {{.Last}}.</p>
+<table>
+<tr><th>metric</th><th>latest median</th><th>change</th><th>medians</th></tr>
+{{range .Rows}}<tr><td><a href="{{.Href}}">{{.Name}}</a></td><td>{{.Latest}}</td><td>{{.Change}}</td><td><svg width="{{$.SparkW}}" height="{{$.SparkH}}"><polyline points="{{.Spark}}"/><circle cx="{{.LastX}}" cy="{{.LastY}}" r="2"/></svg></td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestReadDashboardMergesDates tests that the files of a date
+// are merged however the date is written, whatever their formats.
+func TestReadDashboardMergesDates(t *testing.T) {
+	defer resetFlags()
+	dir := t.TempDir()
+	files := map[string]string{
+		"2024-01-02.txt":     "a 1 2 3\n",
+		"20240102.csv":       "b,c\n1,2\n3,4\n",
+		"2024-01-03.txt":     "a 4 5 6\n",
+		"bench-20240103.txt": "a 7\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	d, err := readDashboard(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(d.dates) != 2 {
+		t.Fatalf("dates %q, want 2 dates", d.dates)
+	}
+	for _, m := range []string{"a", "b", "c"} {
+		if _, ok := d.boxes[m][d.dates[0]]; !ok {
+			t.Errorf("no %s on %s", m, d.dates[0])
+		}
+	}
+	if b := d.boxes["a"][d.dates[1]]; b.n != 4 {
+		t.Errorf("a on %s: n=%d, want 4", d.dates[1], b.n)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+)
+
+// LabelGap is the vertical space between a mark and a label above it.
+const labelGap = 0.02
+
+// A column is the region of the plot in which a single box is drawn.
+type column struct {
+	// X and width give the horizontal extent of the column.
+	x, width float64
+	// Bottom and top give the vertical extent of the plot area.
+	bottom, top float64
+	// Tr transforms values to vertical coordinates.
+	tr func(float64) float64
+}
+
+// A rect is a rectangular region of the output.
+type rect struct {
+	x0, y0, x1, y1 float64
+}
+
+// Page is the region of the entire output.
+var page = rect{0, 0, 1, 1}
+
+// Draw draws the boxes as plot(1) commands.
+func draw(boxes []box, title string, w io.Writer) error {
+	return drawCanvas(boxes, title, plotCanvas{w: w})
+}
+
+// DrawCanvas draws the boxes to a canvas and closes it.
+// The boxes are drawn either as box plots or, with -runorder, as run lines,
+// and either side by side or, with -matrix, as a grid of panels.
+func drawCanvas(boxes []box, title string, cv canvas) error {
+	drawCol := drawBox
+	if *runOrder {
+		drawCol = drawRun
+	}
+	layoutFigure(boxes, title).draw(cv, drawCol)
+	return cv.close()
+}
+
+// DrawPanel lays out a labeled column for each box of a panel,
+// calling drawCol to draw the box within its column.
+// The panel is divided into strips for the name labels at the bottom,
+// captions and notes above the boxes at the top,
+// and the plot area between them,
+// with a strip for the value axis at the left with -axis.
+// With -horizontal, the panel is laid out and drawn transposed,
+// with strips wide enough for the names and notes beside the boxes.
+// Each strip is at least a twentieth of the panel high, for padding.
+// DrawPanel returns the column of each box.
+func drawPanel(cv canvas, p panel, drawCol func(canvas, box, column)) []column {
+	r := p.r
+	yPad := 0.05 * (r.y1 - r.y0)
+	runs := groupRuns(p.boxes)
+	top := 0.0
+	var nameSpace float64
+	for _, b := range p.boxes {
+		ns := notes(b)
+		switch {
+		case len(ns) == 0:
+		case *horizontal:
+			top = math.Max(top, labelGap+textWidth(ns...))
+		default:
+			top = math.Max(top, float64(len(ns))*labelGap+textHeight/2)
+		}
+		nameSpace = math.Max(nameSpace, textWidth(b.name)+charWidth)
+	}
+	if !*horizontal {
+		nameSpace = textHeight
+	}
+	captioned := p.name != "" && caption(p.name, p.boxes) != ""
+	for _, run := range runs {
+		group := p.boxes[run[0]:run[1]]
+		captioned = captioned || caption(groupOf(group[0]), group) != ""
+	}
+	if captioned {
+		top += captionHeight
+	}
+	l := layout{free: r}
+	captions := l.top(math.Max(yPad, top))
+	names := l.bottom(yPad + nameSpace)
+	yBottom, yTop := l.free.y0, l.free.y1
+	tr := valueScale(p.min, p.max, yBottom, yTop)
+	if !math.IsNaN(rangeMin) || !math.IsNaN(rangeMax) {
+		tr = clampTr(tr, yBottom, yTop)
+	}
+	if *axis || tickValues != nil {
+		drawAxis(cv, l.left(axisWidth), yBottom, yTop, p.min, p.max, tr)
+	}
+	left, right := l.free.x0, l.free.x1
+
+	n := float64(len(p.boxes))
+	pad := ((right - left) / n) / 3.0
+	width := ((right - left) - (n+1)*pad) / n
+
+	for i, run := range runs {
+		x0 := left + pad/2 + float64(run[0])*(width+pad)
+		x1 := left + pad/2 + float64(run[1])*(width+pad)
+		if i%2 == 1 {
+			drawShade(cv, x0, yBottom, x1, yTop)
+		}
+		group := p.boxes[run[0]:run[1]]
+		drawCaption(cv, x1, captions.y1, caption(groupOf(group[0]), group))
+	}
+
+	x := left + pad
+	cols := make([]column, len(p.boxes))
+	for i, b := range p.boxes {
+		cv.group(b.name)
+		if !p.noLabels {
+			cv.text("name", x+width/2.0, names.y0+nameSpace, 'C', b.name)
+		}
+		cols[i] = column{x: x, width: width, bottom: yBottom, top: yTop, tr: tr}
+		drawCol(cv, b, cols[i])
+		cv.group("")
+		x += width + pad
+	}
+	return cols
+}
+
+// DrawShade shades a rectangle by hatching it with horizontal lines,
+// which remain visible without color.
+// It must be drawn before anything that it lies behind.
+func drawShade(cv canvas, x0, y0, x1, y1 float64) {
+	const spacing = 0.01
+	for y := y0; y <= y1; y += spacing {
+		cv.line("shade", x0, y, x1, y)
+	}
+}
+
+// DrawBox draws a box plot of a box.
+func drawBox(cv canvas, b box, col column) {
+	x, width, tr := col.x, col.width, col.tr
+	c := x + width/2.0
+	capWidth := width / 4.0
+	minLabel, q1Label, q2Label, q3Label, maxLabel := b.statLabels()
+	bottom, top := tr(b.q1), tr(b.q3)
+	drawHeat(cv, b.heat, x, bottom, x+width, top)
+	med := tr(b.q2)
+	notchLo, notchHi, notch := b.notch()
+	notch = notch && *notched
+	if notch {
+		drawNotchedBox(cv, x, width, bottom, top, tr(notchLo), med, tr(notchHi))
+	} else {
+		cv.box("box", x, bottom, x+width, top)
+	}
+	if m := metaString(b); m != "" {
+		cv.tooltip(m)
+	}
+	cv.text("value", x, bottom, 'R', q1Label)
+	cv.text("value", x, top, 'R', q3Label)
+	if notch {
+		cv.line("median", x+width*notchDepth, med, x+width*(1-notchDepth), med)
+	} else {
+		cv.line("median", x, med, x+width, med)
+	}
+	cv.text("value", x, med, 'R', q2Label)
+	lo, hi, outliers := b.whiskers()
+	if *whiskerRule != "minmax" {
+		minLabel, maxLabel = formatValue(lo), formatValue(hi)
+	}
+	min := tr(lo)
+	cv.line("cap", c-capWidth, min, c+capWidth, min)
+	cv.line("whisker", c, bottom, c, min)
+	cv.text("value", c-capWidth, min, 'R', minLabel)
+	max := tr(hi)
+	cv.line("cap", c-capWidth, max, c+capWidth, max)
+	cv.line("whisker", c, top, c, max)
+	cv.text("value", c-capWidth, max, 'R', maxLabel)
+	ids := b.outlierIDs(lo, hi)
+	for i, v := range outliers {
+		cv.circle("outlier", c, tr(v), width/32)
+		if ids != nil && ids[i] != "" {
+			cv.tooltip(ids[i])
+		}
+	}
+	if *meanCI {
+		drawMeanCI(cv, b, x+width*0.75, capWidth/2, tr)
+	}
+	drawCensored(cv, b, c, width/16, tr)
+	for i, note := range notes(b) {
+		cv.text("note", c, tr(b.max)+labelGap*float64(i+1), 'C', note)
+	}
+}
+
+// Notes returns short annotations to draw above a box
+// for each of the warnings that apply to it,
+// for its censored values, and for its values beyond the -robust-range,
+// followed by those of the -script annotate hook.
+func notes(b box) []string {
+	var ns []string
+	if b.correlated() {
+		ns = append(ns, "r1="+formatValue(b.autocorr()))
+	}
+	if b.multimodal() {
+		ns = append(ns, fmt.Sprintf("modes=%d", b.modes()))
+	}
+	ns = append(ns, censorNotes(b)...)
+	ns = append(ns, robustNotes(b)...)
+	return append(ns, b.scriptNotes...)
+}
+
+// DrawRun draws the values of a box in input order
+// as a line across a framed panel filling the column,
+// labeled with the minimum and maximum values.
+func drawRun(cv canvas, b box, col column) {
+	x, width, tr := col.x, col.width, col.tr
+	cv.box("frame", x, col.bottom, x+width, col.top)
+	if len(b.values) == 0 {
+		return
+	}
+	minLabel, _, _, _, maxLabel := b.statLabels()
+	cv.text("value", x, tr(b.min), 'R', minLabel)
+	cv.text("value", x, tr(b.max), 'R', maxLabel)
+	if len(b.values) == 1 {
+		cv.circle("run", x+width/2, tr(b.values[0]), width/32)
+		return
+	}
+	dx := width / float64(len(b.values)-1)
+	xs := make([]float64, len(b.values))
+	ys := make([]float64, len(b.values))
+	for i, v := range b.values {
+		xs[i], ys[i] = x+dx*float64(i), tr(v)
+	}
+	cv.polyline("run", xs, ys)
+}
+
+// FormatValue returns a value formatted for output
+// with -precision significant digits.
+// Every output uses formatValue, so they agree to the last digit.
+// Values are rounded to nearest, with ties rounded half to even,
+// based on the exact binary value, as by strconv.FormatFloat.
+func formatValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', *precision, 64)
+}
+
+// DrawMeanCI draws the mean of a box as a small circle
+// with an error bar showing its confidence interval,
+// centered horizontally at x.
+func drawMeanCI(cv canvas, b box, x, capWidth float64, tr func(float64) float64) {
+	lo, hi := b.meanCI()
+	if !math.IsNaN(lo) {
+		lo, hi = tr(lo), tr(hi)
+		cv.line("ci", x, lo, x, hi)
+		cv.line("ci", x-capWidth, lo, x+capWidth, lo)
+		cv.line("ci", x-capWidth, hi, x+capWidth, hi)
+	}
+	if b.n > 0 {
+		cv.circle("mean", x, tr(b.mean), capWidth/2)
+	}
+}
+
+// MinMax returns the range of values spanned by the boxes,
+// including their mean confidence intervals with -mean-ci,
+// except for interval ends at or below zero with -log,
+// unless -log-zero is symlog.
+func minMax(boxes []box) (min, max float64) {
+	min, max = math.Inf(1), math.Inf(-1)
+	for _, b := range boxes {
+		if *meanCI {
+			if lo, hi := b.meanCI(); !math.IsNaN(lo) {
+				if lo > 0 || !*logScale || *logZero == "symlog" {
+					min = math.Min(min, lo)
+				}
+				max = math.Max(max, hi)
+			}
+		}
+		if b.min < min {
+			min = b.min
+		}
+		if b.max > max {
+			max = b.max
+		}
+	}
+	return min, max
+}
+
+// ValueScale returns a function that transforms values
+// such that the range [min, max] → [lo, hi],
+// linearly, or, with -log, logarithmically,
+// in which case values at or below zero, or the logFloor,
+// are transformed to lo, unless -log-zero is symlog.
+func valueScale(min, max, lo, hi float64) func(float64) float64 {
+	if !*logScale {
+		return makeTr(min, max, lo, hi)
+	}
+	if *logZero == "symlog" {
+		tr := makeTr(symlog(min), symlog(max), lo, hi)
+		return func(v float64) float64 { return tr(symlog(v)) }
+	}
+	min = math.Max(min, logFloor)
+	tr := makeTr(math.Log10(min), math.Log10(max), lo, hi)
+	return func(v float64) float64 {
+		if v <= logFloor {
+			return lo
+		}
+		return tr(math.Log10(v))
+	}
+}
+
+// MakeTr returns a function that applies a linear transform to its value
+// such that the range [min0, max0] → [min1, max1].
+func makeTr(min0, max0, min1, max1 float64) func(float64) float64 {
+	d0 := max0 - min0
+	d1 := max1 - min1
+	return func(v float64) float64 { return ((v-min0)/d0)*d1 + min1 }
+}
@@ -0,0 +1,212 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// Drill runs the drill command with the given arguments
+// and returns the exit status.
+//
+// Drill reads a CSV file of one row per request, or other event,
+// with a header row naming the columns,
+// and writes a hierarchy of linked HTML pages of box plots
+// of the -value column, grouped by the successive -group-by columns.
+// The first page, index.html, has a box for each value of the first column;
+// the name of each box links to a page of the rows with that value,
+// with a box for each value of the second column, and so on,
+// so that -group-by endpoint,status drills down from endpoints
+// to the status codes of each endpoint.
+// Each page links back to the pages above it.
+//
+// The pages are those of -html, written to the -o directory.
+// The flags of box itself, such as -sort and -precision, apply to every page,
+// as in box -sort median drill requests.csv -group-by endpoint.
+func drill(args []string) int {
+	fs := flag.NewFlagSet("drill", flag.ContinueOnError)
+	groupBy := fs.String("group-by", "", "comma-separated `columns` to group by, outermost first")
+	value := fs.String("value", "", "`column` of the values; the last column by default")
+	dir := fs.String("o", "drill", "`directory` to write the pages to")
+	parseFlags(fs, args)
+	// The file may come before the flags, as in box drill FILE -group-by col.
+	var file string
+	if fs.NArg() > 0 {
+		file = fs.Arg(0)
+		parseFlags(fs, fs.Args()[1:])
+	}
+	if file == "" || fs.NArg() > 0 || *groupBy == "" {
+		fmt.Fprintln(os.Stderr, "usage: box drill FILE -group-by col1,col2 [-value col] [-o dir]")
+		return exitUsage
+	}
+	d, err := readDrill(file, strings.Split(*groupBy, ","), *value)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box drill: %v\n", err)
+		return exitParse
+	}
+	err = os.MkdirAll(*dir, 0777)
+	if err == nil {
+		_, err = d.writePage(*dir, nil, d.all(), nil)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box drill: %v\n", err)
+		return exitOutput
+	}
+	return exitOK
+}
+
+// A drillTable is the rows of a drill input,
+// reduced to the grouping columns and the value.
+type drillTable struct {
+	groupBy   []string
+	valueName string
+	// Keys are the cells of the grouping columns of each row.
+	keys   [][]string
+	values []float64
+	// Pages is the number of pages written so far.
+	pages int
+}
+
+// ReadDrill reads a CSV file with a header row
+// into a drillTable with the given grouping and value columns.
+func readDrill(file string, groupBy []string, value string) (*drillTable, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("Read failed: %v", err)
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no header row")
+	}
+	header := rows[0]
+	column := func(name string) (int, error) {
+		for i, h := range header {
+			if strings.TrimSpace(h) == name {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("no column %q", name)
+	}
+	cols := make([]int, len(groupBy))
+	for i, name := range groupBy {
+		if cols[i], err = column(name); err != nil {
+			return nil, err
+		}
+	}
+	vcol := len(header) - 1
+	if value != "" {
+		if vcol, err = column(value); err != nil {
+			return nil, err
+		}
+	}
+	t := &drillTable{groupBy: groupBy, valueName: strings.TrimSpace(header[vcol])}
+	for i, row := range rows[1:] {
+		if len(row) != len(header) {
+			return nil, fmt.Errorf("line %d: %d cells, expected %d", i+2, len(row), len(header))
+		}
+		v, err := strconv.ParseFloat(strings.TrimSpace(row[vcol]), 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", i+2, err)
+		}
+		key := make([]string, len(cols))
+		for j, c := range cols {
+			key[j] = strings.TrimSpace(row[c])
+		}
+		t.keys = append(t.keys, key)
+		t.values = append(t.values, v)
+	}
+	return t, nil
+}
+
+// All returns the indices of all rows of the table.
+func (t *drillTable) all() []int {
+	rows := make([]int, len(t.values))
+	for i := range rows {
+		rows[i] = i
+	}
+	return rows
+}
+
+// WritePage writes the page of the given rows, at depth len(path),
+// and the pages below it, and returns the name of its file.
+// The path is the values of the grouping columns leading to the page,
+// and nav is the navigation links of the pages above it.
+func (t *drillTable) writePage(dir string, path []string, rows []int, nav []htmlLink) (string, error) {
+	file := "index.html"
+	if t.pages > 0 {
+		file = fmt.Sprintf("page%d.html", t.pages)
+	}
+	t.pages++
+
+	depth := len(path)
+	col := t.groupBy[depth]
+	var names []string
+	groups := make(map[string][]int)
+	for _, r := range rows {
+		k := t.keys[r][depth]
+		if _, ok := groups[k]; !ok {
+			names = append(names, k)
+		}
+		groups[k] = append(groups[k], r)
+	}
+
+	label := "all"
+	if depth > 0 {
+		label = t.groupBy[depth-1] + "=" + path[depth-1]
+	}
+	nav = append(nav[:len(nav):len(nav)], htmlLink{Text: label})
+	hrefs := make(map[string]string)
+	if depth+1 < len(t.groupBy) {
+		above := append(nav[:len(nav)-1:len(nav)-1], htmlLink{Text: label, Href: file})
+		for _, name := range names {
+			child, err := t.writePage(dir, append(path[:depth:depth], name), groups[name], above)
+			if err != nil {
+				return "", err
+			}
+			hrefs[name] = child
+		}
+	}
+
+	var boxes []box
+	for _, name := range names {
+		vs := make([]float64, len(groups[name]))
+		for i, r := range groups[name] {
+			vs[i] = t.values[r]
+		}
+		boxes = append(boxes, newBox(name, vs))
+	}
+	summarize(boxes)
+	if *sortKey != "" {
+		if err := sortBoxes(boxes, *sortKey); err != nil {
+			return "", err
+		}
+	}
+	heading := t.valueName + " by " + col
+	if *title != "" {
+		heading = *title + ": " + heading
+	}
+	for i := 0; i < depth; i++ {
+		heading += ", " + t.groupBy[i] + "=" + path[i]
+	}
+
+	f, err := os.Create(filepath.Join(dir, file))
+	if err != nil {
+		return "", err
+	}
+	if err := writeLinkedHTML(boxes, heading, nav, hrefs, f); err != nil {
+		f.Close()
+		return "", fmt.Errorf("Write failed: %v", err)
+	}
+	return file, f.Close()
+}
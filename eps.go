@@ -0,0 +1,199 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// An epsCanvas draws an Encapsulated PostScript image, for -o eps,
+// to be included in LaTeX and other documents without conversion.
+// Its size is that of -width and -height, taken as pixels at 96 per inch,
+// so the default image is 600 by 450 points.
+// Shapes are styled by role as in svgStyle,
+// and text is in Helvetica, re-encoded as ISO Latin-1
+// so that characters such as µ and ° are drawn;
+// other characters beyond ASCII are drawn as question marks.
+type epsCanvas struct {
+	w   io.Writer
+	buf bytes.Buffer
+	// Style is the style of the current box.
+	style style
+	// Color and pen are the operators setting the current color,
+	// and line width and dash, so that they are only set when they change.
+	color, pen string
+}
+
+// EpsScale is the number of points per pixel of -width and -height.
+const epsScale = 72.0 / 96
+
+// EpsPt returns the PostScript coordinates of a point.
+// Both have the origin at the bottom left.
+func epsPt(x, y float64) (float64, float64) {
+	return x * float64(*width) * epsScale, y * float64(*height) * epsScale
+}
+
+// SetInk sets the color, line width, and dash of the shapes of a role,
+// as styled by svgStyle and the style of the current box.
+func (c *epsCanvas) setInk(role string) {
+	color, lw, dash := c.style.color, 0.75, styleDashes[c.style.line]
+	switch role {
+	case "median":
+		lw = 1.5
+	case "shade":
+		color = "0.8"
+	case "heat":
+		color = "0.533"
+	case "zoom":
+		color, dash = "0.533", "4 3"
+	}
+	c.setColor(color)
+	var ds []string
+	for _, d := range strings.Fields(dash) {
+		v, _ := strconv.ParseFloat(d, 64)
+		ds = append(ds, strconv.FormatFloat(v*epsScale, 'g', -1, 64))
+	}
+	pen := fmt.Sprintf("%g setlinewidth [%s] 0 setdash", lw, strings.Join(ds, " "))
+	if pen != c.pen {
+		c.pen = pen
+		fmt.Fprintln(&c.buf, pen)
+	}
+}
+
+// SetColor sets the color of what is drawn next:
+// a name of styleColors, black if empty, or a gray level.
+func (c *epsCanvas) setColor(color string) {
+	op := "0 setgray"
+	if rgb, ok := styleColors[color]; ok {
+		op = fmt.Sprintf("%.3g %.3g %.3g setrgbcolor", float64(rgb.R)/255, float64(rgb.G)/255, float64(rgb.B)/255)
+	} else if color != "" {
+		op = color + " setgray"
+	}
+	if op != c.color {
+		c.color = op
+		fmt.Fprintln(&c.buf, op)
+	}
+}
+
+func (c *epsCanvas) line(role string, x0, y0, x1, y1 float64) {
+	c.setInk(role)
+	x0, y0 = epsPt(x0, y0)
+	x1, y1 = epsPt(x1, y1)
+	fmt.Fprintf(&c.buf, "%.2f %.2f %.2f %.2f L\n", x0, y0, x1, y1)
+}
+
+func (c *epsCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.setInk(role)
+	x0, y0 = epsPt(x0, y0)
+	x1, y1 = epsPt(x1, y1)
+	fmt.Fprintf(&c.buf, "%.2f %.2f %.2f %.2f rectstroke\n", x0, y0, x1-x0, y1-y0)
+}
+
+func (c *epsCanvas) circle(role string, x, y, r float64) {
+	c.setInk(role)
+	x, y = epsPt(x, y)
+	fmt.Fprintf(&c.buf, "%.2f %.2f %.2f C\n", x, y, r*float64(*width)*epsScale)
+}
+
+func (c *epsCanvas) polyline(role string, xs, ys []float64) {
+	c.setInk(role)
+	c.buf.WriteString("newpath")
+	for i := range xs {
+		x, y := epsPt(xs[i], ys[i])
+		op := "lineto"
+		if i == 0 {
+			op = "moveto"
+		}
+		fmt.Fprintf(&c.buf, " %.2f %.2f %s", x, y, op)
+	}
+	c.buf.WriteString(" stroke\n")
+}
+
+// EpsAligns maps plot(1) alignments to the fraction of the width
+// of a string that is left of its point.
+var epsAligns = map[byte]float64{'L': 0, 'C': 0.5, 'R': 1}
+
+func (c *epsCanvas) text(role string, x, y float64, align byte, s string) {
+	x, y = epsPt(x, y)
+	size := 11 * epsScale
+	if role == "title" {
+		size = 15 * epsScale
+	}
+	color := c.style.color
+	if color == "" && role == "tick" {
+		color = "0.267"
+	}
+	c.setColor(color)
+	fmt.Fprintf(&c.buf, "%s %.2f %.2f %g %g T\n", epsString(s), x, y, size, epsAligns[align])
+}
+
+// EpsString returns s as a PostScript string in ISO Latin-1,
+// with characters beyond it replaced by question marks.
+func epsString(s string) string {
+	var b strings.Builder
+	b.WriteByte('(')
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= ' ' && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	b.WriteByte(')')
+	return b.String()
+}
+
+func (c *epsCanvas) tooltip(string) {}
+
+func (c *epsCanvas) group(name string) {
+	c.style = style{}
+	if name != "" {
+		c.style = styleOf(name)
+	}
+}
+
+// Close writes the EPS image.
+func (c *epsCanvas) close() error {
+	w, h := float64(*width)*epsScale, float64(*height)*epsScale
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%%!PS-Adobe-3.0 EPSF-3.0\n%%%%BoundingBox: 0 0 %.0f %.0f\n", w, h)
+	fmt.Fprintf(&buf, "%%%%HiResBoundingBox: 0 0 %.2f %.2f\n", w, h)
+	buf.WriteString(epsProlog)
+	buf.Write(c.buf.Bytes())
+	buf.WriteString("showpage\nend\n%%EOF\n")
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
+
+// EpsProlog defines the procedures of EPS output in a dictionary of its own:
+// L, a line from x0 y0 to x1 y1; C, a circle at x y of radius r;
+// and T, a string s at x y in Helvetica of a size,
+// vertically centered and shifted left by a fraction of its width.
+const epsProlog = `%%Creator: box
+%%LanguageLevel: 2
+%%EndComments
+%%BeginProlog
+10 dict begin
+/Helvetica findfont dup length dict begin
+	{ 1 index /FID ne { def } { pop pop } ifelse } forall
+	/Encoding ISOLatin1Encoding def
+	currentdict
+end
+/Helvetica-ISO exch definefont pop
+/L { newpath moveto lineto stroke } bind def
+/C { newpath 0 360 arc stroke } bind def
+/T {
+	/a exch def /sz exch def moveto
+	/Helvetica-ISO findfont sz scalefont setfont
+	dup stringwidth pop a mul neg sz -0.35 mul rmoveto show
+} bind def
+%%EndProlog
+`
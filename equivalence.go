@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+)
+
+// An equivalence is the result of the -equivalence or -non-inferiority test
+// of a comparison, for report.
+type equivalence struct {
+	// Margin is the margin of the test, in the units of the data sets.
+	margin float64
+	p      float64
+	// Pass is whether the new mean is shown to be within the margin of the old,
+	// or, for -non-inferiority, less than the margin worse than the old.
+	pass bool
+}
+
+// TestEquivalence tests, for each comparison of old and new boxes,
+// whether the new mean is within a margin of the old,
+// a fraction of the old mean if rel is set, or else an absolute margin,
+// passing if the p-value of tost is below alpha.
+func testEquivalence(cs []comparison, rel bool, size, alpha float64) {
+	testMargins(cs, rel, size, alpha, tost)
+}
+
+// TestNonInferiority tests, for each comparison of old and new boxes,
+// whether the new mean is less than a margin worse than the old,
+// as testEquivalence, but passing if the p-value of nonInferiority is below alpha.
+// Larger means are worse, unless higherBetter is set.
+func testNonInferiority(cs []comparison, rel bool, size, alpha float64, higherBetter bool) {
+	testMargins(cs, rel, size, alpha, func(old, new box, margin float64) float64 {
+		return nonInferiority(old, new, margin, higherBetter)
+	})
+}
+
+// TestMargins sets the equiv of each comparison of old and new boxes
+// to the result of a test returning a p-value for a margin.
+func testMargins(cs []comparison, rel bool, size, alpha float64, test func(old, new box, margin float64) float64) {
+	for i := range cs {
+		c := &cs[i]
+		if c.old == nil || c.new == nil {
+			continue
+		}
+		margin := size
+		if rel {
+			margin = size * math.Abs(c.old.mean)
+		}
+		p := test(*c.old, *c.new, margin)
+		c.equiv = &equivalence{margin: margin, p: p, pass: p < alpha}
+	}
+}
+
+// Tost returns the p-value of the two one-sided tests (TOST)
+// of the equivalence of the means of two boxes within a margin:
+// the larger of the p-values of Welch's t tests
+// that the difference of the new and old means is above -margin
+// and that it is below margin.
+// A small p-value shows that the difference is within the margin,
+// in either direction, whereas a large p-value of welch
+// only fails to show that there is one.
+// The p-value is 1 for boxes of fewer than two values.
+func tost(old, new box, margin float64) float64 {
+	if old.n < 2 || new.n < 2 {
+		return 1
+	}
+	diff := new.mean - old.mean
+	se, df := welchSE(old, new)
+	if se == 0 {
+		if math.Abs(diff) < margin {
+			return 0
+		}
+		return 1
+	}
+	lower := 1 - tCDF((diff+margin)/se, df)
+	upper := tCDF((diff-margin)/se, df)
+	return math.Max(lower, upper)
+}
+
+// NonInferiority returns the p-value of the one-sided test
+// that the new mean is less than a margin worse than the old:
+// the p-value of Welch's t test that the difference of the new and old means
+// is below the margin, or, if higherBetter is set, above -margin.
+// Only the worse direction is tested, so any improvement,
+// however large, is not worse, unlike with tost.
+// The p-value is 1 for boxes of fewer than two values.
+func nonInferiority(old, new box, margin float64, higherBetter bool) float64 {
+	if old.n < 2 || new.n < 2 {
+		return 1
+	}
+	worse := new.mean - old.mean
+	if higherBetter {
+		worse = -worse
+	}
+	se, df := welchSE(old, new)
+	if se == 0 {
+		if worse < margin {
+			return 0
+		}
+		return 1
+	}
+	return tCDF((worse-margin)/se, df)
+}
+
+// WriteEquivalence writes a line for each equivalence test of the comparisons,
+// starting with PASS or FAIL, for continuous integration logs.
+// Claim is what a passing test shows of the mean change,
+// as in within ±5% or less than 5% worse.
+func writeEquivalence(w io.Writer, cs []comparison, claim string) error {
+	for _, c := range cs {
+		e := c.equiv
+		if e == nil {
+			continue
+		}
+		change := formatValue(c.new.mean - c.old.mean)
+		if c.old.mean != 0 {
+			change = fmt.Sprintf("%+.1f%%", 100*(c.new.mean-c.old.mean)/math.Abs(c.old.mean))
+		}
+		result := "PASS %s: mean change %s is %s (p=%s)\n"
+		if !e.pass {
+			result = "FAIL %s: mean change %s is not shown to be %s (p=%s)\n"
+		}
+		if _, err := fmt.Fprintf(w, result, c.name, change, claim, formatValue(e.p)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package main
+
+import "testing"
+
+// EquivalenceBoxes returns summarized boxes of the values 100 to 109,
+// and of them scaled by scale.
+func equivalenceBoxes(scale float64) (box, box) {
+	var olds, news []float64
+	for i := 0; i < 10; i++ {
+		olds = append(olds, float64(100+i))
+		news = append(news, scale*float64(100+i))
+	}
+	bs := []box{newBox("old", olds), newBox("new", news)}
+	summarize(bs)
+	return bs[0], bs[1]
+}
+
+func TestEquivalence(t *testing.T) {
+	tests := []struct {
+		name  string
+		scale float64
+		pass  bool
+	}{
+		{"unchanged", 1, true},
+		{"1% slower", 1.01, true},
+		{"1% faster", 0.99, true},
+		{"2x slower", 2, false},
+		{"2x faster", 0.5, false},
+	}
+	for _, test := range tests {
+		old, new := equivalenceBoxes(test.scale)
+		cs := []comparison{{name: test.name, old: &old, new: &new}}
+		testEquivalence(cs, true, 0.05, 0.05)
+		if cs[0].equiv.pass != test.pass {
+			t.Errorf("%s: pass=%v (p=%v), want %v", test.name, cs[0].equiv.pass, cs[0].equiv.p, test.pass)
+		}
+	}
+}
+
+func TestNonInferiority(t *testing.T) {
+	tests := []struct {
+		name         string
+		scale        float64
+		higherBetter bool
+		pass         bool
+	}{
+		{"unchanged", 1, false, true},
+		{"2x faster", 0.5, false, true},
+		{"2x slower", 2, false, false},
+		{"2x more throughput", 2, true, true},
+		{"half the throughput", 0.5, true, false},
+	}
+	for _, test := range tests {
+		old, new := equivalenceBoxes(test.scale)
+		cs := []comparison{{name: test.name, old: &old, new: &new}}
+		testNonInferiority(cs, true, 0.05, 0.05, test.higherBetter)
+		if cs[0].equiv.pass != test.pass {
+			t.Errorf("%s: pass=%v (p=%v), want %v", test.name, cs[0].equiv.pass, cs[0].equiv.p, test.pass)
+		}
+	}
+}
@@ -0,0 +1,220 @@
+package main
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// ExactFormats are the input formats that support -exact.
+var exactFormats = map[string]bool{
+	"tokens":  true,
+	"lines":   true,
+	"records": true,
+	"csv":     true,
+	"tsv":     true,
+}
+
+// A valueList accumulates the values of a data set as they are read:
+// as float64s, or, with -exact, as int64s.
+// If arena is non-nil, the float64s are allocated from it,
+// so lists sharing an arena must be filled one after another.
+type valueList struct {
+	fs    []float64
+	is    []int64
+	arena *floatArena
+	// Censored are the indices of the censored values, in increasing order.
+	censored []int
+}
+
+// Parse parses a value and appends it to the list.
+// With -exact, the value must be an integer that fits in an int64.
+// A value of the form >N is censored: it is known only to be above N,
+// and N is appended as its value.
+func (l *valueList) parse(s string) error {
+	if len(s) > 1 && s[0] == '>' {
+		n := l.len()
+		if err := l.parseValue(s[1:]); err != nil {
+			return err
+		}
+		l.censored = append(l.censored, n)
+		return nil
+	}
+	return l.parseValue(s)
+}
+
+// ParseToken is parse of a token of a scanner's buffer.
+// A plain float64 is parsed from the bytes themselves,
+// which are not copied to a string.
+func (l *valueList) parseToken(tok []byte) error {
+	if *exact || len(tok) > 1 && tok[0] == '>' {
+		return l.parse(string(tok))
+	}
+	v, err := parseFloat(tok)
+	if err != nil {
+		return err
+	}
+	l.appendFloat(v)
+	return nil
+}
+
+// ParseValue parses an uncensored value and appends it to the list.
+func (l *valueList) parseValue(s string) error {
+	if *exact {
+		i, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		l.is = append(l.is, i)
+		return nil
+	}
+	v, err := parseFloat(s)
+	if err != nil {
+		return err
+	}
+	l.appendFloat(v)
+	return nil
+}
+
+// AppendFloat appends a float64 to the list, from its arena if it has one.
+func (l *valueList) appendFloat(v float64) {
+	if l.arena != nil {
+		l.fs = l.arena.append(l.fs, v)
+		return
+	}
+	l.fs = append(l.fs, v)
+}
+
+func (l valueList) len() int { return len(l.fs) + len(l.is) }
+
+// Slice returns the values of the list from i up to j.
+func (l valueList) slice(i, j int) valueList {
+	var s valueList
+	if *exact {
+		s.is = l.is[i:j]
+	} else {
+		s.fs = l.fs[i:j]
+	}
+	for _, k := range l.censored {
+		if k >= i && k < j {
+			s.censored = append(s.censored, k-i)
+		}
+	}
+	return s
+}
+
+// Box returns a box with the given name and the values of the list.
+func (l valueList) box(name string) box {
+	var b box
+	if *exact {
+		b = exactBox(name, l.is)
+	} else {
+		b = newBox(name, l.fs)
+	}
+	for _, i := range l.censored {
+		b.censored = append(b.censored, b.values[i])
+	}
+	return b
+}
+
+// ExactStats are the statistics of a box computed without rounding.
+type exactStats struct {
+	min, q1, q2, q3, max, mean *big.Rat
+}
+
+// ExactBox returns a box with the given name and integer values
+// and with its summary statistics computed exactly.
+// The quartiles are computed as by stats5,
+// and the mean and variance from sums of big.Ints,
+// so no precision is lost for values beyond 2^53.
+// The float64 statistics of the box are the nearest to the exact ones.
+func exactBox(name string, is []int64) box {
+	b := box{name: name, values: make([]float64, len(is)), n: len(is)}
+	for i, v := range is {
+		b.values[i] = float64(v)
+	}
+	if len(is) == 0 {
+		return b
+	}
+	if !*inPlace {
+		is = append([]int64(nil), is...)
+	}
+	boxplot.SelectRanks(int64Slice(is), boxplot.QuartileRanks(len(is), boxplot.Tukey))
+	min, max := is[0], is[0]
+	for _, v := range is {
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+	half := (len(is) + 1) / 2
+	e := &exactStats{
+		min:  new(big.Rat).SetInt64(min),
+		q1:   medianExact(is[:half]),
+		q2:   medianExact(is),
+		q3:   medianExact(is[len(is)-half:]),
+		max:  new(big.Rat).SetInt64(max),
+		mean: new(big.Rat),
+	}
+	sum, sumSq := new(big.Int), new(big.Int)
+	for _, v := range is {
+		x := big.NewInt(v)
+		sum.Add(sum, x)
+		sumSq.Add(sumSq, x.Mul(x, x))
+	}
+	n := big.NewInt(int64(len(is)))
+	e.mean.SetFrac(sum, n)
+	b.exact = e
+	b.min, _ = e.min.Float64()
+	b.q1, _ = e.q1.Float64()
+	b.q2, _ = e.q2.Float64()
+	b.q3, _ = e.q3.Float64()
+	b.max, _ = e.max.Float64()
+	b.mean, _ = e.mean.Float64()
+	if len(is) > 1 {
+		// The sample variance is (nΣx² - (Σx)²) / (n(n-1)).
+		num := new(big.Int).Mul(n, sumSq)
+		num.Sub(num, sum.Mul(sum, sum))
+		den := new(big.Int).Mul(n, big.NewInt(int64(len(is)-1)))
+		variance, _ := new(big.Rat).SetFrac(num, den).Float64()
+		b.stddev = math.Sqrt(variance)
+	}
+	return b
+}
+
+// MedianExact returns the median of a sorted int64 slice,
+// or of one in which only the middle values are in their sorted places.
+func medianExact(is []int64) *big.Rat {
+	if len(is)%2 == 1 {
+		return new(big.Rat).SetInt64(is[len(is)/2])
+	}
+	sum := new(big.Int).Add(big.NewInt(is[len(is)/2-1]), big.NewInt(is[len(is)/2]))
+	return new(big.Rat).SetFrac(sum, big.NewInt(2))
+}
+
+// FormatExact returns an exact value formatted for output
+// with -precision significant digits, as by formatValue.
+// With -precision -1, the value is printed in full.
+func formatExact(r *big.Rat) string {
+	return new(big.Float).SetRat(r).Text('g', *precision)
+}
+
+// StatLabels returns the minimum, quartiles, and maximum of a box
+// formatted for output, from the exact statistics if there are any.
+func (b box) statLabels() (min, q1, q2, q3, max string) {
+	if e := b.exact; e != nil {
+		return formatExact(e.min), formatExact(e.q1), formatExact(e.q2), formatExact(e.q3), formatExact(e.max)
+	}
+	return formatValue(b.min), formatValue(b.q1), formatValue(b.q2), formatValue(b.q3), formatValue(b.max)
+}
+
+// Int64Slice sorts a []int64 in increasing order.
+type int64Slice []int64
+
+func (s int64Slice) Len() int           { return len(s) }
+func (s int64Slice) Less(i, j int) bool { return s[i] < s[j] }
+func (s int64Slice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
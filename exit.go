@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+)
+
+// The exit statuses of box, distinct for each kind of failure,
+// so that scripts running box can tell them apart.
+const (
+	exitOK = 0
+	// ExitUsage is the status of bad flags or arguments.
+	exitUsage = 1
+	// ExitParse is the status of input that cannot be read.
+	exitParse = 2
+	// ExitEmpty is the status of input without any values.
+	exitEmpty = 3
+	// ExitOutput is the status of failing to render or write the output.
+	exitOutput = 4
+	// ExitRegression is the status of box report or box compare finding a regression,
+	// or of box report finding a data set that fails its -equivalence or -non-inferiority test,
+	// or of box render computing statistics that differ from its manifest.
+	exitRegression = 5
+)
+
+// ErrEmpty is the error of input without any values.
+var errEmpty = errors.New("No values in the input")
+
+// An exitError is an error with the exit status that it causes.
+type exitError struct {
+	status int
+	err    error
+}
+
+func (e *exitError) Error() string { return e.err.Error() }
+
+func (e *exitError) Unwrap() error { return e.err }
+
+// WithStatus returns the error with an exit status,
+// unless it is nil or already has one.
+func withStatus(status int, err error) error {
+	var e *exitError
+	if err == nil || errors.As(err, &e) {
+		return err
+	}
+	return &exitError{status: status, err: err}
+}
+
+// ExitStatus returns the exit status caused by an error:
+// exitOK for nil, and exitOutput for an error without a status.
+func exitStatus(err error) int {
+	var e *exitError
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &e):
+		return e.status
+	}
+	return exitOutput
+}
+
+// ParseFlags parses the flags of a flag set made with flag.ContinueOnError,
+// exiting with exitUsage if they are bad, or exitOK if -help was given,
+// after the flag set has printed its usage.
+func parseFlags(fs *flag.FlagSet, args []string) {
+	switch err := fs.Parse(args); {
+	case err == flag.ErrHelp:
+		os.Exit(exitOK)
+	case err != nil:
+		os.Exit(exitUsage)
+	}
+}
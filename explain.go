@@ -0,0 +1,140 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// WriteExplain writes, for -explain, how each statistic of the named box was computed:
+// the sorted positions and values from which each quartile was taken,
+// with their interpolation weights, the whisker rule and its cutoffs,
+// and the outliers beyond the whiskers,
+// so that the statistics can be checked against other tools.
+// Positions are 1-based, as in R.
+func writeExplain(boxes []box, name string, w io.Writer) error {
+	for _, b := range boxes {
+		if b.name == name {
+			return explain(b, w)
+		}
+	}
+	return fmt.Errorf("No data set named %s", name)
+}
+
+// Explain writes the explanation of a box; see writeExplain.
+func explain(b box, w io.Writer) error {
+	var buf strings.Builder
+	p := func(format string, args ...interface{}) { fmt.Fprintf(&buf, format+"\n", args...) }
+	f := formatValue
+	p("%s: n=%d", b.name, b.n)
+	vs := append([]float64(nil), b.values...)
+	sort.Float64s(vs)
+	switch {
+	case b.n == 0:
+		p("no values")
+	case b.exact != nil:
+		p("-exact: the statistics are computed as below, but with exact rational arithmetic")
+	case b.digest != nil && *approx:
+		p("-approx: the quartiles are estimated from a streaming t-digest, not computed from values")
+	case b.digest != nil:
+		p("the statistics are estimated from a sketch, not computed from values")
+	case len(vs) == 0:
+		p("the statistics are as read from the input, not computed from values")
+	}
+	if len(vs) > 0 && b.sample != nil {
+		p("-budget: the quartiles are of a random sample of %d of the values, not of these positions", len(b.sample))
+	}
+	if len(vs) > 0 {
+		x := func(i int) string { return fmt.Sprintf("x[%d]=%s", i, f(vs[i-1])) }
+		p("min = %s", x(1))
+		t, _ := quantileType(*quantileMethod)
+		for i, q := range []struct {
+			name string
+			p, v float64
+		}{{"q1", 0.25, b.q1}, {"q2", 0.5, b.q2}, {"q3", 0.75, b.q3}} {
+			if t == 0 {
+				lo, hi := 1, len(vs)
+				half := (len(vs) + 1) / 2
+				switch i {
+				case 0:
+					hi = half
+				case 2:
+					lo = len(vs) - half + 1
+				}
+				p("%s = %s, the median of x[%d..%d]: %s", q.name, f(q.v), lo, hi, medianTerms(lo, hi, x))
+				continue
+			}
+			j, h := boxplot.QuantilePosition(len(vs), q.p, boxplot.Method(t))
+			clamp := func(i float64) int { return int(math.Max(1, math.Min(float64(len(vs)), i))) }
+			p("%s = %s, Hyndman-Fan type %d at position %s: %s", q.name, f(q.v), t,
+				f(boxplot.QuantileRawPosition(len(vs), q.p, boxplot.Method(t))), weighted(clamp(j), clamp(j+1), h, x))
+		}
+		p("max = %s", x(len(vs)))
+	} else if b.n > 0 {
+		p("min = %s, q1 = %s, q2 = %s, q3 = %s, max = %s", f(b.min), f(b.q1), f(b.q2), f(b.q3), f(b.max))
+	}
+	if b.n > 0 {
+		p("mean = %s, stddev = %s", f(b.mean), f(b.stddev))
+	}
+	if len(b.censored) > 0 {
+		p("%d values are censored, counted at their limits", len(b.censored))
+	}
+	lo, hi, outliers := b.whiskers()
+	kind, plo, phi, _ := parseWhiskers(*whiskerRule)
+	switch {
+	case kind == "tukey" && len(vs) > 0:
+		iqr := b.q3 - b.q1
+		p("whiskers: -whiskers tukey, IQR = q3 - q1 = %s, fences q1 - %s·IQR = %s and q3 + %s·IQR = %s",
+			f(iqr), f(*whiskerK), f(b.q1-*whiskerK*iqr), f(*whiskerK), f(b.q3+*whiskerK*iqr))
+		p("  ending at the most extreme values within the fences: %s and %s", f(lo), f(hi))
+	case kind == "percentile" && len(vs) > 0:
+		p("whiskers: -whiskers %s, linearly interpolated at positions 1 + p(n-1) = %s and %s: %s and %s",
+			*whiskerRule, f(1+plo*float64(len(vs)-1)), f(1+phi*float64(len(vs)-1)), f(lo), f(hi))
+	case kind == "percentile" && b.digest != nil:
+		p("whiskers: -whiskers %s, estimated from the sketch: %s and %s", *whiskerRule, f(lo), f(hi))
+	default:
+		p("whiskers: at the minimum and maximum, %s and %s", f(lo), f(hi))
+	}
+	if len(outliers) == 0 {
+		p("outliers: none")
+	} else {
+		ids := b.outlierIDs(lo, hi)
+		var list []string
+		for i, v := range outliers {
+			s := f(v)
+			if ids != nil && ids[i] != "" {
+				s = ids[i] + "=" + s
+			}
+			list = append(list, s)
+		}
+		p("outliers: %d beyond the whiskers: %s", len(outliers), strings.Join(list, " "))
+	}
+	_, err := io.WriteString(w, buf.String())
+	return err
+}
+
+// MedianTerms returns the order statistics of the median of x[lo..hi]:
+// the middle one, or the average of the middle two.
+func medianTerms(lo, hi int, x func(int) string) string {
+	mid := (lo + hi) / 2
+	if (hi-lo)%2 == 0 {
+		return x(mid)
+	}
+	return fmt.Sprintf("(%s + %s) / 2", x(mid), x(mid+1))
+}
+
+// Weighted returns the interpolation of order statistics i and j with weight h:
+// (1-h)·x[i] + h·x[j], or just the one with all of the weight.
+func weighted(i, j int, h float64, x func(int) string) string {
+	switch {
+	case h == 0 || i == j:
+		return x(i)
+	case h == 1:
+		return x(j)
+	}
+	return fmt.Sprintf("%s·%s + %s·%s", formatValue(1-h), x(i), formatValue(h), x(j))
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// TextHeight is the vertical space taken by a line of text.
+	textHeight = 0.02
+	// TitleHeight is the height of the strip holding the title or column labels,
+	// which leaves half a line of space above and below the text.
+	titleHeight = 2 * textHeight
+	// CaptionHeight is the height of the strip holding captions
+	// at the top of a panel.
+	captionHeight = 1.5 * textHeight
+	// CharWidth is the approximate width of a character of text.
+	charWidth = 0.01
+	// LegendHeight is the height of the legend strip at the bottom of a figure.
+	legendHeight = 0.04
+	// RowLabelWidth is the width of the row labels to the left of a matrix.
+	rowLabelWidth = 0.1
+)
+
+// A figure is the layout of an entire output:
+// a title, one or more panels of boxes,
+// labels for the panels, and a legend,
+// each of which appears once for the whole figure.
+type figure struct {
+	title  string
+	panels []panel
+	labels []label
+	legend []legendEntry
+	// Texts are the labels placed by -text.
+	texts []label
+	// TitleArea and legendArea are the regions
+	// allocated to the title and legend.
+	titleArea, legendArea rect
+}
+
+// A panel is a region of a figure in which boxes are drawn side by side.
+type panel struct {
+	r rect
+	// Name is the name of the panel for captions,
+	// or the empty string if it has no caption.
+	name string
+	// Frame is whether to draw a frame around the panel.
+	frame bool
+	// NoLabels is whether to omit the box name labels.
+	noLabels bool
+	// Inset is whether the panel zooms in on some of the boxes
+	// of the panel before it, with a scale of its own.
+	inset bool
+	boxes []box
+	// Min and max are the range of values spanned by the panel.
+	min, max float64
+}
+
+// A label is a line of text in a figure,
+// aligned by one of the plot(1) alignments L, C, or R.
+type label struct {
+	x, y  float64
+	align byte
+	text  string
+}
+
+// A legendEntry explains a mark drawn in the figure.
+// Key, if non-nil, draws an example of the mark centered at x, y.
+type legendEntry struct {
+	key  func(cv canvas, x, y float64)
+	text string
+}
+
+// LayoutFigure returns the layout of a figure of the boxes.
+// Without -matrix, there is one panel with all of the boxes.
+// With -matrix, there is a panel for each box, with its own scale,
+// unless -share-y is set.
+// With -inset, there is also an inset panel of the matching boxes.
+// With -all-metrics, there is a panel for each metric, with its own scale,
+// unless -share-y is set.
+func layoutFigure(boxes []box, title string) *figure {
+	f := &figure{title: title, legend: legendEntries(boxes)}
+	l := layout{free: page}
+	f.texts = layoutTexts(&l)
+	if len(f.legend) > 0 {
+		f.legendArea = l.bottom(legendHeight)
+	}
+	if title != "" {
+		f.titleArea = l.top(titleHeight)
+	}
+	if *matrix {
+		f.layoutMatrix(boxes, l)
+	} else if *inset != "" {
+		f.layoutInset(boxes, l)
+	} else if *allMetrics {
+		f.layoutMetrics(boxes, l)
+	} else {
+		f.panels = []panel{{r: l.free, boxes: boxes}}
+	}
+	min, max := minMax(boxes)
+	for i := range f.panels {
+		p := &f.panels[i]
+		p.min, p.max = min, max
+		if p.inset || (*matrix || *allMetrics) && !*shareY {
+			p.min, p.max = minMax(p.boxes)
+		}
+		if !p.inset {
+			p.min, p.max = pinned(p.min, p.max)
+		}
+	}
+	return f
+}
+
+// LayoutMatrix lays out a framed panel for each box in a grid
+// within the free space of a layout,
+// with row labels to the left and column labels above.
+// The row and column of a box are the parts of its name
+// before and after the first dot, in order of first appearance.
+func (f *figure) layoutMatrix(boxes []box, l layout) {
+	var rows, cols []string
+	cells := make(map[[2]string]box)
+	seenRow := make(map[string]bool)
+	seenCol := make(map[string]bool)
+	for _, b := range boxes {
+		row, col := b.name, ""
+		if i := strings.IndexByte(b.name, '.'); i >= 0 {
+			row, col = b.name[:i], b.name[i+1:]
+		}
+		if !seenRow[row] {
+			seenRow[row] = true
+			rows = append(rows, row)
+		}
+		if !seenCol[col] {
+			seenCol[col] = true
+			cols = append(cols, col)
+		}
+		cells[[2]string{row, col}] = b
+	}
+
+	colLabels := l.top(titleHeight)
+	rowLabels := l.left(rowLabelWidth)
+	area := l.free
+	pw := (area.x1 - area.x0) / float64(len(cols))
+	for j, col := range cols {
+		x := area.x0 + (float64(j)+0.5)*pw
+		f.labels = append(f.labels, label{x: x, y: (colLabels.y0 + colLabels.y1) / 2, align: 'C', text: col})
+	}
+	ph := (area.y1 - area.y0) / float64(len(rows))
+	for i, row := range rows {
+		y1 := area.y1 - float64(i)*ph
+		y0 := y1 - ph
+		f.labels = append(f.labels, label{x: rowLabels.x1 - textHeight, y: (y0 + y1) / 2, align: 'R', text: row})
+		for j, col := range cols {
+			p := panel{
+				r: rect{
+					x0: area.x0 + float64(j)*pw,
+					y0: y0,
+					x1: area.x0 + float64(j+1)*pw,
+					y1: y1,
+				},
+				name:     row + "." + col,
+				frame:    true,
+				noLabels: true,
+			}
+			if b, ok := cells[[2]string{row, col}]; ok {
+				p.boxes = []box{b}
+			}
+			f.panels = append(f.panels, p)
+		}
+	}
+}
+
+// Draw draws the figure, calling drawCol to draw each box.
+func (f *figure) draw(cv canvas, drawCol func(canvas, box, column)) {
+	if f.title != "" {
+		r := f.titleArea
+		cv.text("title", (r.x0+r.x1)/2, (r.y0+r.y1)/2, 'C', f.title)
+	}
+	for _, l := range f.labels {
+		cv.text("label", l.x, l.y, l.align, l.text)
+	}
+	for _, t := range f.texts {
+		cv.text("text", t.x, t.y, t.align, t.text)
+	}
+	var cols []column
+	for i, p := range f.panels {
+		if p.frame {
+			cv.box("frame", p.r.x0, p.r.y0, p.r.x1, p.r.y1)
+		}
+		if p.inset && i > 0 {
+			drawZoom(cv, f.panels[i-1], cols, p)
+		}
+		cols = nil
+		if len(p.boxes) > 0 && *horizontal {
+			t := p
+			t.r = p.r.transpose()
+			cols = drawPanel(&transposedCanvas{canvas: cv}, t, drawCol)
+		} else if len(p.boxes) > 0 {
+			cols = drawPanel(cv, p, drawCol)
+		}
+		if p.name != "" {
+			drawCaption(cv, p.r.x1, p.r.y1, caption(p.name, p.boxes))
+		}
+	}
+	x, y := f.legendArea.x0+0.05, (f.legendArea.y0+f.legendArea.y1)/2
+	for _, e := range f.legend {
+		if e.key != nil {
+			e.key(cv, x, y)
+			x += 2 * charWidth
+		}
+		cv.text("legend", x, y, 'L', e.text)
+		x += textWidth(e.text) + 3*charWidth
+	}
+}
+
+// DrawCaption draws a caption in the top-right corner
+// of a region whose top-right corner is x, y.
+// The caption fits in a strip of captionHeight at the top of the region.
+func drawCaption(cv canvas, x, y float64, text string) {
+	if text != "" {
+		cv.text("caption", x-charWidth, y-textHeight, 'R', text)
+	}
+}
+
+// LegendEntries returns legend entries for the marks
+// that will be drawn for the boxes.
+func legendEntries(boxes []box) []legendEntry {
+	var es []legendEntry
+	if *meanCI {
+		es = append(es, legendEntry{
+			key:  keyMeanCI,
+			text: fmt.Sprintf("mean and %s%% confidence interval", formatValue(*ciLevel*100)),
+		})
+	}
+	if groupRuns(boxes) != nil {
+		es = append(es, legendEntry{key: keyShade, text: "alternate groups"})
+	}
+	var correlated, multimodal, censored bool
+	for _, b := range boxes {
+		correlated = correlated || b.correlated()
+		multimodal = multimodal || b.multimodal()
+		censored = censored || len(b.censored) > 0
+	}
+	if correlated {
+		es = append(es, legendEntry{text: "r1: lag-1 autocorrelation"})
+	}
+	if multimodal {
+		es = append(es, legendEntry{text: "modes: density peaks"})
+	}
+	if censored {
+		es = append(es, legendEntry{key: keyCensored, text: "censored at a limit; km: Kaplan-Meier median"})
+	}
+	return es
+}
+
+func keyMeanCI(cv canvas, x, y float64) {
+	const h, cap = textHeight / 2, charWidth / 2
+	cv.line("legend", x, y-h, x, y+h)
+	cv.line("legend", x-cap, y-h, x+cap, y-h)
+	cv.line("legend", x-cap, y+h, x+cap, y+h)
+	cv.circle("legend", x, y, cap/2)
+}
+
+func keyShade(cv canvas, x, y float64) {
+	drawShade(cv, x-charWidth/2, y-textHeight/2, x+charWidth/2, y+textHeight/2)
+}
+
+func keyCensored(cv canvas, x, y float64) {
+	const w = charWidth / 2
+	cv.polyline("legend", []float64{x - w, x, x + w, x - w}, []float64{y - w, y + w, y - w, y - w})
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// InputFiles are the files named on the command line,
+// which are read in place of standard input.
+var inputFiles []string
+
+// ReadFiles reads the data sets of each of the files in turn,
+// as readInput reads them from standard input,
+// detecting the format of each file separately with -format auto.
+//
+// With -basename, the data set of each file is named by its base name
+// without its extension, and in the default format,
+// every token of the file is a value of that data set.
+// A file in another format with several data sets
+// names them <base>.<name>.
+func readFiles(paths []string) ([]box, error) {
+	format0, names0 := *format, *names
+	defer func() { *format, *names = format0, names0 }()
+	var boxes []box
+	for _, path := range paths {
+		*format = format0
+		if *baseName {
+			*names = "file"
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("Read failed: %v", err)
+		}
+		bs, err := readInput(f)
+		f.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if *baseName {
+			label := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+			for i := range bs {
+				if len(bs) == 1 {
+					bs[i].name = label
+				} else {
+					bs[i].name = label + "." + bs[i].name
+				}
+			}
+		}
+		boxes = append(boxes, bs...)
+	}
+	return boxes, nil
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// WriteGnuplot writes the boxes as a self-contained gnuplot script,
+// for -o gnuplot, which draws the same box plots as plot(1) output
+// with gnuplot's candlesticks style: a box from the first to the third quartile,
+// whiskers by -whiskers, a heavier line at the median, and outliers as circles,
+// with the names of the boxes as the labels of the x axis.
+// The statistics are in inline data blocks, so the script needs gnuplot 5 or later.
+// The value labels of plot(1) output are left to the y axis,
+// which is logarithmic with -log or -log2 and spans -ymin and -ymax if set.
+// Boxes are colored by their -style, but drawn with solid lines.
+// The flags it cannot draw, such as -matrix and -notch, are rejected by checkFormat.
+// No terminal is set, so the script plots to gnuplot's default,
+// or to one set beforehand, as in gnuplot -e 'set terminal pdf; set output "box.pdf"' box.gp.
+func writeGnuplot(boxes []box, title string, w io.Writer) error {
+	var b strings.Builder
+	p := func(format string, args ...interface{}) { fmt.Fprintf(&b, format+"\n", args...) }
+	p("# Box plots written by box; run with gnuplot -p FILE.")
+	if title != "" {
+		p("set title %s noenhanced", gnuplotString(title))
+	}
+	p("set key off")
+	p("set style fill empty")
+	p("set boxwidth 0.5")
+	p("set xrange [0.5:%d.5]", len(boxes))
+	var tics []string
+	for i, bx := range boxes {
+		tics = append(tics, fmt.Sprintf("%s %d", gnuplotString(bx.name), i+1))
+	}
+	p("set xtics noenhanced (%s)", strings.Join(tics, ", "))
+	if *logScale {
+		base := 10
+		if *log2Scale {
+			base = 2
+		}
+		p("set logscale y %d", base)
+	}
+	if !math.IsNaN(rangeMin) || !math.IsNaN(rangeMax) {
+		p("set yrange [%s:%s]", gnuplotBound(rangeMin), gnuplotBound(rangeMax))
+	}
+	// Each row of $boxes is x, q1, low whisker, high whisker, q3, median, and color.
+	p("$boxes << EOD")
+	var outliers []string
+	for i, bx := range boxes {
+		if bx.n == 0 {
+			continue
+		}
+		lo, hi, out := bx.whiskers()
+		color := styleColors["black"]
+		if st := styleOf(bx.name); st.color != "" {
+			color = styleColors[st.color]
+		}
+		rgb := int(color.R)<<16 | int(color.G)<<8 | int(color.B)
+		p("%d %s %s %s %s %s %d", i+1, gnuplotValue(bx.q1), gnuplotValue(lo), gnuplotValue(hi),
+			gnuplotValue(bx.q3), gnuplotValue(bx.q2), rgb)
+		for _, v := range out {
+			outliers = append(outliers, fmt.Sprintf("%d %s %d", i+1, gnuplotValue(v), rgb))
+		}
+	}
+	p("EOD")
+	plots := []string{
+		"$boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable",
+		"$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable",
+	}
+	if len(outliers) > 0 {
+		p("$outliers << EOD")
+		for _, o := range outliers {
+			p("%s", o)
+		}
+		p("EOD")
+		plots = append(plots, "$outliers using 1:2:3 with points pt 6 lc rgb variable")
+	}
+	p("plot %s", strings.Join(plots, ", \\\n\t"))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// GnuplotString returns s as a double-quoted gnuplot string.
+func gnuplotString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s) + `"`
+}
+
+// GnuplotValue returns a value formatted for a gnuplot data block, in full.
+func gnuplotValue(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
+
+// GnuplotBound returns an end of a gnuplot range: the value, or * if it is NaN.
+func gnuplotBound(v float64) string {
+	if math.IsNaN(v) {
+		return "*"
+	}
+	return gnuplotValue(v)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// ReadGoBench reads the output of go test -bench,
+// the benchmark result lines that benchstat reads,
+// with one box per benchmark and metric, in order of first appearance,
+// named by the benchmark, without its Benchmark prefix, and the unit,
+// as in Encode-8 ns/op, and with the unit as its metric.
+// The box values are the metric's values from each run of the benchmark.
+// Other lines, such as the goos and pkg configuration lines, are ignored.
+func readGoBench(r io.Reader) ([]box, error) {
+	var names []string
+	values := make(map[string][]float64)
+	units := make(map[string]string)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		bench, metrics, ok := parseGoBench(scanner.Text())
+		if !ok {
+			continue
+		}
+		for i := 0; i < len(metrics); i += 2 {
+			v, _ := strconv.ParseFloat(metrics[i], 64)
+			name := bench + " " + metrics[i+1]
+			if _, ok := values[name]; !ok {
+				names = append(names, name)
+				units[name] = metrics[i+1]
+			}
+			values[name] = append(values[name], v)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, errors.New("no benchmark results")
+	}
+	var boxes []box
+	for _, name := range names {
+		b := newBox(name, values[name])
+		b.metric = units[name]
+		boxes = append(boxes, b)
+	}
+	return boxes, nil
+}
+
+// ParseGoBench parses a benchmark result line of go test -bench:
+// the benchmark name, the iteration count,
+// and pairs of a value and its unit, which is not a number.
+// It returns the name without its Benchmark prefix
+// and the value and unit fields,
+// or false if the line is not a result line.
+func parseGoBench(line string) (string, []string, bool) {
+	fs := strings.Fields(line)
+	if len(fs) < 4 || len(fs)%2 != 0 || !strings.HasPrefix(fs[0], "Benchmark") {
+		return "", nil, false
+	}
+	if _, err := strconv.ParseUint(fs[1], 10, 64); err != nil {
+		return "", nil, false
+	}
+	for i := 2; i < len(fs); i += 2 {
+		_, err0 := strconv.ParseFloat(fs[i], 64)
+		_, err1 := strconv.ParseFloat(fs[i+1], 64)
+		if err0 != nil || err1 == nil {
+			return "", nil, false
+		}
+	}
+	name := strings.TrimPrefix(fs[0], "Benchmark")
+	if name == "" {
+		name = fs[0]
+	}
+	return name, fs[2:], true
+}
@@ -0,0 +1,38 @@
+package main
+
+import "strings"
+
+// GroupOf returns the group of a data set:
+// the part of its name before the first -group-sep,
+// or, if -group-sep is not set, its group from its -meta or -group-by columns,
+// or the empty string if it has neither.
+func groupOf(b box) string {
+	if *groupSep == "" {
+		return b.group
+	}
+	if i := strings.Index(b.name, *groupSep); i >= 0 {
+		return b.name[:i]
+	}
+	return ""
+}
+
+// GroupRuns returns the half-open index ranges of the runs
+// of consecutive boxes in the same group,
+// or nil if no box has a group.
+func groupRuns(boxes []box) [][2]int {
+	var runs [][2]int
+	grouped := false
+	for i, b := range boxes {
+		g := groupOf(b)
+		grouped = grouped || g != ""
+		if i == 0 || g != groupOf(boxes[i-1]) {
+			runs = append(runs, [2]int{i, i + 1})
+		} else {
+			runs[len(runs)-1][1] = i + 1
+		}
+	}
+	if !grouped {
+		return nil
+	}
+	return runs
+}
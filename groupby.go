@@ -0,0 +1,113 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// A nameExpr is a -group-by expression,
+// forming a data set name from the columns of a row:
+// column names and double-quoted strings joined by +,
+// as in host + "/" + config.
+type nameExpr []nameTerm
+
+// A nameTerm is a term of a nameExpr:
+// a column, if col is set, or else a literal string.
+type nameTerm struct {
+	col string
+	lit string
+	// Index is the index of the column in the header row.
+	index int
+}
+
+// ParseNameExpr returns the nameExpr of a -group-by expression.
+func parseNameExpr(s string) (nameExpr, error) {
+	var e nameExpr
+	rest := strings.TrimSpace(s)
+	for {
+		var t nameTerm
+		switch {
+		case strings.HasPrefix(rest, `"`):
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(rest) {
+				return nil, fmt.Errorf("Bad -group-by expression: unterminated string in %s", s)
+			}
+			lit, err := strconv.Unquote(rest[:end+1])
+			if err != nil {
+				return nil, fmt.Errorf("Bad -group-by expression: %v in %s", err, s)
+			}
+			t.lit, rest = lit, rest[end+1:]
+		default:
+			end := strings.IndexFunc(rest, func(r rune) bool { return r == '+' || r == '"' || unicode.IsSpace(r) })
+			if end < 0 {
+				end = len(rest)
+			}
+			if end == 0 {
+				return nil, fmt.Errorf("Bad -group-by expression: expected a column or string in %s", s)
+			}
+			t.col, rest = rest[:end], rest[end:]
+		}
+		e = append(e, t)
+		rest = strings.TrimSpace(rest)
+		if rest == "" {
+			return e, nil
+		}
+		if rest[0] != '+' {
+			return nil, fmt.Errorf("Bad -group-by expression: expected + in %s", s)
+		}
+		rest = strings.TrimSpace(rest[1:])
+	}
+}
+
+// Bind sets the indices of the columns of an expression
+// from a header row, and returns them.
+func (e nameExpr) bind(header []string) ([]int, error) {
+	var cols []int
+	for i := range e {
+		t := &e[i]
+		if t.col == "" {
+			continue
+		}
+		t.index = -1
+		for j, h := range header {
+			if strings.TrimSpace(h) == t.col {
+				t.index = j
+			}
+		}
+		if t.index < 0 {
+			return nil, fmt.Errorf("no -group-by column %s", t.col)
+		}
+		cols = append(cols, t.index)
+	}
+	return cols, nil
+}
+
+// Eval returns the name that an expression forms from a row.
+func (e nameExpr) eval(row []string) string {
+	var b strings.Builder
+	for _, t := range e {
+		switch {
+		case t.col == "":
+			b.WriteString(t.lit)
+		case t.index < len(row):
+			b.WriteString(strings.TrimSpace(row[t.index]))
+		}
+	}
+	return b.String()
+}
+
+// GroupByExpr returns the nameExpr of -group-by, or nil if it is not set.
+func groupByExpr() (nameExpr, error) {
+	if *groupBy == "" {
+		return nil, nil
+	}
+	return parseNameExpr(*groupBy)
+}
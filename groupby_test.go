@@ -0,0 +1,35 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestGroupByUsage tests that a bad -group-by expression is a usage error,
+// and a column missing from the input is a read error.
+func TestGroupByUsage(t *testing.T) {
+	defer resetFlags()
+	for _, test := range []struct {
+		expr string
+		want int
+	}{
+		{"host +", exitUsage},
+		{`host + "/`, exitUsage},
+		{"nope", exitParse},
+		{"host", exitOK},
+	} {
+		resetFlags()
+		if err := flag.Set("csv", "true"); err != nil {
+			t.Fatal(err)
+		}
+		if err := flag.Set("group-by", test.expr); err != nil {
+			t.Fatal(err)
+		}
+		err := run(strings.NewReader("host,v\na,1\nb,2\n"), ioutil.Discard)
+		if got := exitStatus(err); got != test.want {
+			t.Errorf("-group-by %q: exit status %d (%v), want %d", test.expr, got, err, test.want)
+		}
+	}
+}
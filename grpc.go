@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// gRPC status codes.
+const (
+	grpcOK              = 0
+	grpcInvalidArgument = 3
+	grpcExhausted       = 8
+	grpcUnimplemented   = 12
+)
+
+// HandleGRPC serves the Box gRPC service defined in proto/box.proto.
+// It speaks the gRPC protocol over the HTTP/2 support of net/http,
+// decoding and encoding the messages with protoFields,
+// so it needs no generated code.
+func handleGRPC(w http.ResponseWriter, r *http.Request) {
+	if r.ProtoMajor != 2 || !strings.HasPrefix(r.Header.Get("Content-Type"), "application/grpc") {
+		httpStatusError(w, http.StatusUnsupportedMediaType, "gRPC requires HTTP/2 and content type application/grpc")
+		return
+	}
+	w.Header().Set("Content-Type", "application/grpc")
+	w.Header().Set("Trailer", "Grpc-Status, Grpc-Message")
+	msgs, err := readGRPCMessages(r.Body)
+	if err != nil {
+		grpcStatus(w, grpcCode(err), err)
+		return
+	}
+	var plot func([][]byte, io.Writer) error
+	switch r.URL.Path {
+	case "/box.v1.Box/Plot":
+		plot = grpcPlot
+	case "/box.v1.Box/StreamSamples":
+		plot = grpcStreamSamples
+	default:
+		grpcStatus(w, grpcUnimplemented, fmt.Errorf("unknown method %s", r.URL.Path))
+		return
+	}
+	var out bytes.Buffer
+	if err := grpcRender(plot, msgs, &out); err != nil {
+		grpcStatus(w, grpcCode(err), err)
+		return
+	}
+	// PlotResponse has the output in field 1.
+	writeGRPCMessage(w, protoAppendBytes(nil, 1, out.Bytes()))
+	grpcStatus(w, grpcOK, nil)
+}
+
+// GrpcRender calls plot holding renderMu,
+// which is released even if plot panics.
+func grpcRender(plot func([][]byte, io.Writer) error, msgs [][]byte, out io.Writer) error {
+	renderMu.Lock()
+	defer renderMu.Unlock()
+	return plot(msgs, out)
+}
+
+// GrpcPlot plots the input of a PlotRequest.
+// The caller must hold renderMu.
+func grpcPlot(msgs [][]byte, out io.Writer) error {
+	if len(msgs) != 1 {
+		return fmt.Errorf("expected 1 request message, got %d", len(msgs))
+	}
+	var input []byte
+	flags := make(url.Values)
+	err := protoFields(msgs[0], func(field int, v protoValue) error {
+		switch field {
+		case 1:
+			input = v.bytes
+		case 2:
+			return protoMapEntry(v.bytes, flags)
+		}
+		return nil
+	})
+	if err == nil {
+		err = setQueryFlags(flags)
+	}
+	if err != nil {
+		return err
+	}
+	return run(bytes.NewReader(input), out)
+}
+
+// GrpcStreamSamples plots the data sets of a stream of Samples messages,
+// checking the flags and data sets as run does for the input of a PlotRequest.
+// The caller must hold renderMu.
+func grpcStreamSamples(msgs [][]byte, out io.Writer) error {
+	start := time.Now()
+	c := &collection{values: make(map[string][]float64)}
+	flags := make(url.Values)
+	for _, m := range msgs {
+		var name string
+		var vs []float64
+		err := protoFields(m, func(field int, v protoValue) error {
+			switch field {
+			case 1:
+				return protoMapEntry(v.bytes, flags)
+			case 2:
+				name = string(v.bytes)
+			case 3:
+				if v.wire == 1 {
+					vs = append(vs, v.float())
+					return nil
+				}
+				if len(v.bytes)%8 != 0 {
+					return errors.New("bad packed values")
+				}
+				for i := 0; i < len(v.bytes); i += 8 {
+					vs = append(vs, math.Float64frombits(binary.LittleEndian.Uint64(v.bytes[i:])))
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := c.checkAppend([]box{{name: name, values: vs}}); err != nil {
+			return err
+		}
+		if _, ok := c.values[name]; !ok {
+			c.names = append(c.names, name)
+		}
+		c.values[name] = append(c.values[name], vs...)
+	}
+	if err := setQueryFlags(flags); err != nil {
+		return err
+	}
+	if err := prepareRun(); err != nil {
+		return err
+	}
+	return runBoxes(c.boxes(), nil, out, start)
+}
+
+// ProtoMapEntry decodes an entry of a map<string, string> field into vs.
+func protoMapEntry(data []byte, vs url.Values) error {
+	var k, v string
+	err := protoFields(data, func(field int, pv protoValue) error {
+		switch field {
+		case 1:
+			k = string(pv.bytes)
+		case 2:
+			v = string(pv.bytes)
+		}
+		return nil
+	})
+	vs.Add(k, v)
+	return err
+}
+
+// ProtoAppendBytes appends a length-delimited field to a protocol buffer message.
+func protoAppendBytes(buf []byte, field int, data []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|2)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// ReadGRPCMessages reads the length-prefixed messages of a gRPC request body.
+// Compressed messages are not supported,
+// and the messages together may be no larger than the body limit.
+func readGRPCMessages(r io.Reader) ([][]byte, error) {
+	var msgs [][]byte
+	var total int64
+	for {
+		var hdr [5]byte
+		if _, err := io.ReadFull(r, hdr[:]); err == io.EOF {
+			return msgs, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if hdr[0] != 0 {
+			return nil, errors.New("compressed gRPC messages are not supported")
+		}
+		size := int64(binary.BigEndian.Uint32(hdr[1:]))
+		if total += size; limits.body > 0 && total > limits.body {
+			return nil, &limitError{limit: "max-body", max: limits.body}
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(r, msg); err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, msg)
+	}
+}
+
+// WriteGRPCMessage writes a length-prefixed, uncompressed gRPC message.
+func writeGRPCMessage(w io.Writer, msg []byte) {
+	var hdr [5]byte
+	binary.BigEndian.PutUint32(hdr[1:], uint32(len(msg)))
+	w.Write(hdr[:])
+	w.Write(msg)
+}
+
+// GrpcCode returns the gRPC status code of an error:
+// RESOURCE_EXHAUSTED for input beyond a limit,
+// and INVALID_ARGUMENT otherwise.
+func grpcCode(err error) int {
+	var le *limitError
+	if errors.As(err, &le) {
+		return grpcExhausted
+	}
+	return grpcInvalidArgument
+}
+
+// GrpcStatus sets the gRPC status trailers of a response.
+func grpcStatus(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Grpc-Status", fmt.Sprint(code))
+	if err != nil {
+		w.Header().Set("Grpc-Message", url.PathEscape(err.Error()))
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/eaburns/box/proto/boxpb"
+)
+
+// NewGRPCTestClient returns a boxpb client of a test server of handleGRPC over HTTP/2.
+func newGRPCTestClient(t *testing.T) *boxpb.Client {
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(handleGRPC))
+	ts.EnableHTTP2 = true
+	ts.StartTLS()
+	t.Cleanup(ts.Close)
+	return &boxpb.Client{URL: ts.URL, HTTP: ts.Client()}
+}
+
+func TestGRPCPlot(t *testing.T) {
+	defer resetFlags()
+	c := newGRPCTestClient(t)
+	resp, err := c.Plot(context.Background(), &boxpb.PlotRequest{
+		Input: []byte("a 1 2 3 4"),
+		Flags: map[string]string{"o": "svg", "t": "Latency"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out := string(resp.Output); !strings.HasPrefix(out, "<svg") || !strings.Contains(out, "Latency") {
+		t.Errorf("output is not an SVG plot titled Latency:\n%s", out)
+	}
+	_, err = c.Plot(context.Background(), &boxpb.PlotRequest{
+		Input: []byte("a 1 2 3 4"),
+		Flags: map[string]string{"manifest": "/tmp/m"},
+	})
+	var e *boxpb.Error
+	if !errors.As(err, &e) || e.Code != grpcInvalidArgument {
+		t.Errorf("-manifest: error %v, want gRPC status %d", err, grpcInvalidArgument)
+	}
+}
+
+func TestGRPCStreamSamples(t *testing.T) {
+	defer resetFlags()
+	c := newGRPCTestClient(t)
+	s, err := c.StreamSamples(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*boxpb.Samples{
+		{Flags: map[string]string{"stats": "true"}, Name: "a", Values: []float64{1, 2}},
+		{Name: "b", Values: []float64{5}},
+		{Name: "a", Values: []float64{3}},
+	} {
+		if err := s.Send(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	resp, err := s.CloseAndRecv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(resp.Output)), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[1], "a\t3\t") || !strings.HasPrefix(lines[2], "b\t1\t") {
+		t.Errorf("-stats output is not of a with 3 values and b with 1:\n%s", resp.Output)
+	}
+}
+
+func TestGRPCStreamSamplesEmpty(t *testing.T) {
+	defer resetFlags()
+	c := newGRPCTestClient(t)
+	s, err := c.StreamSamples(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(&boxpb.Samples{Name: "a"}); err != nil {
+		t.Fatal(err)
+	}
+	_, err = s.CloseAndRecv()
+	var e *boxpb.Error
+	if !errors.As(err, &e) || e.Code != grpcInvalidArgument {
+		t.Errorf("no values: error %v, want gRPC status %d", err, grpcInvalidArgument)
+	}
+}
+
+func TestGRPCRenderPanic(t *testing.T) {
+	func() {
+		defer func() { recover() }()
+		grpcRender(func([][]byte, io.Writer) error { panic("plot") }, nil, io.Discard)
+	}()
+	if !renderMu.TryLock() {
+		t.Fatal("renderMu is held after a panicking plot")
+	}
+	renderMu.Unlock()
+}
@@ -0,0 +1,195 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Cookies identifying HdrHistogram V2 encodings,
+// with the word-size bits masked out.
+const (
+	hdrV2Cookie           = 0x1c849303
+	hdrV2CompressedCookie = 0x1c849304
+)
+
+// ReadHDR reads HdrHistogram output.
+// Two forms are understood, and may be mixed:
+// percentile distribution tables, as printed by
+// outputPercentileDistribution, wrk2, and JMH,
+// with rows of the form <value> <percentile> <total count> [<1/(1-percentile)>],
+// separated by either white space or commas;
+// and histogram interval logs, as written by HistogramLogWriter,
+// with lines of the form [Tag=<tag>,]<start>,<interval>,<max>,<histogram>.
+//
+// Each percentile table becomes a box named hdr1, hdr2, and so on.
+// All intervals of a log with the same tag are merged into one box
+// named by the tag, or hdr for untagged intervals.
+func readHDR(r io.Reader) ([]box, error) {
+	var boxes []box
+	var table *digest
+	var total float64
+	endTable := func() {
+		if table != nil {
+			sortCentroids(table)
+			boxes = append(boxes, digestBox(fmt.Sprintf("hdr%d", len(boxes)+1), table))
+			table = nil
+		}
+	}
+	logs := make(map[string]*digest)
+	var tags []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fs := strings.FieldsFunc(line, func(r rune) bool { return r == ',' || unicode.IsSpace(r) })
+		if len(fs) > 0 && strings.HasPrefix(fs[len(fs)-1], "HIST") {
+			endTable()
+			tag := "hdr"
+			if strings.HasPrefix(fs[0], "Tag=") {
+				tag = strings.TrimPrefix(fs[0], "Tag=")
+			}
+			cs, err := decodeHistogram(fs[len(fs)-1])
+			if err != nil {
+				return nil, fmt.Errorf("%s: %v", tag, err)
+			}
+			d := logs[tag]
+			if d == nil {
+				d = &digest{}
+				logs[tag] = d
+				tags = append(tags, tag)
+			}
+			d.centroids = append(d.centroids, cs...)
+			continue
+		}
+		if v, count, ok := percentileRow(fs); ok {
+			if table == nil {
+				table = &digest{}
+				total = 0
+			}
+			if count > total {
+				table.centroids = append(table.centroids, centroid{mean: v, weight: count - total})
+				total = count
+			}
+			continue
+		}
+		endTable()
+	}
+	endTable()
+	for _, tag := range tags {
+		d := logs[tag]
+		sortCentroids(d)
+		boxes = append(boxes, digestBox(tag, d))
+	}
+	return boxes, scanner.Err()
+}
+
+// PercentileRow returns the value and total count of a percentile table row.
+func percentileRow(fs []string) (v, count float64, ok bool) {
+	if len(fs) < 3 || len(fs) > 4 {
+		return 0, 0, false
+	}
+	var vs [3]float64
+	for i := range vs {
+		var err error
+		if vs[i], err = strconv.ParseFloat(fs[i], 64); err != nil {
+			return 0, 0, false
+		}
+	}
+	return vs[0], vs[2], true
+}
+
+// SortCentroids sorts the centroids of a digest by their means
+// and sets its minimum and maximum from the extreme centroids.
+func sortCentroids(d *digest) {
+	sort.Slice(d.centroids, func(i, j int) bool { return d.centroids[i].mean < d.centroids[j].mean })
+	if len(d.centroids) > 0 {
+		d.min, d.max = d.centroids[0].mean, d.centroids[len(d.centroids)-1].mean
+	}
+}
+
+// DecodeHistogram decodes a base64, compressed, V2-encoded HdrHistogram
+// into centroids at the middle of each non-empty bucket.
+func decodeHistogram(s string) ([]centroid, error) {
+	data, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 8 || binary.BigEndian.Uint32(data)&^0xf0 != hdrV2CompressedCookie {
+		return nil, errors.New("not a compressed V2 histogram")
+	}
+	z, err := zlib.NewReader(bytes.NewReader(data[8:]))
+	if err != nil {
+		return nil, err
+	}
+	data, err = ioutil.ReadAll(z)
+	if err != nil {
+		return nil, err
+	}
+	r := bytes.NewReader(data)
+	var hdr struct {
+		Cookie, PayloadLength, IndexOffset, Digits int32
+		Lowest, Highest                            int64
+		Ratio                                      float64
+	}
+	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
+		return nil, err
+	}
+	if uint32(hdr.Cookie)&^0xf0 != hdrV2Cookie {
+		return nil, errors.New("not a V2 histogram")
+	}
+	if hdr.Digits < 0 || hdr.Digits > 5 || hdr.Lowest < 1 {
+		return nil, errors.New("bad histogram header")
+	}
+	payload := data[len(data)-r.Len():]
+	if int(hdr.PayloadLength) < len(payload) {
+		payload = payload[:hdr.PayloadLength]
+	}
+
+	ratio := hdr.Ratio
+	if ratio == 0 {
+		ratio = 1
+	}
+	unit := uint(math.Floor(math.Log2(float64(hdr.Lowest))))
+	subBuckets := uint(math.Ceil(math.Log2(2 * math.Pow10(int(hdr.Digits)))))
+	halfMag := subBuckets - 1
+	halfCount := int64(1) << halfMag
+	var cs []centroid
+	var index int64
+	for len(payload) > 0 {
+		c, n := binary.Varint(payload)
+		if n <= 0 {
+			return nil, errors.New("bad histogram counts")
+		}
+		payload = payload[n:]
+		if c < 0 {
+			index += -c
+			continue
+		}
+		if c > 0 {
+			bucket := (index >> halfMag) - 1
+			sub := index&(halfCount-1) + halfCount
+			if bucket < 0 {
+				sub -= halfCount
+				bucket = 0
+			}
+			shift := uint(bucket) + unit
+			v := float64(sub<<shift + int64(1)<<shift>>1)
+			cs = append(cs, centroid{mean: v * ratio, weight: float64(c)})
+		}
+		index++
+	}
+	return cs, nil
+}
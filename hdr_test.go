@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// HdrBlob returns a base64, compressed, V2-encoded HdrHistogram
+// with 3 significant digits and a lowest trackable value of 1,
+// so the value of each count index below 2048 is the index itself.
+// Counts maps the value to its count.
+func hdrBlob(cookie uint32, digits int32, counts map[int64]int64) string {
+	var payload []byte
+	var index int64
+	for v := int64(0); v < 2048; v++ {
+		if counts[v] == 0 {
+			continue
+		}
+		if v > index {
+			payload = binary.AppendVarint(payload, index-v)
+		}
+		payload = binary.AppendVarint(payload, counts[v])
+		index = v + 1
+	}
+	var h bytes.Buffer
+	put := func(v interface{}) { binary.Write(&h, binary.BigEndian, v) }
+	put(cookie)
+	put(int32(len(payload)))
+	put(int32(0)) // normalizing index offset
+	put(digits)
+	put(int64(1))    // lowest trackable value
+	put(int64(3600)) // highest trackable value
+	put(float64(1))  // integer to double value conversion ratio
+	h.Write(payload)
+	var z bytes.Buffer
+	zw := zlib.NewWriter(&z)
+	zw.Write(h.Bytes())
+	zw.Close()
+	var c bytes.Buffer
+	binary.Write(&c, binary.BigEndian, uint32(hdrV2CompressedCookie|0x10))
+	binary.Write(&c, binary.BigEndian, int32(z.Len()))
+	c.Write(z.Bytes())
+	return base64.StdEncoding.EncodeToString(c.Bytes())
+}
+
+// TestReadHDR tests that percentile tables and interval logs
+// decode to boxes with the quantiles of their recorded values.
+func TestReadHDR(t *testing.T) {
+	// Both inputs hold the values 10, 20, 20, and 30.
+	table := `       Value     Percentile TotalCount 1/(1-Percentile)
+
+      10.000 0.000000000000          1           1.00
+      20.000 0.500000000000          3           2.00
+      30.000 1.000000000000          4
+#[Mean    =       20.000, StdDeviation   =        7.071]
+`
+	csvTable := "Value,Percentile,TotalCount,1/(1-Percentile)\n10.000,0.0,1,1.00\n20.000,0.5,3,2.00\n30.000,1.0,4,Infinity\n"
+	log := "#[StartTime: 0.000 (seconds since epoch)]\n" +
+		"\"StartTimestamp\",\"Interval_Length\",\"Interval_Max\",\"Interval_Compressed_Histogram\"\n" +
+		"Tag=a,0.000,1.000,20.000," + hdrBlob(hdrV2Cookie|0x10, 3, map[int64]int64{10: 1, 20: 1}) + "\n" +
+		"Tag=a,1.000,1.000,30.000," + hdrBlob(hdrV2Cookie|0x10, 3, map[int64]int64{20: 1, 30: 1}) + "\n"
+	// The table's centroids at 10, 20, and 30 sit at the middles of their weights, 0.5, 2, and 3.5.
+	tableWant := [5]float64{10, 10 + 10.0/3, 20, 20 + 20.0/3, 30}
+	// The log's intervals each add centroids of weight 1, at 10, 20, 20, and 30.
+	logWant := [5]float64{10, 15, 20, 25, 30}
+	for _, test := range []struct {
+		input string
+		name  string
+		want  [5]float64
+	}{
+		{table, "hdr1", tableWant},
+		{csvTable, "hdr1", tableWant},
+		{log, "a", logWant},
+	} {
+		boxes, err := readHDR(strings.NewReader(test.input))
+		if err != nil {
+			t.Errorf("%q: %v", test.input, err)
+			continue
+		}
+		if len(boxes) != 1 {
+			t.Errorf("%q: got %d boxes, want 1", test.input, len(boxes))
+			continue
+		}
+		b := boxes[0]
+		got := [5]float64{b.min, b.q1, b.q2, b.q3, b.max}
+		for i := range got {
+			if math.Abs(got[i]-test.want[i]) > 1e-9 {
+				t.Errorf("%q: five-number summary %v, want %v", test.input, got, test.want)
+				break
+			}
+		}
+		if b.name != test.name || b.n != 4 {
+			t.Errorf("%q: got box %s of %d values, want %s of 4", test.input, b.name, b.n, test.name)
+		}
+	}
+}
+
+// TestReadHDRMalformed tests that malformed interval log histograms are errors.
+func TestReadHDRMalformed(t *testing.T) {
+	counts := map[int64]int64{10: 1}
+	for _, hist := range []string{
+		"HIST!!!",
+		"HISTAAAA",
+		hdrBlob(0x12345678, 3, counts),
+		hdrBlob(hdrV2Cookie|0x10, 9, counts),
+		base64.StdEncoding.EncodeToString(append(binary.BigEndian.AppendUint32(nil, hdrV2CompressedCookie|0x10), 0, 0, 0, 4, 1, 2, 3, 4)),
+	} {
+		input := "0.000,1.000,10.000," + hist + "\n"
+		if _, err := readHDR(strings.NewReader(input)); err == nil {
+			t.Errorf("readHDR(%q) succeeded, want an error", input)
+		}
+	}
+}
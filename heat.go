@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// MinHeatSpacing is the spacing of the hatching of a box
+// whose median deviates the most from the reference.
+const minHeatSpacing = 0.004
+
+// SetHeat sets the heat of each box, according to the -heat flag,
+// to the distance of its median from the reference median
+// relative to the largest such distance, from 0 to 1.
+// With -heat all, the reference is the median of the medians of every box;
+// otherwise, -heat is a regular expression matching the baseline boxes,
+// and the reference is the median of their medians.
+func setHeat(boxes []box) error {
+	if *heat == "" || len(boxes) == 0 {
+		return nil
+	}
+	var ref []float64
+	if *heat == "all" {
+		for _, b := range boxes {
+			ref = append(ref, b.q2)
+		}
+	} else {
+		re, err := regexp.Compile(*heat)
+		if err != nil {
+			return fmt.Errorf("Bad -heat pattern: %v", err)
+		}
+		for _, b := range boxes {
+			if re.MatchString(b.name) {
+				ref = append(ref, b.q2)
+			}
+		}
+		if len(ref) == 0 {
+			return fmt.Errorf("No baseline data sets match -heat %s", *heat)
+		}
+	}
+	sort.Float64s(ref)
+	m := boxplot.Median(ref)
+	max := 0.0
+	for _, b := range boxes {
+		max = math.Max(max, math.Abs(b.q2-m))
+	}
+	for i := range boxes {
+		if max > 0 {
+			boxes[i].heat = math.Abs(boxes[i].q2-m) / max
+		}
+	}
+	return nil
+}
+
+// DrawHeat hatches a box with horizontal lines,
+// more densely the greater its heat,
+// so that many boxes read as a heat map of their medians.
+// It must be drawn before the outline of the box.
+func drawHeat(cv canvas, heat, x0, y0, x1, y1 float64) {
+	if heat < 0.05 {
+		return
+	}
+	spacing := minHeatSpacing / heat
+	for y := y0 + spacing/2; y < y1; y += spacing {
+		cv.line("heat", x0, y, x1, y)
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// A transposedCanvas draws on another canvas with x and y swapped,
+// so that a panel laid out with vertical boxes, side by side,
+// is drawn with horizontal boxes, one above another, for -horizontal.
+// Texts are not rotated, but are moved and realigned by their role
+// to sit beside the transposed shapes:
+// names to the left of their boxes, value and tick labels below their marks,
+// and the notes of a box listed downward to the right of it.
+type transposedCanvas struct {
+	canvas
+	// Notes is the number of notes drawn so far for the current box,
+	// and noteX is the x coordinate of the first.
+	notes int
+	noteX float64
+}
+
+func (c *transposedCanvas) line(role string, x0, y0, x1, y1 float64) {
+	c.canvas.line(role, y0, x0, y1, x1)
+}
+
+func (c *transposedCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.canvas.box(role, y0, x0, y1, x1)
+}
+
+func (c *transposedCanvas) circle(role string, x, y, r float64) {
+	c.canvas.circle(role, y, x, r)
+}
+
+func (c *transposedCanvas) polyline(role string, xs, ys []float64) {
+	c.canvas.polyline(role, ys, xs)
+}
+
+func (c *transposedCanvas) text(role string, x, y float64, align byte, s string) {
+	x, y = y, x
+	switch role {
+	case "name":
+		align = 'R'
+	case "value", "tick":
+		y -= textHeight / 2
+		align = 'C'
+	case "note":
+		if c.notes == 0 {
+			c.noteX = x
+		}
+		x, y = c.noteX, y-float64(c.notes)*textHeight
+		align = 'L'
+		c.notes++
+	}
+	c.canvas.text(role, x, y, align, s)
+}
+
+func (c *transposedCanvas) group(name string) {
+	c.notes = 0
+	c.canvas.group(name)
+}
+
+// Transpose returns a rectangle with x and y swapped.
+func (r rect) transpose() rect {
+	return rect{x0: r.y0, y0: r.x0, x1: r.y1, y1: r.x1}
+}
+
+// TextWidth returns the width of the longest of the lines of text,
+// at charWidth for each character, as the PNG font draws them,
+// not for each byte of their UTF-8 encoding.
+func textWidth(ss ...string) float64 {
+	w := 0.0
+	for _, s := range ss {
+		w = math.Max(w, float64(utf8.RuneCountInString(s))*charWidth)
+	}
+	return w
+}
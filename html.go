@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"html/template"
+	"io"
+	"math"
+)
+
+// HtmlBox is the statistics of a box embedded in an HTML page.
+type htmlBox struct {
+	Name string     `json:"name"`
+	N    int        `json:"n"`
+	Stat [5]float64 `json:"stat"`
+	Mean float64    `json:"mean"`
+	// Outliers are the values beyond the whiskers.
+	Outliers []float64 `json:"outliers,omitempty"`
+	// IDs are the -id-column identifiers of the outliers, if any.
+	IDs []string `json:"ids,omitempty"`
+	// Meta is the -meta values of the box as name=value pairs, if any.
+	Meta string `json:"meta,omitempty"`
+	Href string `json:"href,omitempty"`
+	// Color and Line are the style of the box, if it is not the default.
+	Color string `json:"color,omitempty"`
+	Line  string `json:"line,omitempty"`
+}
+
+// An htmlLink is a link in the navigation of an HTML page.
+type htmlLink struct {
+	Text, Href string
+}
+
+// WriteHTML writes the boxes as a self-contained HTML page.
+// The page embeds the statistics of each box
+// and draws them as box plots in SVG with a value axis.
+// The value axis can be zoomed with the mouse wheel,
+// panned by dragging, and re-windowed by shift-dragging a brush
+// over a range of values; double-clicking resets it.
+// The value labels are regenerated from the embedded statistics
+// on every change, so no re-run of box is needed.
+func writeHTML(boxes []box, title string, w io.Writer) error {
+	return writeLinkedHTML(boxes, title, nil, nil, w)
+}
+
+// WriteLinkedHTML writes the boxes as an HTML page, as writeHTML,
+// with a line of navigation links above the plot,
+// and with the name of each box in hrefs linking to its page.
+func writeLinkedHTML(boxes []box, title string, nav []htmlLink, hrefs map[string]string, w io.Writer) error {
+	var hs []htmlBox
+	for _, b := range boxes {
+		lo, hi, outliers := b.whiskers()
+		st := styleOf(b.name)
+		hs = append(hs, htmlBox{
+			Name:     b.name,
+			N:        b.n,
+			Stat:     [5]float64{lo, b.q1, b.q2, b.q3, hi},
+			Mean:     b.mean,
+			Href:     hrefs[b.name],
+			Outliers: outliers,
+			IDs:      b.outlierIDs(lo, hi),
+			Meta:     metaString(b),
+			Color:    st.color,
+			Line:     st.line,
+		})
+	}
+	data, err := json.Marshal(hs)
+	if err != nil {
+		return err
+	}
+	return htmlPage.Execute(w, struct {
+		Title     string
+		Nav       []htmlLink
+		Boxes     template.JS
+		Precision int
+		Log       bool
+		Symlog    bool
+		LogFloor  float64
+		LogLinear float64
+		Ticks     []float64
+		Labels    []string
+		Min, Max  *float64
+		Log2      bool
+		Notch     bool
+	}{title, nav, template.JS(data), *precision, *logScale, *logZero == "symlog", logFloor, logLinear, tickValues, tickLabels,
+		optional(rangeMin), optional(rangeMax), *log2Scale, *notched})
+}
+
+// Optional returns a pointer to v, or nil if v is NaN,
+// which the template writes as null.
+func optional(v float64) *float64 {
+	if math.IsNaN(v) {
+		return nil
+	}
+	return &v
+}
+
+var htmlPage = template.Must(template.New("html").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font: 12px sans-serif; margin: 1em; }
+svg { user-select: none; cursor: grab; }
+.box { fill: none; stroke: black; }
+.brush { fill: rgba(0, 0, 255, 0.1); stroke: blue; }
+</style>
+</head>
+<body>
+{{with .Nav}}<p>{{range $i, $l := .}}{{if $i}} › {{end}}{{if $l.Href}}<a href="{{$l.Href}}">{{$l.Text}}</a>{{else}}{{$l.Text}}{{end}}{{end}}</p>
+{{end}}<h3>{{.Title}}</h3>
+<svg id="plot" width="800" height="500"></svg>
+<p>Scroll to zoom, drag to pan, shift-drag to select a range, double-click to reset.</p>
+<script>
+const boxes = {{.Boxes}};
+const precision = {{.Precision}};
+// The -yticks, if any, replace the evenly spaced axis labels.
+const ticks = {{.Ticks}} || [], tickLabels = {{.Labels}} || [];
+// F transforms values to the scale of the plot, and inv transforms them back;
+// the window, zooming, and panning are all on the transformed scale.
+// With -log-zero epsilon, values at or below zero are drawn at logFloor,
+// and with -log-zero symlog, the scale is linear within logLinear of zero.
+const logFloor = {{.LogFloor}}, logLinear = {{.LogLinear}};
+const f = !{{.Log}} ? v => v
+	: {{.Symlog}} ? v => Math.sign(v) * Math.log10(1 + Math.abs(v) / logLinear)
+	: v => Math.log10(Math.max(v, logFloor));
+const inv = !{{.Log}} ? t => t
+	: {{.Symlog}} ? t => Math.sign(t) * logLinear * (Math.pow(10, Math.abs(t)) - 1)
+	: t => Math.pow(10, t);
+const svg = document.getElementById("plot");
+const W = 800, H = 500, left = 60, bottom = 30, top = 10;
+const ns = "http://www.w3.org/2000/svg";
+let win = [f(Math.min(...boxes.map(b => Math.min(b.stat[0], ...(b.outliers || []))))),
+	f(Math.max(...boxes.map(b => Math.max(b.stat[4], ...(b.outliers || [])))))];
+// The -ymin and -ymax, if any, pin the initial window.
+if ({{.Min}} !== null) win[0] = f({{.Min}});
+if ({{.Max}} !== null) win[1] = f({{.Max}});
+if (win[0] === win[1]) { win[0] -= 1; win[1] += 1; }
+const home = win.slice();
+const dashes = {dashed: "6 4", dotted: "2 3"};
+
+function fmt(v) { return precision < 0 ? String(v) : Number(v.toPrecision(precision)).toString(); }
+function py(t) { return top + (H - top - bottom) * (win[1] - t) / (win[1] - win[0]); }
+function y(v) { return py(f(v)); }
+function value(p) { return win[1] - (p - top) / (H - top - bottom) * (win[1] - win[0]); }
+function el(name, attrs, text) {
+	const e = document.createElementNS(ns, name);
+	for (const k in attrs) e.setAttribute(k, attrs[k]);
+	if (text !== undefined) e.textContent = text;
+	svg.appendChild(e);
+	return e;
+}
+
+function draw() {
+	svg.innerHTML = "";
+	el("line", {x1: left, y1: top, x2: left, y2: H - bottom, stroke: "black"});
+	if (ticks.length > 0) {
+		ticks.forEach((v, i) => {
+			if (f(v) >= win[0] && f(v) <= win[1]) el("text", {x: left - 4, y: y(v) + 4, "text-anchor": "end"}, tickLabels[i]);
+		});
+	} else if ({{.Log2}} && !{{.Symlog}}) {
+		// Label powers of two, every so many doublings, with binary suffixes.
+		const lo = Math.ceil(Math.log2(inv(win[0]))), hi = Math.floor(Math.log2(inv(win[1])));
+		const every = Math.max(1, Math.ceil((hi - lo + 1) / 10));
+		for (let e = lo; e <= hi; e++) {
+			if (e % every !== 0) continue;
+			const v = Math.pow(2, e), i = Math.min(Math.floor(e / 10), 6);
+			const label = e < 10 ? fmt(v) : Math.pow(2, e - 10 * i) + ["", "Ki", "Mi", "Gi", "Ti", "Pi", "Ei"][i];
+			el("text", {x: left - 4, y: y(v) + 4, "text-anchor": "end"}, label);
+		}
+	} else for (let i = 0; i <= 5; i++) {
+		const t = win[0] + (win[1] - win[0]) * i / 5;
+		el("text", {x: left - 4, y: py(t) + 4, "text-anchor": "end"}, fmt(inv(t)));
+	}
+	const cw = (W - left) / boxes.length;
+	boxes.forEach((b, i) => {
+		const x = left + cw * i + cw / 4, w = cw / 2, c = x + w / 2;
+		const [min, q1, q2, q3, max] = b.stat;
+		const g = el("g", {"clip-path": "url(#area)"});
+		const add = (name, attrs) => {
+			const e = g.appendChild(el(name, attrs));
+			if (b.color) e.style[name === "text" ? "fill" : "stroke"] = b.color;
+			if (b.line && name !== "text") e.style.strokeDasharray = dashes[b.line];
+			return e;
+		};
+		let shape;
+		if ({{.Notch}} && b.n > 0) {
+			// Notch the box at the median ± 1.57·IQR/√n, within the box.
+			const d = 1.57 * (q3 - q1) / Math.sqrt(b.n), lo = y(Math.max(q1, q2 - d)), hi = y(Math.min(q3, q2 + d)), m = w * 0.2;
+			const pts = [[x, y(q1)], [x + w, y(q1)], [x + w, lo], [x + w - m, y(q2)], [x + w, hi], [x + w, y(q3)],
+				[x, y(q3)], [x, hi], [x + m, y(q2)], [x, lo]];
+			shape = add("polygon", {class: "box", points: pts.map(p => p.join(",")).join(" ")});
+			add("line", {x1: x + m, y1: y(q2), x2: x + w - m, y2: y(q2), stroke: "black", "stroke-width": 2});
+		} else {
+			shape = add("rect", {class: "box", x: x, y: y(q3), width: w, height: Math.max(0, y(q1) - y(q3))});
+			add("line", {x1: x, y1: y(q2), x2: x + w, y2: y(q2), stroke: "black", "stroke-width": 2});
+		}
+		if (b.meta) shape.appendChild(el("title", {}, b.meta));
+		add("line", {x1: c, y1: y(q1), x2: c, y2: y(min), stroke: "black"});
+		add("line", {x1: c, y1: y(q3), x2: c, y2: y(max), stroke: "black"});
+		add("line", {x1: c - w / 4, y1: y(min), x2: c + w / 4, y2: y(min), stroke: "black"});
+		add("line", {x1: c - w / 4, y1: y(max), x2: c + w / 4, y2: y(max), stroke: "black"});
+		(b.outliers || []).forEach((v, i) => {
+			const o = add("circle", {cx: c, cy: y(v), r: 3, fill: "none", stroke: "black"});
+			if (b.ids && b.ids[i]) o.appendChild(el("title", {}, b.ids[i] + ": " + fmt(v)));
+		});
+		for (const v of b.stat) {
+			if (f(v) >= win[0] && f(v) <= win[1]) add("text", {x: x - 2, y: y(v) + 4, "text-anchor": "end"}).textContent = fmt(v);
+		}
+		const label = el("text", {x: c, y: H - 10, "text-anchor": "middle"}, b.name + " (n=" + b.n + ")");
+		if (b.href) {
+			const a = el("a", {href: b.href});
+			a.appendChild(label);
+			label.setAttribute("fill", "blue");
+		}
+	});
+	const defs = el("defs", {});
+	const clip = document.createElementNS(ns, "clipPath");
+	clip.setAttribute("id", "area");
+	const r = document.createElementNS(ns, "rect");
+	for (const [k, v] of Object.entries({x: left, y: top, width: W - left, height: H - top - bottom})) r.setAttribute(k, v);
+	clip.appendChild(r);
+	defs.appendChild(clip);
+}
+
+svg.addEventListener("wheel", e => {
+	e.preventDefault();
+	const v = value(e.offsetY), k = Math.exp(e.deltaY * 0.001);
+	win = [v - (v - win[0]) * k, v + (win[1] - v) * k];
+	draw();
+});
+let drag = null;
+svg.addEventListener("mousedown", e => {
+	// Leave clicks on links to the links.
+	if (e.target.closest("a")) return;
+	drag = {y: e.offsetY, win: win.slice(), brush: e.shiftKey};
+});
+svg.addEventListener("mousemove", e => {
+	if (!drag) return;
+	if (drag.brush) {
+		draw();
+		el("rect", {class: "brush", x: left, y: Math.min(drag.y, e.offsetY), width: W - left, height: Math.abs(e.offsetY - drag.y)});
+		return;
+	}
+	const dv = (e.offsetY - drag.y) / (H - top - bottom) * (drag.win[1] - drag.win[0]);
+	win = [drag.win[0] + dv, drag.win[1] + dv];
+	draw();
+});
+svg.addEventListener("mouseup", e => {
+	if (!drag) return;
+	if (drag.brush && Math.abs(e.offsetY - drag.y) > 2) {
+		const a = value(drag.y), b = value(e.offsetY);
+		win = [Math.min(a, b), Math.max(a, b)];
+	}
+	drag = null;
+	draw();
+});
+svg.addEventListener("dblclick", () => { win = home.slice(); draw(); });
+draw();
+</script>
+</body>
+</html>
+`))
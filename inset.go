@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+)
+
+// InsetWidth is the fraction of the width of a figure
+// taken by the -inset panel.
+const insetWidth = 1.0 / 3
+
+// CheckInset returns an error if the -inset pattern is bad,
+// if it matches none of the boxes, or if it is used with -matrix or -horizontal.
+func checkInset(boxes []box) error {
+	if *inset == "" {
+		return nil
+	}
+	if *matrix || *horizontal {
+		return fmt.Errorf("-inset cannot be used with -matrix or -horizontal")
+	}
+	if _, err := insetBoxes(boxes); err != nil {
+		return err
+	}
+	return nil
+}
+
+// InsetBoxes returns the boxes whose names match the -inset pattern.
+func insetBoxes(boxes []box) ([]box, error) {
+	re, err := regexp.Compile(*inset)
+	if err != nil {
+		return nil, fmt.Errorf("Bad -inset pattern: %v", err)
+	}
+	var in []box
+	for _, b := range boxes {
+		if re.MatchString(b.name) {
+			in = append(in, b)
+		}
+	}
+	if len(in) == 0 {
+		return nil, fmt.Errorf("No data sets match -inset %s", *inset)
+	}
+	return in, nil
+}
+
+// LayoutInset lays out the main panel of all of the boxes
+// in the free space of a layout,
+// and to its right a framed inset panel of the boxes matching -inset,
+// with a scale of their own.
+func (f *figure) layoutInset(boxes []box, l layout) {
+	in, err := insetBoxes(boxes)
+	if err != nil {
+		// Reported by checkInset before drawing.
+		f.panels = []panel{{r: l.free, boxes: boxes}}
+		return
+	}
+	r := l.right(insetWidth * (l.free.x1 - l.free.x0))
+	r.x0 += 2 * charWidth
+	r.x1 -= charWidth
+	r.y1 -= textHeight
+	f.panels = []panel{
+		{r: l.free, boxes: boxes},
+		{r: r, frame: true, inset: true, boxes: in},
+	}
+}
+
+// DrawZoom draws the indicator of an inset panel on the main panel:
+// a rectangle around the columns of the inset boxes
+// spanning the range of values of the inset,
+// and lines from its corners to those of the inset panel.
+func drawZoom(cv canvas, main panel, cols []column, in panel) {
+	names := make(map[string]bool)
+	for _, b := range in.boxes {
+		names[b.name] = true
+	}
+	var x0, x1 float64
+	var tr func(float64) float64
+	for i, b := range main.boxes {
+		if !names[b.name] {
+			continue
+		}
+		col := cols[i]
+		if tr == nil || col.x < x0 {
+			x0 = col.x
+		}
+		if tr == nil || col.x+col.width > x1 {
+			x1 = col.x + col.width
+		}
+		tr = col.tr
+	}
+	if tr == nil {
+		return
+	}
+	pad := charWidth / 2
+	x0, x1 = x0-pad, x1+pad
+	y0, y1 := tr(in.min), tr(in.max)
+	cv.box("zoom", x0, y0, x1, y1)
+	cv.line("zoom", x1, y1, in.r.x0, in.r.y1)
+	cv.line("zoom", x1, y0, in.r.x0, in.r.y0)
+}
@@ -0,0 +1,470 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"strconv"
+)
+
+// The Kafka API keys and versions spoken by subscribeKafka.
+// The versions are those of Kafka 1.0, which later brokers, up to 4.0 at least, still accept.
+const (
+	kafkaFetch       = 1
+	kafkaListOffsets = 2
+	kafkaMetadata    = 3
+
+	kafkaFetchVersion       = 4
+	kafkaListOffsetsVersion = 1
+	kafkaMetadataVersion    = 4
+)
+
+// KafkaLatest and kafkaEarliest are the timestamps of ListOffsets
+// that ask for the offset after the last message and of the first message.
+const (
+	kafkaLatest   = -1
+	kafkaEarliest = -2
+)
+
+// SubscribeKafka consumes the messages of every partition of a Kafka topic,
+// beginning after the last message or, if earliest, at the first,
+// and sends the value of each message received.
+// It speaks the Kafka protocol directly, without a consumer group,
+// so it commits no offsets, and it fails if the leader of a partition changes.
+// Record batches may be uncompressed or compressed with gzip;
+// other compression codecs are not supported.
+func subscribeKafka(broker, topic string, earliest bool, msgs chan<- []byte) error {
+	bootstrap, err := dialKafka(broker)
+	if err != nil {
+		return err
+	}
+	leaders, err := bootstrap.leaders(topic)
+	bootstrap.close()
+	if err != nil {
+		return err
+	}
+	errs := make(chan error, len(leaders))
+	for addr, parts := range leaders {
+		addr, parts := addr, parts
+		go func() { errs <- consumeKafkaPartitions(addr, topic, parts, earliest, msgs) }()
+	}
+	// A fetch loop ends only with an error.
+	return <-errs
+}
+
+// ConsumeKafkaPartitions fetches the messages of partitions of a topic from their leader.
+func consumeKafkaPartitions(addr, topic string, parts []int32, earliest bool, msgs chan<- []byte) error {
+	c, err := dialKafka(addr)
+	if err != nil {
+		return err
+	}
+	defer c.close()
+	timestamp := int64(kafkaLatest)
+	if earliest {
+		timestamp = kafkaEarliest
+	}
+	offsets, err := c.listOffsets(topic, parts, timestamp)
+	if err != nil {
+		return err
+	}
+	for {
+		if err := c.fetch(topic, offsets, msgs); err != nil {
+			return err
+		}
+	}
+}
+
+// A kafkaConn is a connection to a Kafka broker.
+type kafkaConn struct {
+	conn net.Conn
+	r    *bufio.Reader
+	// Correlation is the correlation ID of the last request.
+	correlation int32
+}
+
+// DialKafka connects to a broker at host:port.
+func dialKafka(addr string) (*kafkaConn, error) {
+	conn, err := net.Dial("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	return &kafkaConn{conn: conn, r: bufio.NewReader(conn)}, nil
+}
+
+func (c *kafkaConn) close() { c.conn.Close() }
+
+// Call sends a request with a body and returns the body of its response.
+func (c *kafkaConn) call(api, version int16, body []byte) (*kafkaDecoder, error) {
+	c.correlation++
+	var e kafkaEncoder
+	e.int32(0) // The size, set below.
+	e.int16(api)
+	e.int16(version)
+	e.int32(c.correlation)
+	e.string("box")
+	e.buf = append(e.buf, body...)
+	binary.BigEndian.PutUint32(e.buf, uint32(len(e.buf)-4))
+	if _, err := c.conn.Write(e.buf); err != nil {
+		return nil, err
+	}
+	var hdr [8]byte
+	if _, err := io.ReadFull(c.r, hdr[:]); err != nil {
+		return nil, err
+	}
+	size := int32(binary.BigEndian.Uint32(hdr[:4]))
+	if corr := int32(binary.BigEndian.Uint32(hdr[4:])); corr != c.correlation || size < 4 {
+		return nil, fmt.Errorf("bad Kafka response header: size %d, correlation ID %d", size, corr)
+	}
+	resp := make([]byte, size-4)
+	if _, err := io.ReadFull(c.r, resp); err != nil {
+		return nil, err
+	}
+	return &kafkaDecoder{b: resp}, nil
+}
+
+// Leaders returns the partitions of a topic by the host:port of their leaders.
+func (c *kafkaConn) leaders(topic string) (map[string][]int32, error) {
+	var e kafkaEncoder
+	e.int32(1)
+	e.string(topic)
+	e.int8(0) // allow_auto_topic_creation
+	d, err := c.call(kafkaMetadata, kafkaMetadataVersion, e.buf)
+	if err != nil {
+		return nil, err
+	}
+	d.int32() // throttle_time_ms
+	brokers := make(map[int32]string)
+	for i, n := 0, d.array(); i < n; i++ {
+		id, host, port := d.int32(), d.string(), d.int32()
+		d.string() // rack
+		brokers[id] = net.JoinHostPort(host, strconv.Itoa(int(port)))
+	}
+	d.string() // cluster_id
+	d.int32()  // controller_id
+	leaders := make(map[string][]int32)
+	for i, n := 0, d.array(); i < n; i++ {
+		code, name := d.int16(), d.string()
+		d.int8() // is_internal
+		if code != 0 {
+			return nil, fmt.Errorf("Kafka topic %s: error code %d", name, code)
+		}
+		for j, m := 0, d.array(); j < m; j++ {
+			code, part, leader := d.int16(), d.int32(), d.int32()
+			d.skipArray(4) // replica_nodes
+			d.skipArray(4) // isr_nodes
+			addr, ok := brokers[leader]
+			if code != 0 || !ok {
+				return nil, fmt.Errorf("Kafka topic %s partition %d: error code %d, leader %d", name, part, code, leader)
+			}
+			leaders[addr] = append(leaders[addr], part)
+		}
+	}
+	if d.err != nil {
+		return nil, d.err
+	}
+	if len(leaders) == 0 {
+		return nil, fmt.Errorf("Kafka topic %s has no partitions", topic)
+	}
+	return leaders, nil
+}
+
+// ListOffsets returns the offsets of partitions of a topic at a timestamp,
+// kafkaLatest or kafkaEarliest.
+func (c *kafkaConn) listOffsets(topic string, parts []int32, timestamp int64) (map[int32]int64, error) {
+	var e kafkaEncoder
+	e.int32(-1) // replica_id
+	e.int32(1)
+	e.string(topic)
+	e.int32(int32(len(parts)))
+	for _, p := range parts {
+		e.int32(p)
+		e.int64(timestamp)
+	}
+	d, err := c.call(kafkaListOffsets, kafkaListOffsetsVersion, e.buf)
+	if err != nil {
+		return nil, err
+	}
+	offsets := make(map[int32]int64)
+	for i, n := 0, d.array(); i < n; i++ {
+		d.string() // name
+		for j, m := 0, d.array(); j < m; j++ {
+			part, code := d.int32(), d.int16()
+			d.int64() // timestamp
+			offset := d.int64()
+			if code != 0 {
+				return nil, fmt.Errorf("Kafka topic %s partition %d: error code %d", topic, part, code)
+			}
+			offsets[part] = offset
+		}
+	}
+	return offsets, d.err
+}
+
+// Fetch fetches the messages after the offsets of partitions of a topic,
+// waiting up to half a second for some, sends their values,
+// and advances the offsets past them.
+func (c *kafkaConn) fetch(topic string, offsets map[int32]int64, msgs chan<- []byte) error {
+	const maxBytes = 1 << 20
+	var e kafkaEncoder
+	e.int32(-1)  // replica_id
+	e.int32(500) // max_wait_ms
+	e.int32(1)   // min_bytes
+	e.int32(maxBytes)
+	e.int8(0) // isolation_level: read uncommitted
+	e.int32(1)
+	e.string(topic)
+	e.int32(int32(len(offsets)))
+	for p, off := range offsets {
+		e.int32(p)
+		e.int64(off)
+		e.int32(maxBytes)
+	}
+	d, err := c.call(kafkaFetch, kafkaFetchVersion, e.buf)
+	if err != nil {
+		return err
+	}
+	d.int32() // throttle_time_ms
+	for i, n := 0, d.array(); i < n; i++ {
+		d.string() // topic
+		for j, m := 0, d.array(); j < m; j++ {
+			part, code := d.int32(), d.int16()
+			d.int64()       // high_watermark
+			d.int64()       // last_stable_offset
+			d.skipArray(16) // aborted_transactions
+			records := d.bytes()
+			if d.err != nil {
+				return d.err
+			}
+			if code != 0 {
+				return fmt.Errorf("Kafka topic %s partition %d: error code %d", topic, part, code)
+			}
+			next, err := readKafkaRecords(records, offsets[part], msgs)
+			if err != nil {
+				return fmt.Errorf("Kafka topic %s partition %d: %v", topic, part, err)
+			}
+			offsets[part] = next
+		}
+	}
+	return d.err
+}
+
+// ReadKafkaRecords sends the values of the records of a fetch response,
+// a sequence of record batches, at or after an offset,
+// and returns the offset after the last of them.
+// A batch cut off at the end of the records is left for the next fetch,
+// but the broker returns the first batch whole, even if it is larger than
+// the fetch asked for, so a cut-off first batch is an error,
+// as is a batch with a negative size.
+func readKafkaRecords(data []byte, offset int64, msgs chan<- []byte) (int64, error) {
+	for first := true; len(data) >= 12; first = false {
+		d := &kafkaDecoder{b: data}
+		base, size := d.int64(), d.int32()
+		if size < 0 || first && int(size) > len(d.b) {
+			return 0, fmt.Errorf("bad record batch size %d with %d bytes left", size, len(d.b))
+		}
+		if int(size) > len(d.b) {
+			break
+		}
+		batch := &kafkaDecoder{b: d.b[:size]}
+		data = d.b[size:]
+		batch.int32() // partition_leader_epoch
+		if magic := batch.int8(); magic != 2 {
+			return 0, fmt.Errorf("unsupported message format %d", magic)
+		}
+		batch.int32() // crc
+		attrs := batch.int16()
+		last := batch.int32()
+		batch.int64() // first_timestamp
+		batch.int64() // max_timestamp
+		batch.int64() // producer_id
+		batch.int16() // producer_epoch
+		batch.int32() // base_sequence
+		n := batch.int32()
+		if batch.err != nil {
+			return 0, batch.err
+		}
+		next := base + int64(last) + 1
+		// Control batches mark the ends of transactions and have no messages.
+		if attrs&0x20 != 0 || next <= offset {
+			offset = maxInt64(offset, next)
+			continue
+		}
+		records := batch.b
+		switch codec := attrs & 7; codec {
+		case 0:
+		case 1:
+			zr, err := gzip.NewReader(bytes.NewReader(records))
+			if err != nil {
+				return 0, err
+			}
+			if records, err = ioutil.ReadAll(zr); err != nil {
+				return 0, err
+			}
+		default:
+			return 0, fmt.Errorf("unsupported compression codec %d; only gzip is supported", codec)
+		}
+		rd := &kafkaDecoder{b: records}
+		for i := int32(0); i < n; i++ {
+			rd.varint() // length
+			rd.int8()   // attributes
+			rd.varint() // timestamp_delta
+			delta := rd.varint()
+			rd.skip(int(rd.varint())) // key
+			value := rd.varbytes()
+			for h, nh := int64(0), rd.varint(); h < nh; h++ {
+				rd.skip(int(rd.varint())) // header key
+				rd.skip(int(rd.varint())) // header value
+			}
+			if rd.err != nil {
+				return 0, rd.err
+			}
+			if base+delta >= offset && value != nil {
+				msgs <- value
+			}
+		}
+		offset = next
+	}
+	return offset, nil
+}
+
+func maxInt64(a, b int64) int64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// A kafkaEncoder appends the big-endian fields of a Kafka request.
+type kafkaEncoder struct {
+	buf []byte
+}
+
+func (e *kafkaEncoder) int8(v int8) { e.buf = append(e.buf, byte(v)) }
+
+func (e *kafkaEncoder) int16(v int16) { e.buf = binary.BigEndian.AppendUint16(e.buf, uint16(v)) }
+
+func (e *kafkaEncoder) int32(v int32) { e.buf = binary.BigEndian.AppendUint32(e.buf, uint32(v)) }
+
+func (e *kafkaEncoder) int64(v int64) { e.buf = binary.BigEndian.AppendUint64(e.buf, uint64(v)) }
+
+func (e *kafkaEncoder) string(s string) {
+	e.int16(int16(len(s)))
+	e.buf = append(e.buf, s...)
+}
+
+// A kafkaDecoder reads the fields of a Kafka response.
+// After the first error, it reads zeros, and err is the error.
+type kafkaDecoder struct {
+	b   []byte
+	err error
+}
+
+var errKafkaShort = errors.New("short Kafka response")
+
+// Next returns the next n bytes, or nil if there are fewer.
+func (d *kafkaDecoder) next(n int) []byte {
+	if d.err != nil || n < 0 || n > len(d.b) {
+		if d.err == nil {
+			d.err = errKafkaShort
+		}
+		return nil
+	}
+	b := d.b[:n]
+	d.b = d.b[n:]
+	return b
+}
+
+func (d *kafkaDecoder) skip(n int) {
+	if n > 0 {
+		d.next(n)
+	}
+}
+
+func (d *kafkaDecoder) int8() int8 {
+	if b := d.next(1); b != nil {
+		return int8(b[0])
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int16() int16 {
+	if b := d.next(2); b != nil {
+		return int16(binary.BigEndian.Uint16(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int32() int32 {
+	if b := d.next(4); b != nil {
+		return int32(binary.BigEndian.Uint32(b))
+	}
+	return 0
+}
+
+func (d *kafkaDecoder) int64() int64 {
+	if b := d.next(8); b != nil {
+		return int64(binary.BigEndian.Uint64(b))
+	}
+	return 0
+}
+
+// String reads a nullable string, returning "" for null.
+func (d *kafkaDecoder) string() string {
+	n := d.int16()
+	if n < 0 {
+		return ""
+	}
+	return string(d.next(int(n)))
+}
+
+// Bytes reads nullable bytes, returning nil for null.
+func (d *kafkaDecoder) bytes() []byte {
+	n := d.int32()
+	if n < 0 {
+		return nil
+	}
+	return d.next(int(n))
+}
+
+// Array reads the length of an array, returning 0 for null.
+func (d *kafkaDecoder) array() int {
+	n := d.int32()
+	if n < 0 || int(n) > len(d.b) {
+		return 0
+	}
+	return int(n)
+}
+
+// SkipArray skips an array of elements of a size.
+func (d *kafkaDecoder) skipArray(size int) {
+	d.skip(d.array() * size)
+}
+
+// Varint reads a zigzag-encoded variable-length integer of a record.
+func (d *kafkaDecoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.err = errKafkaShort
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+// Varbytes reads the varint-length bytes of a record, returning nil for null.
+func (d *kafkaDecoder) varbytes() []byte {
+	n := d.varint()
+	if n < 0 {
+		return nil
+	}
+	return d.next(int(n))
+}
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"net"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// KafkaBatch returns a record batch of values beginning at an offset,
+// compressed with gzip if gz.
+func kafkaBatch(t *testing.T, base int64, gz bool, values ...string) []byte {
+	var records []byte
+	for i, v := range values {
+		var r []byte
+		r = append(r, 0)                          // attributes
+		r = binary.AppendVarint(r, 0)             // timestamp_delta
+		r = binary.AppendVarint(r, int64(i))      // offset_delta
+		r = binary.AppendVarint(r, -1)            // key
+		r = binary.AppendVarint(r, int64(len(v))) // value
+		r = append(r, v...)
+		r = binary.AppendVarint(r, 0) // headers
+		records = binary.AppendVarint(records, int64(len(r)))
+		records = append(records, r...)
+	}
+	attrs := int16(0)
+	if gz {
+		attrs = 1
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		zw.Write(records)
+		if err := zw.Close(); err != nil {
+			t.Fatal(err)
+		}
+		records = buf.Bytes()
+	}
+	var e kafkaEncoder
+	e.int32(0) // partition_leader_epoch
+	e.int8(2)  // magic
+	e.int32(0) // crc
+	e.int16(attrs)
+	e.int32(int32(len(values) - 1))
+	e.int64(0)  // first_timestamp
+	e.int64(0)  // max_timestamp
+	e.int64(-1) // producer_id
+	e.int16(-1) // producer_epoch
+	e.int32(-1) // base_sequence
+	e.int32(int32(len(values)))
+	e.buf = append(e.buf, records...)
+	var b kafkaEncoder
+	b.int64(base)
+	b.int32(int32(len(e.buf)))
+	return append(b.buf, e.buf...)
+}
+
+// FakeKafka serves one partition of topic on a listener:
+// partition 0 of topic holds batches, the first at offset 0,
+// and its latest offset is latest.
+func fakeKafka(t *testing.T, l net.Listener, topic string, latest int64, batches []byte) {
+	host, port, _ := net.SplitHostPort(l.Addr().String())
+	portNum, _ := strconv.Atoi(port)
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			defer conn.Close()
+			r := bufio.NewReader(conn)
+			for {
+				var size [4]byte
+				if _, err := io.ReadFull(r, size[:]); err != nil {
+					return
+				}
+				req := make([]byte, binary.BigEndian.Uint32(size[:]))
+				if _, err := io.ReadFull(r, req); err != nil {
+					return
+				}
+				d := &kafkaDecoder{b: req}
+				api := d.int16()
+				d.int16() // api_version
+				corr := d.int32()
+				d.string() // client_id
+				var e kafkaEncoder
+				e.int32(0)
+				e.int32(corr)
+				switch api {
+				case kafkaMetadata:
+					e.int32(0) // throttle_time_ms
+					e.int32(1)
+					e.int32(7)
+					e.string(host)
+					e.int32(int32(portNum))
+					e.int16(-1) // rack
+					e.int16(-1) // cluster_id
+					e.int32(7)
+					e.int32(1)
+					e.int16(0)
+					e.string(topic)
+					e.int8(0)
+					e.int32(1)
+					e.int16(0)
+					e.int32(0)
+					e.int32(7)
+					e.int32(1)
+					e.int32(7)
+					e.int32(1)
+					e.int32(7)
+				case kafkaListOffsets:
+					d.int32() // replica_id
+					d.int32()
+					d.string()
+					d.int32()
+					d.int32()
+					offset := latest
+					if d.int64() == kafkaEarliest {
+						offset = 0
+					}
+					e.int32(1)
+					e.string(topic)
+					e.int32(1)
+					e.int32(0)
+					e.int16(0)
+					e.int64(-1)
+					e.int64(offset)
+				case kafkaFetch:
+					d.skip(17)
+					d.int32()
+					d.string()
+					d.int32()
+					d.int32()
+					offset := d.int64()
+					records := batches
+					if offset >= latest {
+						// Nothing new: wait as a broker waits max_wait_ms.
+						time.Sleep(10 * time.Millisecond)
+						records = nil
+					}
+					e.int32(0)
+					e.int32(1)
+					e.string(topic)
+					e.int32(1)
+					e.int32(0)
+					e.int16(0)
+					e.int64(latest)
+					e.int64(latest)
+					e.int32(-1)
+					e.int32(int32(len(records)))
+					e.buf = append(e.buf, records...)
+				default:
+					t.Errorf("unexpected Kafka API key %d", api)
+					return
+				}
+				binary.BigEndian.PutUint32(e.buf, uint32(len(e.buf)-4))
+				if _, err := conn.Write(e.buf); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+func TestSubscribeKafka(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	var batches []byte
+	batches = append(batches, kafkaBatch(t, 0, false, "a", "b")...)
+	batches = append(batches, kafkaBatch(t, 2, true, "c", "d", "e")...)
+	// A batch cut off by the fetch size is left for the next fetch.
+	batches = append(batches, kafkaBatch(t, 5, false, "f")[:20]...)
+	go fakeKafka(t, l, "lat", 5, batches)
+
+	msgs := make(chan []byte)
+	errs := make(chan error, 1)
+	go func() { errs <- subscribeKafka(l.Addr().String(), "lat", true, msgs) }()
+	var got []string
+	for len(got) < 5 {
+		select {
+		case msg := <-msgs:
+			got = append(got, string(msg))
+		case err := <-errs:
+			t.Fatalf("subscribeKafka returned %v after %q", err, got)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out after %q", got)
+		}
+	}
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	select {
+	case msg := <-msgs:
+		t.Errorf("got %q after the latest offset", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestReadKafkaRecordsOffset(t *testing.T) {
+	msgs := make(chan []byte, 10)
+	// A fetch from offset 1 returns the whole batch beginning at 0.
+	next, err := readKafkaRecords(kafkaBatch(t, 0, false, "a", "b", "c"), 1, msgs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	close(msgs)
+	var got []string
+	for msg := range msgs {
+		got = append(got, string(msg))
+	}
+	if want := []string{"b", "c"}; !reflect.DeepEqual(got, want) || next != 3 {
+		t.Errorf("got %q, next offset %d, want %q, 3", got, next, want)
+	}
+}
+
+func TestReadKafkaRecordsBadSize(t *testing.T) {
+	batch := kafkaBatch(t, 0, false, "a", "b")
+	negative := append([]byte(nil), batch...)
+	binary.BigEndian.PutUint32(negative[8:], uint32(0xfffffff0))
+	for _, data := range [][]byte{
+		negative,
+		append(append([]byte(nil), batch...), negative...),
+		batch[:len(batch)-1],
+	} {
+		if _, err := readKafkaRecords(data, 0, make(chan []byte, 10)); err == nil {
+			t.Errorf("readKafkaRecords(% x) succeeded, want an error", data)
+		}
+	}
+
+	// A batch cut off after the first is left for the next fetch.
+	msgs := make(chan []byte, 10)
+	data := append(append([]byte(nil), batch...), kafkaBatch(t, 2, false, "c")[:20]...)
+	next, err := readKafkaRecords(data, 0, msgs)
+	if err != nil || next != 2 || len(msgs) != 2 {
+		t.Errorf("got %d messages, next offset %d, error %v, want 2, 2, nil", len(msgs), next, err)
+	}
+}
@@ -0,0 +1,51 @@
+package main
+
+import "math"
+
+// A layout allocates non-overlapping regions of a rectangle.
+// Each region is a strip taken from one edge of the space that is still free,
+// so no two regions overlap, whichever of them are allocated.
+// The space left over after the strips are taken is typically the plot area.
+type layout struct {
+	free rect
+}
+
+// Top returns a strip of height h taken from the top of the free space.
+// The strip is clipped to the free space.
+func (l *layout) top(h float64) rect {
+	h = math.Min(h, l.free.y1-l.free.y0)
+	r := l.free
+	r.y0 = r.y1 - h
+	l.free.y1 = r.y0
+	return r
+}
+
+// Bottom returns a strip of height h taken from the bottom of the free space.
+// The strip is clipped to the free space.
+func (l *layout) bottom(h float64) rect {
+	h = math.Min(h, l.free.y1-l.free.y0)
+	r := l.free
+	r.y1 = r.y0 + h
+	l.free.y0 = r.y1
+	return r
+}
+
+// Left returns a strip of width w taken from the left of the free space.
+// The strip is clipped to the free space.
+func (l *layout) left(w float64) rect {
+	w = math.Min(w, l.free.x1-l.free.x0)
+	r := l.free
+	r.x1 = r.x0 + w
+	l.free.x0 = r.x1
+	return r
+}
+
+// Right returns a strip of width w taken from the right of the free space.
+// The strip is clipped to the free space.
+func (l *layout) right(w float64) rect {
+	w = math.Min(w, l.free.x1-l.free.x0)
+	r := l.free
+	r.x0 = r.x1 - w
+	l.free.x1 = r.x0
+	return r
+}
@@ -0,0 +1,136 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// ServeLimits are the limits on the input of requests to the server,
+// or of the messages of -consume,
+// so that a malformed or malicious client cannot exhaust its memory.
+// Zero is no limit, as on the command line.
+type serveLimits struct {
+	// Prefix is the prefix of the names of the flags setting the limits:
+	// none for serve, and consume- for -consume.
+	prefix string
+	// Body is the largest request body, in bytes, after decompression.
+	body int64
+	// Sets is the most data sets of an input or collection.
+	sets int
+	// Values is the most values of a data set,
+	// counting each centroid of a sketch as a value.
+	values int
+	// Pixels is the most pixels of the -width by -height of a plot.
+	pixels int64
+	// DPI is the largest -dpi of a plot.
+	dpi int64
+}
+
+// Limits are the limits of the server, set by serve, or of -consume, set by consume.
+var limits serveLimits
+
+// A limitError is the error of input beyond a limit.
+type limitError struct {
+	// Limit is the name of the flag setting the limit.
+	limit string
+	max   int64
+}
+
+func (e *limitError) Error() string {
+	return fmt.Sprintf("input exceeds -%s %d", e.limit, e.max)
+}
+
+// Check returns a limitError if the boxes exceed the limits.
+func (l serveLimits) check(boxes []box) error {
+	if l.sets > 0 && len(boxes) > l.sets {
+		return &limitError{limit: l.prefix + "max-datasets", max: int64(l.sets)}
+	}
+	for _, b := range boxes {
+		if err := l.checkValues(len(b.values) + centroidCount(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckSize returns a limitError if the -width, -height, or -dpi
+// of a plot exceed the limits.
+func (l serveLimits) checkSize() error {
+	if l.pixels > 0 && int64(*width)*int64(*height) > l.pixels {
+		return &limitError{limit: l.prefix + "max-pixels", max: l.pixels}
+	}
+	if l.dpi > 0 && !(*dpi <= float64(l.dpi)) {
+		return &limitError{limit: l.prefix + "max-dpi", max: l.dpi}
+	}
+	return nil
+}
+
+// CheckValues returns a limitError if n values exceed the limit.
+func (l serveLimits) checkValues(n int) error {
+	if l.values > 0 && n > l.values {
+		return &limitError{limit: l.prefix + "max-values", max: int64(l.values)}
+	}
+	return nil
+}
+
+func centroidCount(b box) int {
+	if b.digest == nil {
+		return 0
+	}
+	return len(b.digest.centroids)
+}
+
+// ReadBody reads a request body, returning a limitError
+// if it is larger than the body limit.
+func readBody(r io.Reader) ([]byte, error) {
+	if limits.body <= 0 {
+		return ioutil.ReadAll(r)
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(r, limits.body+1))
+	if err == nil && int64(len(data)) > limits.body {
+		return nil, &limitError{limit: "max-body", max: limits.body}
+	}
+	return data, err
+}
+
+// HttpError writes the response to a request that failed with an error.
+// Input beyond a limit is 413 Request Entity Too Large,
+// with a JSON body naming the limit:
+//
+//	{"error": "input exceeds -max-values 1000000", "limit": "max-values", "max": 1000000}
+//
+// Other errors are 400 Bad Request, with a JSON body as by httpStatusError.
+func httpError(w http.ResponseWriter, err error) {
+	var le *limitError
+	if !errors.As(err, &le) {
+		httpStatusError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	writeJSONError(w, http.StatusRequestEntityTooLarge, struct {
+		Error string `json:"error"`
+		Limit string `json:"limit"`
+		Max   int64  `json:"max"`
+	}{le.Error(), le.limit, le.max})
+}
+
+// HttpStatusError writes an error response with the given status
+// and a JSON body of the error message, in the shape of every
+// error response of the server, so clients need parse only one:
+//
+//	{"error": "method not allowed"}
+func httpStatusError(w http.ResponseWriter, status int, msg string) {
+	writeJSONError(w, status, struct {
+		Error string `json:"error"`
+	}{msg})
+}
+
+func writeJSONError(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// ReadLines reads one data set per line.
+// The first field of each line is the name of the data set,
+// and every following field must be a number.
+// Blank lines are ignored.
+func readLines(r io.Reader) ([]box, error) {
+	var boxes []box
+	var arena floatArena
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		fs := strings.Fields(scanner.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		vs := valueList{arena: &arena}
+		for _, f := range fs[1:] {
+			if err := vs.parse(f); err != nil {
+				return nil, fmt.Errorf("line %d: bad value %q", line, f)
+			}
+		}
+		boxes = append(boxes, vs.box(fs[0]))
+	}
+	return boxes, scanner.Err()
+}
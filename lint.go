@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"math"
+)
+
+// LintRatio is the ratio of the largest to the smallest sample count
+// of the data sets beyond which -lint warns of them.
+const lintRatio = 10
+
+// Lint returns warnings of suspicious data in summarized boxes, for -lint:
+// data sets with the same name, without values, or with every value the same;
+// sample counts differing by more than lintRatio times;
+// and medians differing by about a power of 1000,
+// as when one data set is in nanoseconds and another in microseconds.
+// Each warning suggests what to check.
+func lint(boxes []box) []string {
+	var ws []string
+	count := make(map[string]int)
+	for _, b := range boxes {
+		count[b.name]++
+	}
+	var small, large, low, high *box
+	for i := range boxes {
+		b := &boxes[i]
+		if c := count[b.name]; c > 1 {
+			ws = append(ws, fmt.Sprintf("%s: %d data sets have this name; give each a distinct name", b.name, c))
+			count[b.name] = 0
+		}
+		switch {
+		case b.n == 0:
+			ws = append(ws, fmt.Sprintf("%s: no values; check the input format and columns", b.name))
+			continue
+		case b.n > 1 && b.min == b.max:
+			ws = append(ws, fmt.Sprintf("%s: all %d values are %s; check that the measurement varies", b.name, b.n, formatValue(b.min)))
+		}
+		if small == nil || b.n < small.n {
+			small = b
+		}
+		if large == nil || b.n > large.n {
+			large = b
+		}
+		if b.q2 == 0 {
+			continue
+		}
+		if low == nil || math.Abs(b.q2) < math.Abs(low.q2) {
+			low = b
+		}
+		if high == nil || math.Abs(b.q2) > math.Abs(high.q2) {
+			high = b
+		}
+	}
+	if small != nil && large.n >= lintRatio*small.n {
+		ws = append(ws, fmt.Sprintf("%s has n=%d but %s has n=%d; their boxes are not equally reliable, so collect more values of %s",
+			large.name, large.n, small.name, small.n, small.name))
+	}
+	if low != nil {
+		// A ratio within a factor of about 3 of a power of 1000 at least 1000
+		// suggests a mismatch of SI prefixes.
+		r := math.Log10(math.Abs(high.q2) / math.Abs(low.q2))
+		if k := 3 * math.Round(r/3); k >= 3 && math.Abs(r-k) < 0.5 {
+			ws = append(ws, fmt.Sprintf("the median of %s is about 1e%g times that of %s; check that they are in the same units",
+				high.name, k, low.name))
+		}
+	}
+	return ws
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+var (
+	// LogFloor is, with -log-zero epsilon, the value
+	// at which values at or below zero are drawn:
+	// a tenth of the smallest positive value.
+	// Otherwise it is zero.
+	logFloor float64
+	// LogLinear is, with -log-zero symlog, the smallest non-zero magnitude
+	// of the values, within which of zero the scale is nearly linear.
+	logLinear = 1.0
+)
+
+// DropLogZero removes the values at or below zero from the boxes,
+// for -log with -log-zero drop, before the boxes are summarized,
+// so that their statistics are of the positive values alone.
+// Boxes read from sketches or with -exact have no values to drop,
+// and are left to checkLog.
+func dropLogZero(boxes []box) {
+	if !*logScale || *logZero != "drop" {
+		return
+	}
+	for i := range boxes {
+		b := &boxes[i]
+		if !b.pending || b.sample != nil {
+			continue
+		}
+		var vs []float64
+		var ids []string
+		for j, v := range b.values {
+			if v > 0 {
+				vs = append(vs, v)
+				if b.ids != nil {
+					ids = append(ids, b.ids[j])
+				}
+			}
+		}
+		if len(vs) == len(b.values) {
+			continue
+		}
+		warnf("%s: dropped %d values at or below zero for -log", b.name, len(b.values)-len(vs))
+		var censored []float64
+		for _, v := range b.censored {
+			if v > 0 {
+				censored = append(censored, v)
+			}
+		}
+		b.values, b.ids, b.censored = vs, ids, censored
+		b.n, b.pending = len(vs), len(vs) > 0
+	}
+}
+
+// CheckLog returns an error if -log is set
+// and any of the boxes has a value at or below zero,
+// which has no place on a logarithmic scale,
+// unless -log-zero is epsilon or symlog,
+// in which case it sets logFloor or logLinear from the values.
+func checkLog(boxes []box) error {
+	if !*logScale {
+		return nil
+	}
+	switch *logZero {
+	case "error", "drop":
+		for _, b := range boxes {
+			if b.n > 0 && b.min <= 0 {
+				return fmt.Errorf("-log needs positive values, but %s has %s; see -log-zero", b.name, formatValue(b.min))
+			}
+		}
+	case "epsilon":
+		logFloor = smallestNonzero(boxes, true) / 10
+	case "symlog":
+		logLinear = smallestNonzero(boxes, false)
+	default:
+		return fmt.Errorf("Unknown -log-zero strategy: %s", *logZero)
+	}
+	return nil
+}
+
+// SmallestNonzero returns the smallest magnitude of the non-zero values,
+// or of only the positive values if positive is set,
+// or 1 if there are none.
+// Boxes without values contribute their five-number summaries.
+func smallestNonzero(boxes []box, positive bool) float64 {
+	min := math.Inf(1)
+	for _, b := range boxes {
+		vs := b.values
+		if len(vs) == 0 && b.n > 0 {
+			vs = []float64{b.min, b.q1, b.q2, b.q3, b.max}
+		}
+		for _, v := range vs {
+			if v == 0 || positive && v < 0 {
+				continue
+			}
+			min = math.Min(min, math.Abs(v))
+		}
+	}
+	if math.IsInf(min, 1) {
+		return 1
+	}
+	return min
+}
+
+// Symlog returns the symmetric logarithm of a value, for -log-zero symlog:
+// sign(v)·log10(1 + |v|/logLinear),
+// which is logarithmic far from zero and nearly linear near it,
+// and defined for every value.
+func symlog(v float64) float64 {
+	s := math.Log10(1 + math.Abs(v)/logLinear)
+	if v < 0 {
+		return -s
+	}
+	return s
+}
+
+// SymlogTicks returns tick values spanning min to max on a symlog scale:
+// zero, if it is in the range,
+// and the powers of ten of magnitude at least logLinear on either side.
+func symlogTicks(min, max float64) []float64 {
+	var ticks []float64
+	powers := func(sign, lo, hi float64) {
+		for e := math.Ceil(math.Log10(lo)); e <= math.Floor(math.Log10(hi)); e++ {
+			ticks = append(ticks, sign*math.Pow(10, e))
+		}
+	}
+	if -min >= logLinear {
+		powers(-1, logLinear, -min)
+	}
+	if min <= 0 && max >= 0 {
+		ticks = append(ticks, 0)
+	}
+	if max >= logLinear {
+		powers(1, logLinear, max)
+	}
+	sort.Float64s(ticks)
+	return ticks
+}
@@ -0,0 +1,310 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"sort"
+	"strconv"
+)
+
+// A manifest records what is needed to reproduce a figure, for -manifest:
+// the version of box, every flag, the hashes of the inputs,
+// the seed of any random sampling, and digests of the computed statistics.
+type manifest struct {
+	Version string `json:"version"`
+	Go      string `json:"go"`
+	// Flags are the values of every flag, by name,
+	// except -manifest, and -style-file, whose rules are those of -style.
+	Flags  map[string]manifestFlag `json:"flags"`
+	Inputs []manifestInput         `json:"inputs"`
+	// Seed is the seed of the random sampling of -budget.
+	Seed  int64           `json:"seed"`
+	Stats []manifestStats `json:"stats"`
+	// Digest is the SHA-256 of the JSON of the Stats.
+	Digest string `json:"digest"`
+}
+
+// A manifestFlag is the value of a flag in a manifest:
+// a JSON string, or, for a repeatedFlag, a JSON array of its values in order,
+// which its String joins ambiguously, so that each is set again by itself.
+type manifestFlag struct {
+	values   []string
+	repeated bool
+}
+
+// A repeatedFlag is the value of a flag that may be repeated, such as -text,
+// with each value added to a list, and the list cleared by an empty value.
+type repeatedFlag interface {
+	flag.Value
+	// Values returns the values given to Set, in order.
+	values() []string
+}
+
+func (f manifestFlag) MarshalJSON() ([]byte, error) {
+	if f.repeated {
+		vs := f.values
+		if vs == nil {
+			vs = []string{}
+		}
+		return json.Marshal(vs)
+	}
+	return json.Marshal(f.values[0])
+}
+
+func (f *manifestFlag) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*f = manifestFlag{values: []string{s}}
+		return nil
+	}
+	*f = manifestFlag{repeated: true}
+	return json.Unmarshal(data, &f.values)
+}
+
+// A manifestInput is an input file, or - for standard input, and its SHA-256.
+type manifestInput struct {
+	Name   string `json:"name"`
+	SHA256 string `json:"sha256"`
+}
+
+// ManifestStats are the statistics of a box as recorded in a manifest.
+type manifestStats struct {
+	Name  string           `json:"name"`
+	N     int              `json:"n"`
+	Stats [7]manifestFloat `json:"stats"`
+}
+
+// A manifestFloat is a statistic of a manifest.
+// It is a JSON number if it is finite, and otherwise the string NaN, +Inf, or -Inf,
+// which JSON numbers cannot be, such as the standard deviation of a single value.
+type manifestFloat float64
+
+func (f manifestFloat) MarshalJSON() ([]byte, error) {
+	v := float64(f)
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return json.Marshal(strconv.FormatFloat(v, 'g', -1, 64))
+	}
+	return json.Marshal(v)
+}
+
+func (f *manifestFloat) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return json.Unmarshal(data, (*float64)(f))
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil || !(math.IsNaN(v) || math.IsInf(v, 0)) {
+		return fmt.Errorf("bad statistic %q", s)
+	}
+	*f = manifestFloat(v)
+	return nil
+}
+
+// Same returns whether two statistics are the same, taking NaN to be the same as NaN.
+func (s manifestStats) same(t manifestStats) bool {
+	if s.Name != t.Name || s.N != t.N {
+		return false
+	}
+	for i, v := range s.Stats {
+		w := t.Stats[i]
+		if v != w && !(math.IsNaN(float64(v)) && math.IsNaN(float64(w))) {
+			return false
+		}
+	}
+	return true
+}
+
+// Recording is the manifest whose statistics output records,
+// for -manifest or box render, or nil.
+var recording *manifest
+
+// NewManifest returns a manifest of the current version of box and its flags.
+func newManifest() *manifest {
+	m := &manifest{Version: buildVersion(), Go: runtime.Version(), Flags: make(map[string]manifestFlag), Seed: budgetSeed}
+	flag.VisitAll(func(f *flag.Flag) {
+		switch r, ok := f.Value.(repeatedFlag); {
+		case f.Name == "manifest" || f.Name == "style-file":
+		case ok:
+			m.Flags[f.Name] = manifestFlag{values: r.values(), repeated: true}
+		default:
+			m.Flags[f.Name] = manifestFlag{values: []string{f.Value.String()}}
+		}
+	})
+	return m
+}
+
+// SetFlags sets every flag as a manifest records it, in the order of their names.
+// The list of a repeated flag is cleared and then each of its values is added.
+func (m *manifest) setFlags() error {
+	names := make([]string, 0, len(m.Flags))
+	for name := range m.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		f := m.Flags[name]
+		vs := f.values
+		if f.repeated {
+			vs = append([]string{""}, vs...)
+		}
+		for _, v := range vs {
+			if err := flag.Set(name, v); err != nil {
+				return fmt.Errorf("-%s: %v", name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// BuildVersion returns the module version and VCS revision of box,
+// as far as the build records them.
+func buildVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	v := info.Main.Version
+	for _, s := range info.Settings {
+		if s.Key == "vcs.revision" {
+			v += " " + s.Value
+		}
+	}
+	if v == "" {
+		return "unknown"
+	}
+	return v
+}
+
+// Record sets the statistics of a manifest from summarized boxes.
+func (m *manifest) record(boxes []box) error {
+	m.Stats = nil
+	for _, b := range boxes {
+		st := manifestStats{Name: b.name, N: b.n}
+		for i, v := range []float64{b.min, b.q1, b.q2, b.q3, b.max, b.mean, b.stddev} {
+			st.Stats[i] = manifestFloat(v)
+		}
+		m.Stats = append(m.Stats, st)
+	}
+	data, err := json.Marshal(m.Stats)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(data)
+	m.Digest = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// HashFile returns the SHA-256 of a file in hexadecimal.
+func hashFile(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// WriteManifest writes a manifest as indented JSON to a file.
+func writeManifest(path string, m *manifest) error {
+	data, err := json.MarshalIndent(m, "", "\t")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(data, '\n'), 0666)
+}
+
+// RenderManifest runs the render command with the given arguments
+// and returns the exit status.
+//
+// Render replays a -manifest: it sets every flag as the manifest records it,
+// checks that the inputs have the hashes that it records,
+// reads them, as box would, and writes the figure on standard output.
+// The inputs are the files named by the manifest, or standard input,
+// or the files given as arguments in their place, in the same order.
+// The exit status is exitParse if an input has changed,
+// and exitRegression if the statistics differ from those of the manifest,
+// as they may with a different version of box.
+func renderManifest(args []string) int {
+	fs := flag.NewFlagSet("render", flag.ContinueOnError)
+	file := fs.String("manifest", "", "manifest `file` to replay")
+	parseFlags(fs, args)
+	if *file == "" {
+		fmt.Fprintln(os.Stderr, "usage: box render -manifest FILE [FILE...]")
+		return exitUsage
+	}
+	var m manifest
+	data, err := ioutil.ReadFile(*file)
+	if err == nil {
+		err = json.Unmarshal(data, &m)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box render: %v\n", err)
+		return exitParse
+	}
+	if err := m.setFlags(); err != nil {
+		fmt.Fprintf(os.Stderr, "box render: %v\n", err)
+		return exitUsage
+	}
+	paths := fs.Args()
+	if len(paths) > 0 && len(paths) != len(m.Inputs) {
+		fmt.Fprintf(os.Stderr, "box render: the manifest has %d inputs, not %d\n", len(m.Inputs), len(paths))
+		return exitUsage
+	}
+	var in io.Reader = os.Stdin
+	inputFiles = nil
+	for i, input := range m.Inputs {
+		path := input.Name
+		if len(paths) > 0 {
+			path = paths[i]
+		}
+		var sum string
+		if path == "-" {
+			data, err := ioutil.ReadAll(os.Stdin)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "box render: %v\n", err)
+				return exitParse
+			}
+			h := sha256.Sum256(data)
+			sum, in = hex.EncodeToString(h[:]), bytes.NewReader(data)
+		} else {
+			if sum, err = hashFile(path); err != nil {
+				fmt.Fprintf(os.Stderr, "box render: %v\n", err)
+				return exitParse
+			}
+			inputFiles = append(inputFiles, path)
+		}
+		if sum != input.SHA256 {
+			fmt.Fprintf(os.Stderr, "box render: %s has changed since the manifest was written\n", path)
+			return exitParse
+		}
+	}
+	recording = &manifest{}
+	if err := run(in, os.Stdout); err != nil {
+		printError(err)
+		return exitStatus(err)
+	}
+	if recording.Digest != m.Digest {
+		for i, s := range recording.Stats {
+			if i >= len(m.Stats) || !s.same(m.Stats[i]) {
+				fmt.Fprintf(os.Stderr, "box render: the statistics of %s differ from the manifest\n", s.Name)
+				break
+			}
+		}
+		return exitRegression
+	}
+	return exitOK
+}
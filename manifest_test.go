@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestManifestRepeatedFlags(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+	want := textFlag{{region: "topright", text: "run 1"}, {region: "bottom", text: "x"}}
+	for _, v := range []string{"topright:run 1", "bottom:x"} {
+		if err := flag.Set("text", v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := flag.Set("style", "a b:color=gray"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(newManifest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		t.Fatal(err)
+	}
+	for name := range m.Flags {
+		if strings.HasPrefix(name, "test.") {
+			delete(m.Flags, name)
+		}
+	}
+	resetFlags()
+	if err := flag.Set("text", "top:stale"); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.setFlags(); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(texts, want) {
+		t.Errorf("-text replayed as %q, want %q", texts.values(), want.values())
+	}
+	if got := styles.values(); !reflect.DeepEqual(got, []string{"a b:color=gray"}) {
+		t.Errorf("-style replayed as %q, want [\"a b:color=gray\"]", got)
+	}
+}
+
+func TestManifestNonFiniteStats(t *testing.T) {
+	m := &manifest{}
+	b := newBox("a", []float64{1})
+	b.stddev = math.NaN()
+	b.max = math.Inf(1)
+	if err := m.record([]box{b}); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got manifest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !got.Stats[0].same(m.Stats[0]) {
+		t.Errorf("stats %v read back as %v", m.Stats[0], got.Stats[0])
+	}
+	if !math.IsInf(float64(got.Stats[0].Stats[4]), 1) {
+		t.Errorf("max read back as %v, want +Inf", got.Stats[0].Stats[4])
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MetaNames returns the names of the -meta columns,
+// or nil if there are none.
+func metaNames() []string {
+	if *metaCols == "" {
+		return nil
+	}
+	names := strings.Split(*metaCols, ",")
+	for i := range names {
+		names[i] = strings.TrimSpace(names[i])
+	}
+	return names
+}
+
+// MetaColumns returns the indices in a header row of the -meta columns,
+// in the order they are named, or nil if there are none.
+func metaColumns(header []string) ([]int, error) {
+	var cols []int
+	for _, name := range metaNames() {
+		col := -1
+		for j, h := range header {
+			if strings.TrimSpace(h) == name {
+				col = j
+			}
+		}
+		if col < 0 {
+			return nil, fmt.Errorf("no -meta column %s", name)
+		}
+		cols = append(cols, col)
+	}
+	return cols, nil
+}
+
+// MetaValues returns the cells of a row in the given columns.
+func metaValues(row []string, cols []int) []string {
+	vs := make([]string, len(cols))
+	for i, j := range cols {
+		if j < len(row) {
+			vs[i] = strings.TrimSpace(row[j])
+		}
+	}
+	return vs
+}
+
+// IsMeta returns whether column j is one of the -meta columns.
+func isMeta(j int, cols []int) bool {
+	for _, c := range cols {
+		if c == j {
+			return true
+		}
+	}
+	return false
+}
+
+// MetaKey returns the -meta values of a box joined by slashes,
+// which is its group, and prefixes its name when the columns are split by them,
+// or the empty string if it has none.
+func metaKey(meta []string) string {
+	return strings.Join(meta, "/")
+}
+
+// MetaString returns the -meta values of a box as name=value pairs,
+// as in host=a commit=f00d, or the empty string if it has none.
+func metaString(b box) string {
+	if b.meta == nil {
+		return ""
+	}
+	var parts []string
+	for i, name := range metaNames() {
+		if i < len(b.meta) {
+			parts = append(parts, name+"="+b.meta[i])
+		}
+	}
+	return strings.Join(parts, " ")
+}
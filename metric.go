@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SelectMetric returns the boxes of the -metric, named without it,
+// or all of the boxes if -metric is not set.
+// It returns an error naming the metrics of the input if none of the boxes has the -metric,
+// if -metric or -all-metrics is used with -matrix or -inset,
+// which lay out panels of their own,
+// and if -all-metrics is used with an -o format that draws a single panel, such as term.
+func selectMetric(boxes []box) ([]box, error) {
+	if _, ok := undrawnFlags[*outFormat]; ok && *allMetrics {
+		return nil, fmt.Errorf("-o %s cannot draw the panels of -all-metrics", *outFormat)
+	}
+	if (*metricName != "" || *allMetrics) && (*matrix || *inset != "") {
+		return nil, fmt.Errorf("-metric and -all-metrics cannot be used with -matrix or -inset")
+	}
+	if *metricName != "" && *allMetrics {
+		return nil, fmt.Errorf("-metric and -all-metrics cannot be used together")
+	}
+	if *metricName == "" {
+		return boxes, nil
+	}
+	var sel []box
+	for _, b := range boxes {
+		if b.metric == *metricName {
+			b.name, b.group = b.baseName(), ""
+			sel = append(sel, b)
+		}
+	}
+	if len(sel) == 0 {
+		ms := metrics(boxes)
+		if len(ms) == 0 {
+			return nil, fmt.Errorf("No -metric %s: the data sets have no metrics", *metricName)
+		}
+		return nil, fmt.Errorf("No -metric %s: the metrics are %s", *metricName, strings.Join(ms, ", "))
+	}
+	return sel, nil
+}
+
+// Metrics returns the metrics of the boxes, in order of first appearance,
+// including the empty metric of boxes that have none.
+func metrics(boxes []box) []string {
+	var ms []string
+	seen := make(map[string]bool)
+	for _, b := range boxes {
+		if !seen[b.metric] {
+			seen[b.metric] = true
+			ms = append(ms, b.metric)
+		}
+	}
+	return ms
+}
+
+// BaseName returns the name of a box without its metric,
+// which is the last word or /-separated part of the name, if the box has one.
+func (b box) baseName() string {
+	if b.metric == "" || len(b.name) <= len(b.metric) || !strings.HasSuffix(b.name, b.metric) {
+		return b.name
+	}
+	switch base := b.name[:len(b.name)-len(b.metric)]; base[len(base)-1] {
+	case ' ', '/':
+		return base[:len(base)-1]
+	}
+	return b.name
+}
+
+// LayoutMetrics lays out, for -all-metrics, a panel for each metric of the boxes
+// within the free space of a layout, one above another, in order of first appearance,
+// with the metric labeled above the panel and its boxes named without it.
+func (f *figure) layoutMetrics(boxes []box, l layout) {
+	ms := metrics(boxes)
+	area := l.free
+	ph := (area.y1 - area.y0) / float64(len(ms))
+	for i, m := range ms {
+		y1 := area.y1 - float64(i)*ph
+		pl := layout{free: rect{x0: area.x0, y0: y1 - ph, x1: area.x1, y1: y1}}
+		if m != "" {
+			r := pl.top(titleHeight)
+			f.labels = append(f.labels, label{x: r.x0 + charWidth, y: (r.y0 + r.y1) / 2, align: 'L', text: m})
+		}
+		p := panel{r: pl.free, name: m}
+		for _, b := range boxes {
+			if b.metric == m {
+				b.name, b.group = b.baseName(), ""
+				p.boxes = append(p.boxes, b)
+			}
+		}
+		f.panels = append(f.panels, p)
+	}
+}
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// MapFile returns nil on platforms without mmap,
+// so the file is read in the usual way.
+func mapFile(f *os.File) ([]byte, func()) { return nil, nil }
@@ -0,0 +1,30 @@
+//go:build unix
+
+package main
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// MapFile memory-maps a regular file for reading
+// and returns its contents and a function to unmap them.
+// It returns nil contents if the file cannot be mapped,
+// because it is not a regular file, it is empty,
+// or the platform is not 64-bit, where large files
+// would not fit in the address space.
+func mapFile(f *os.File) ([]byte, func()) {
+	if strconv.IntSize != 64 {
+		return nil, nil
+	}
+	fi, err := f.Stat()
+	if err != nil || !fi.Mode().IsRegular() || fi.Size() == 0 {
+		return nil, nil
+	}
+	data, err := syscall.Mmap(int(f.Fd()), 0, int(fi.Size()), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil
+	}
+	return data, func() { syscall.Munmap(data) }
+}
@@ -0,0 +1,30 @@
+package main
+
+import "math"
+
+// NotchDepth is the depth of the notches of a -notch box
+// as a fraction of its width.
+const notchDepth = 0.2
+
+// Notch returns the ends of the notch of a box for -notch:
+// the median ± 1.57·IQR/√n, which is roughly a 95% confidence interval
+// for comparing medians, so that two boxes whose notches do not overlap
+// have medians that differ.
+// The notch is limited to the box.
+// It returns false if the box has no values.
+func (b box) notch() (lo, hi float64, ok bool) {
+	if b.n == 0 {
+		return 0, 0, false
+	}
+	d := 1.57 * (b.q3 - b.q1) / math.Sqrt(float64(b.n))
+	return math.Max(b.q1, b.q2-d), math.Min(b.q3, b.q2+d), true
+}
+
+// DrawNotchedBox draws the outline of a box from bottom to top
+// with a notch cut into either side from lo to hi, deepest at the median.
+func drawNotchedBox(cv canvas, x, width, bottom, top, lo, med, hi float64) {
+	d := width * notchDepth
+	xs := []float64{x, x + width, x + width, x + width - d, x + width, x + width, x, x, x + d, x, x}
+	ys := []float64{bottom, bottom, lo, med, hi, top, top, hi, med, lo, bottom}
+	cv.polyline("box", xs, ys)
+}
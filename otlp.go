@@ -0,0 +1,263 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+)
+
+// An otlpRequest is an OTLP/JSON export request for traces or metrics,
+// with only the fields that box uses.
+type otlpRequest struct {
+	ResourceSpans []struct {
+		Resource   otlpResource
+		ScopeSpans []struct {
+			Spans []struct {
+				Name              string
+				StartTimeUnixNano otlpInt
+				EndTimeUnixNano   otlpInt
+				Attributes        []otlpAttribute
+			}
+		}
+	}
+	ResourceMetrics []struct {
+		Resource     otlpResource
+		ScopeMetrics []struct {
+			Metrics []struct {
+				Name      string
+				Histogram *struct {
+					DataPoints []struct {
+						Attributes     []otlpAttribute
+						BucketCounts   []otlpInt
+						ExplicitBounds []float64
+						Min, Max       *float64
+					}
+				}
+				ExponentialHistogram *struct {
+					DataPoints []struct {
+						Attributes         []otlpAttribute
+						Scale              int
+						ZeroCount          otlpInt
+						Positive, Negative otlpBuckets
+						Min, Max           *float64
+					}
+				}
+			}
+		}
+	}
+}
+
+type otlpResource struct {
+	Attributes []otlpAttribute
+}
+
+type otlpAttribute struct {
+	Key   string
+	Value struct {
+		StringValue *string
+		BoolValue   *bool
+		IntValue    *otlpInt
+		DoubleValue *float64
+	}
+}
+
+type otlpBuckets struct {
+	Offset       int
+	BucketCounts []otlpInt
+}
+
+// An otlpInt is a 64-bit integer of OTLP/JSON,
+// which is encoded as a string, but may also be a number.
+type otlpInt int64
+
+func (i *otlpInt) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if uq, err := strconv.Unquote(s); err == nil {
+		s = uq
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	*i = otlpInt(v)
+	return err
+}
+
+// ReadOTLP reads an OTLP/JSON export request for traces or metrics,
+// as sent by OpenTelemetry SDKs and collectors to /v1/traces or /v1/metrics.
+//
+// For traces, the values are the span durations in milliseconds.
+// The spans are grouped into boxes by name,
+// or, with -otlp-group, by the value of the named attribute
+// of the span or its resource; spans without it are grouped by name.
+//
+// For metrics, each histogram or exponential histogram metric is a box,
+// merging all of its data points, with one centroid per non-empty bucket,
+// and with the minimum and maximum recorded by the data points, if any.
+// With -otlp-group, data points are grouped by the named attribute,
+// in boxes named <metric>:<value>.
+// Other metric types are ignored.
+func readOTLP(r io.Reader) ([]box, error) {
+	var req otlpRequest
+	if err := json.NewDecoder(r).Decode(&req); err != nil {
+		return nil, err
+	}
+	var names []string
+	values := make(map[string][]float64)
+	for _, rs := range req.ResourceSpans {
+		for _, ss := range rs.ScopeSpans {
+			for _, s := range ss.Spans {
+				name, ok := otlpAttr(s.Attributes, rs.Resource.Attributes)
+				if !ok {
+					name = s.Name
+				}
+				if _, ok := values[name]; !ok {
+					names = append(names, name)
+				}
+				values[name] = append(values[name], float64(s.EndTimeUnixNano-s.StartTimeUnixNano)/1e6)
+			}
+		}
+	}
+	var boxes []box
+	for _, name := range names {
+		boxes = append(boxes, newBox(name, values[name]))
+	}
+
+	names = nil
+	type hist struct {
+		d        digest
+		min, max float64
+		// Extremes is whether every data point recorded its min and max.
+		extremes bool
+	}
+	hists := make(map[string]*hist)
+	add := func(metric string, attrs, resource []otlpAttribute, cs []centroid, min, max *float64) {
+		name := metric
+		if v, ok := otlpAttr(attrs, resource); ok {
+			name += ":" + v
+		}
+		h := hists[name]
+		if h == nil {
+			h = &hist{min: math.Inf(1), max: math.Inf(-1), extremes: true}
+			hists[name] = h
+			names = append(names, name)
+		}
+		h.d.centroids = append(h.d.centroids, cs...)
+		if min == nil || max == nil {
+			h.extremes = false
+			return
+		}
+		h.min, h.max = math.Min(h.min, *min), math.Max(h.max, *max)
+	}
+	for _, rm := range req.ResourceMetrics {
+		for _, sm := range rm.ScopeMetrics {
+			for _, m := range sm.Metrics {
+				if h := m.Histogram; h != nil {
+					for _, p := range h.DataPoints {
+						cs := otlpExplicitBuckets(p.BucketCounts, p.ExplicitBounds, p.Min, p.Max)
+						add(m.Name, p.Attributes, rm.Resource.Attributes, cs, p.Min, p.Max)
+					}
+				}
+				if h := m.ExponentialHistogram; h != nil {
+					for _, p := range h.DataPoints {
+						base := math.Exp2(math.Exp2(-float64(p.Scale)))
+						var cs []centroid
+						neg := otlpExponentialBuckets(p.Negative, base)
+						for i := len(neg) - 1; i >= 0; i-- {
+							cs = append(cs, centroid{mean: -neg[i].mean, weight: neg[i].weight})
+						}
+						if p.ZeroCount > 0 {
+							cs = append(cs, centroid{mean: 0, weight: float64(p.ZeroCount)})
+						}
+						cs = append(cs, otlpExponentialBuckets(p.Positive, base)...)
+						add(m.Name, p.Attributes, rm.Resource.Attributes, cs, p.Min, p.Max)
+					}
+				}
+			}
+		}
+	}
+	for _, name := range names {
+		h := hists[name]
+		sortCentroids(&h.d)
+		if h.extremes && len(h.d.centroids) > 0 {
+			h.d.min, h.d.max = h.min, h.max
+		}
+		boxes = append(boxes, digestBox(name, &h.d))
+	}
+	return boxes, nil
+}
+
+// OtlpAttr returns the string form of the -otlp-group attribute,
+// from attrs or, failing that, from the resource attributes.
+func otlpAttr(attrs, resource []otlpAttribute) (string, bool) {
+	if *otlpGroup == "" {
+		return "", false
+	}
+	for _, as := range [][]otlpAttribute{attrs, resource} {
+		for _, a := range as {
+			if a.Key != *otlpGroup {
+				continue
+			}
+			switch v := a.Value; {
+			case v.StringValue != nil:
+				return *v.StringValue, true
+			case v.BoolValue != nil:
+				return strconv.FormatBool(*v.BoolValue), true
+			case v.IntValue != nil:
+				return strconv.FormatInt(int64(*v.IntValue), 10), true
+			case v.DoubleValue != nil:
+				return formatValue(*v.DoubleValue), true
+			}
+		}
+	}
+	return "", false
+}
+
+// OtlpExplicitBuckets returns a centroid at the middle of each non-empty bucket
+// of an explicit-bounds histogram.
+// The unbounded first and last buckets are taken to extend
+// to the minimum and maximum, if they are known,
+// and otherwise their centroids are at their finite bound.
+func otlpExplicitBuckets(counts []otlpInt, bounds []float64, min, max *float64) []centroid {
+	var cs []centroid
+	for i, c := range counts {
+		if c <= 0 {
+			continue
+		}
+		var v float64
+		switch {
+		case len(bounds) == 0:
+			v = 0
+			if min != nil && max != nil {
+				v = (*min + *max) / 2
+			}
+		case i == 0:
+			v = bounds[0]
+			if min != nil {
+				v = (*min + bounds[0]) / 2
+			}
+		case i >= len(bounds):
+			v = bounds[len(bounds)-1]
+			if max != nil {
+				v = (bounds[len(bounds)-1] + *max) / 2
+			}
+		default:
+			v = (bounds[i-1] + bounds[i]) / 2
+		}
+		cs = append(cs, centroid{mean: v, weight: float64(c)})
+	}
+	return cs
+}
+
+// OtlpExponentialBuckets returns a centroid at the geometric middle
+// of each non-empty bucket of one side of an exponential histogram,
+// in increasing order of magnitude.
+// Bucket i holds the values in (base^i, base^(i+1)].
+func otlpExponentialBuckets(b otlpBuckets, base float64) []centroid {
+	var cs []centroid
+	for i, c := range b.BucketCounts {
+		if c > 0 {
+			v := math.Pow(base, float64(b.Offset+i)+0.5)
+			cs = append(cs, centroid{mean: v, weight: float64(c)})
+		}
+	}
+	return cs
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A picCanvas draws a pic(1) picture, for -o pic,
+// to be included in troff documents and processed by pic(1).
+// Its size is that of -width and -height, taken as pixels at 96 per inch,
+// as for -o eps, and it is drawn in black, as by plot(1),
+// since plan 9's pic has no colors,
+// with the lines of boxes dashed or dotted by their -style.
+// Text is set in the current troff font and size.
+type picCanvas struct {
+	w   io.Writer
+	buf bytes.Buffer
+	// Style is the style of the current box.
+	style style
+}
+
+// PicPt returns the pic coordinates of a point, in inches.
+// Both have the origin at the bottom left.
+func picPt(x, y float64) string {
+	return fmt.Sprintf("%.3f,%.3f", x*float64(*width)/96, y*float64(*height)/96)
+}
+
+// Pen returns the pic attribute of the line style of the current box,
+// preceded by a space, or the empty string for solid lines.
+func (c *picCanvas) pen() string {
+	if c.style.line == "dashed" || c.style.line == "dotted" {
+		return " " + c.style.line
+	}
+	return ""
+}
+
+func (c *picCanvas) line(_ string, x0, y0, x1, y1 float64) {
+	fmt.Fprintf(&c.buf, "line from %s to %s%s\n", picPt(x0, y0), picPt(x1, y1), c.pen())
+}
+
+func (c *picCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.polyline(role, []float64{x0, x1, x1, x0, x0}, []float64{y0, y0, y1, y1, y0})
+}
+
+func (c *picCanvas) circle(_ string, x, y, r float64) {
+	fmt.Fprintf(&c.buf, "circle rad %.3f at %s%s\n", r*float64(*width)/96, picPt(x, y), c.pen())
+}
+
+func (c *picCanvas) polyline(_ string, xs, ys []float64) {
+	c.buf.WriteString("line from " + picPt(xs[0], ys[0]))
+	for i := range xs[1:] {
+		c.buf.WriteString(" to " + picPt(xs[i+1], ys[i+1]))
+	}
+	c.buf.WriteString(c.pen() + "\n")
+}
+
+// PicAligns maps plot(1) alignments to pic text positions.
+var picAligns = map[byte]string{'L': " ljust", 'C': "", 'R': " rjust"}
+
+func (c *picCanvas) text(_ string, x, y float64, align byte, s string) {
+	fmt.Fprintf(&c.buf, "%s%s at %s\n", picString(s), picAligns[align], picPt(x, y))
+}
+
+// PicString returns s as a pic string of troff text,
+// with its backslashes and double quotes escaped.
+func picString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\e`, `"`, `\(dq`).Replace(s) + `"`
+}
+
+func (c *picCanvas) tooltip(string) {}
+
+func (c *picCanvas) group(name string) {
+	c.style = style{}
+	if name != "" {
+		c.style = styleOf(name)
+	}
+}
+
+// Close writes the picture, between .PS and .PE,
+// with an invisible box of its full size, so that it is that size
+// whatever is drawn in it.
+func (c *picCanvas) close() error {
+	var buf bytes.Buffer
+	buf.WriteString(".PS\n")
+	fmt.Fprintf(&buf, "box invis wid %.3f ht %.3f with .sw at 0,0\n", float64(*width)/96, float64(*height)/96)
+	buf.Write(c.buf.Bytes())
+	buf.WriteString(".PE\n")
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PlotArgs are the number of coordinates of each plot(1) command
+// that takes coordinates, of those that plotCanvas writes.
+var plotArgs = map[string]int{
+	"li": 4,
+	"bo": 4,
+	"ci": 3,
+	"m":  2,
+	"v":  2,
+}
+
+// CheckPlot returns an error if the output is not valid plot(1) input,
+// as far as the subset of plot(1) that plotCanvas writes:
+// li, bo, ci, m, and v, with finite coordinates;
+// t, with a quoted string beginning with an alignment, \L, \C, or \R,
+// and containing no quotes, after a command setting the current point;
+// co and pe, with a color and pen of styleColors and styleDashes;
+// and a final cl.
+// Selftest checks the plot(1) output of every case with it,
+// since plot(1) silently misdraws the rest of a figure after a malformed line.
+func checkPlot(data []byte) error {
+	if len(data) == 0 || data[len(data)-1] != '\n' {
+		return fmt.Errorf("missing final newline")
+	}
+	lines := strings.Split(string(data[:len(data)-1]), "\n")
+	point := false
+	for i, line := range lines {
+		if err := checkPlotLine(line, point); err != nil {
+			return fmt.Errorf("line %d: %v: %q", i+1, err, line)
+		}
+		cmd := strings.Fields(line)[0]
+		if cmd == "cl" && i != len(lines)-1 {
+			return fmt.Errorf("line %d: cl before the end", i+1)
+		}
+		point = point || cmd == "m" || cmd == "v"
+	}
+	if lines[len(lines)-1] != "cl" {
+		return fmt.Errorf("missing final cl")
+	}
+	return nil
+}
+
+// CheckPlotLine returns an error if a line is not a valid plot(1) command.
+// Point is whether a previous command has set the current point.
+func checkPlotLine(line string, point bool) error {
+	cmd := line
+	if i := strings.IndexByte(line, ' '); i >= 0 {
+		cmd = line[:i]
+	}
+	arg := strings.TrimPrefix(line[len(cmd):], " ")
+	switch cmd {
+	case "cl":
+		if line != cmd {
+			return fmt.Errorf("cl takes no arguments")
+		}
+	case "t":
+		if !point {
+			return fmt.Errorf("t without a current point")
+		}
+		if len(arg) < 4 || arg[0] != '"' || arg[len(arg)-1] != '"' {
+			return fmt.Errorf("t needs a quoted string")
+		}
+		s := arg[1 : len(arg)-1]
+		if s[0] != '\\' || strings.IndexByte("LCR", s[1]) < 0 {
+			return fmt.Errorf("t needs an alignment of \\L, \\C, or \\R")
+		}
+		if strings.IndexByte(s, '"') >= 0 {
+			return fmt.Errorf("quote within the string")
+		}
+	case "co":
+		if _, ok := styleColors[arg]; !ok {
+			return fmt.Errorf("unknown color")
+		}
+	case "pe":
+		if _, ok := styleDashes[arg]; !ok {
+			return fmt.Errorf("unknown pen")
+		}
+	default:
+		n, ok := plotArgs[cmd]
+		if !ok {
+			return fmt.Errorf("unknown command")
+		}
+		if cmd == "v" && !point {
+			return fmt.Errorf("v without a current point")
+		}
+		fields := strings.Split(arg, " ")
+		if len(fields) != n {
+			return fmt.Errorf("%s takes %d coordinates", cmd, n)
+		}
+		for _, f := range fields {
+			v, err := strconv.ParseFloat(f, 64)
+			if err != nil || math.IsInf(v, 0) || math.IsNaN(v) {
+				return fmt.Errorf("bad coordinate %s", f)
+			}
+		}
+	}
+	return nil
+}
+
+// PlotText returns s made safe for a quoted plot(1) string,
+// which has no escapes and ends at the first quote or newline:
+// quotes become apostrophes and line breaks become spaces.
+func plotText(s string) string {
+	return plotTextReplacer.Replace(s)
+}
+
+var plotTextReplacer = strings.NewReplacer(`"`, `'`, "\n", " ", "\r", " ")
@@ -0,0 +1,217 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os/exec"
+	"strings"
+)
+
+// A plugin is an external program that extends box
+// with input formats or statistics.
+//
+// Box runs the program once for each request,
+// writes a JSON request object to its standard input,
+// and reads a JSON response object from its standard output.
+// A response with a non-empty error member, or a non-zero exit status,
+// is an error. The requests are:
+//
+//	{"op": "describe"}
+//	→ {"formats": ["name", ...], "stats": ["name", ...]}
+//
+//	{"op": "parse", "format": "name", "input": "the whole input"}
+//	→ {"datasets": [{"name": "name", "values": [1, 2, ...]}, ...]}
+//
+//	{"op": "stat", "stat": "name", "datasets": [{"name": "name", "values": [...], "n": n}, ...]}
+//	→ {"values": [v, ...]}
+//
+// Describe is sent when the plugin is registered with -plugin.
+// Each format it names is added to the formats of -format,
+// and each statistic to the keys of -sort.
+// The datasets of a stat request are those being sorted,
+// and the response has one value for each, in order.
+// Data sets read from sketches are sent with their count and no values.
+type plugin struct {
+	path    string
+	formats []string
+	stats   []string
+}
+
+type pluginRequest struct {
+	Op       string          `json:"op"`
+	Format   string          `json:"format,omitempty"`
+	Input    string          `json:"input,omitempty"`
+	Stat     string          `json:"stat,omitempty"`
+	Datasets []pluginDataset `json:"datasets,omitempty"`
+}
+
+type pluginDataset struct {
+	Name   string    `json:"name"`
+	Values []float64 `json:"values"`
+	N      int       `json:"n"`
+}
+
+type pluginResponse struct {
+	Error    string          `json:"error"`
+	Formats  []string        `json:"formats"`
+	Stats    []string        `json:"stats"`
+	Datasets []pluginDataset `json:"datasets"`
+	Values   []float64       `json:"values"`
+}
+
+// PluginFlag is the value of the repeatable -plugin flag.
+type pluginFlag []*plugin
+
+// Plugins are the registered plugins, in order.
+var plugins pluginFlag
+
+// PluginStats maps the statistics of the plugins to the plugins.
+var pluginStats = make(map[string]*plugin)
+
+func init() {
+	flag.Var(&plugins, "plugin", "register the input formats and statistics of the plugin `program`; may be repeated")
+}
+
+func (ps *pluginFlag) String() string {
+	var ss []string
+	for _, p := range *ps {
+		ss = append(ss, p.path)
+	}
+	return strings.Join(ss, " ")
+}
+
+func (ps *pluginFlag) values() []string {
+	var vs []string
+	for _, p := range *ps {
+		vs = append(vs, p.path)
+	}
+	return vs
+}
+
+// Set runs the plugin at path to describe it,
+// and registers its formats and statistics.
+// Formats and statistics of later plugins replace
+// those of earlier plugins, but not those of box.
+// An empty value clears the list, and the registrations.
+func (ps *pluginFlag) Set(path string) error {
+	if path == "" {
+		for _, p := range *ps {
+			for _, f := range p.formats {
+				delete(readers, f)
+			}
+			for _, s := range p.stats {
+				delete(pluginStats, s)
+			}
+		}
+		*ps = nil
+		return nil
+	}
+	p := &plugin{path: path}
+	resp, err := p.call(pluginRequest{Op: "describe"})
+	if err != nil {
+		return err
+	}
+	for _, f := range resp.Formats {
+		if _, ok := readers[f]; ok && !ps.hasFormat(f) {
+			return fmt.Errorf("%s: format %s is built in", path, f)
+		}
+		p.formats = append(p.formats, f)
+		readers[f] = p.reader(f)
+	}
+	for _, s := range resp.Stats {
+		if _, ok := sortKeys[s]; ok || s == "name" {
+			return fmt.Errorf("%s: statistic %s is built in", path, s)
+		}
+		p.stats = append(p.stats, s)
+		pluginStats[s] = p
+	}
+	*ps = append(*ps, p)
+	return nil
+}
+
+// HasFormat returns whether a plugin in the list provides a format.
+func (ps pluginFlag) hasFormat(format string) bool {
+	for _, p := range ps {
+		for _, f := range p.formats {
+			if f == format {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Call runs the plugin with a request and returns its response.
+func (p *plugin) call(req pluginRequest) (*pluginResponse, error) {
+	in, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(p.path)
+	cmd.Stdin = bytes.NewReader(in)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("%s: %v: %s", p.path, err, msg)
+		}
+		return nil, fmt.Errorf("%s: %v", p.path, err)
+	}
+	var resp pluginResponse
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		return nil, fmt.Errorf("%s: bad response: %v", p.path, err)
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("%s: %s", p.path, resp.Error)
+	}
+	return &resp, nil
+}
+
+// Reader returns a reader of the plugin's format.
+func (p *plugin) reader(format string) func(io.Reader) ([]box, error) {
+	return func(r io.Reader) ([]box, error) {
+		input, err := ioutil.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := p.call(pluginRequest{Op: "parse", Format: format, Input: string(input)})
+		if err != nil {
+			return nil, err
+		}
+		var boxes []box
+		for _, d := range resp.Datasets {
+			boxes = append(boxes, newBox(d.Name, d.Values))
+		}
+		return boxes, nil
+	}
+}
+
+// PluginStat returns a function returning the value of a plugin statistic
+// for each of the boxes, or false if no plugin provides the statistic.
+func pluginStat(stat string, boxes []box) (func(box) float64, bool, error) {
+	p, ok := pluginStats[stat]
+	if !ok {
+		return nil, false, nil
+	}
+	req := pluginRequest{Op: "stat", Stat: stat}
+	for _, b := range boxes {
+		req.Datasets = append(req.Datasets, pluginDataset{Name: b.name, Values: b.values, N: b.n})
+	}
+	resp, err := p.call(req)
+	if err != nil {
+		return nil, true, err
+	}
+	if len(resp.Values) != len(boxes) {
+		return nil, true, fmt.Errorf("%s: %d values of %s for %d data sets", p.path, len(resp.Values), stat, len(boxes))
+	}
+	values := make(map[string]float64)
+	for i, b := range boxes {
+		values[b.name] = resp.Values[i]
+	}
+	return func(b box) float64 { return values[b.name] }, true, nil
+}
@@ -0,0 +1,328 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+)
+
+// A pngCanvas draws a PNG image, rasterizing the shapes itself,
+// with text in a built-in 5×7 pixel font.
+// Its size is that of -width and -height,
+// and its lines and text are scaled by -dpi over 96.
+type pngCanvas struct {
+	w   io.Writer
+	img *image.RGBA
+	// Style is the style of the current box.
+	style style
+}
+
+// MaxSide and maxDPI are the largest -width or -height and -dpi of a plot.
+// Beyond them, an image would take gigabytes,
+// or its lines would each take minutes to draw.
+const (
+	maxSide = 1 << 15
+	maxDPI  = 9600
+)
+
+// CheckSize returns an error if -width, -height, or -dpi
+// are not positive or are beyond maxSide or maxDPI.
+func checkSize() error {
+	if *width <= 0 || *height <= 0 || *width > maxSide || *height > maxSide {
+		return fmt.Errorf("Bad output size: %dx%d; each side must be from 1 to %d", *width, *height, maxSide)
+	}
+	if !(*dpi > 0 && *dpi <= maxDPI) {
+		return fmt.Errorf("Bad -dpi: %s; it must be above 0 and at most %d", formatValue(*dpi), maxDPI)
+	}
+	return nil
+}
+
+func newPNGCanvas(w io.Writer) *pngCanvas {
+	img := image.NewRGBA(image.Rect(0, 0, *width, *height))
+	for i := range img.Pix {
+		img.Pix[i] = 0xff
+	}
+	return &pngCanvas{w: w, img: img}
+}
+
+// PngScale returns the scale of lines and text.
+func pngScale() float64 {
+	return math.Max(*dpi/96, 0.5)
+}
+
+// Pt returns the image coordinates of a point,
+// whose canvas coordinates have the origin at the bottom left.
+func (c *pngCanvas) pt(x, y float64) (float64, float64) {
+	return x * float64(*width), (1 - y) * float64(*height)
+}
+
+// Ink returns the color and width of the lines of a role,
+// as styled by svgStyle and the style of the current box.
+func (c *pngCanvas) ink(role string) (color.RGBA, float64) {
+	ink := styleColors["black"]
+	if c.style.color != "" {
+		ink = styleColors[c.style.color]
+	}
+	switch role {
+	case "median":
+		return ink, 2 * pngScale()
+	case "shade":
+		return color.RGBA{0xcc, 0xcc, 0xcc, 0xff}, pngScale()
+	case "heat", "zoom":
+		return color.RGBA{0x88, 0x88, 0x88, 0xff}, pngScale()
+	}
+	return ink, pngScale()
+}
+
+// Inked returns whether a line of the current box is drawn
+// at a distance along it, in pixels, according to the line style.
+func (c *pngCanvas) inked(dist float64) bool {
+	on, off := 0.0, 0.0
+	switch c.style.line {
+	case "dashed":
+		on, off = 6, 4
+	case "dotted":
+		on, off = 2, 3
+	default:
+		return true
+	}
+	period := (on + off) * pngScale()
+	return math.Mod(dist, period) < on*pngScale()
+}
+
+// Dot fills a square of width w centered on image coordinates x, y.
+func (c *pngCanvas) dot(x, y, w float64, ink color.RGBA) {
+	n := int(math.Max(1, math.Round(w)))
+	x0 := int(math.Floor(x - float64(n)/2 + 0.5))
+	y0 := int(math.Floor(y - float64(n)/2 + 0.5))
+	for yy := y0; yy < y0+n; yy++ {
+		for xx := x0; xx < x0+n; xx++ {
+			c.img.SetRGBA(xx, yy, ink)
+		}
+	}
+}
+
+// Stroke draws a line between image coordinates.
+func (c *pngCanvas) stroke(x0, y0, x1, y1, w float64, ink color.RGBA) {
+	n := int(math.Ceil(math.Max(math.Abs(x1-x0), math.Abs(y1-y0))))
+	length := math.Hypot(x1-x0, y1-y0)
+	for i := 0; i <= n; i++ {
+		t := 0.0
+		if n > 0 {
+			t = float64(i) / float64(n)
+		}
+		if c.inked(t * length) {
+			c.dot(x0+t*(x1-x0), y0+t*(y1-y0), w, ink)
+		}
+	}
+}
+
+func (c *pngCanvas) line(role string, x0, y0, x1, y1 float64) {
+	ink, w := c.ink(role)
+	x0, y0 = c.pt(x0, y0)
+	x1, y1 = c.pt(x1, y1)
+	c.stroke(x0, y0, x1, y1, w, ink)
+}
+
+func (c *pngCanvas) box(role string, x0, y0, x1, y1 float64) {
+	c.polyline(role, []float64{x0, x1, x1, x0, x0}, []float64{y0, y0, y1, y1, y0})
+}
+
+func (c *pngCanvas) circle(role string, x, y, r float64) {
+	ink, w := c.ink(role)
+	x, y = c.pt(x, y)
+	r *= float64(*width)
+	n := int(math.Max(8, math.Ceil(2*math.Pi*r)))
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		if c.inked(a * r) {
+			c.dot(x+r*math.Cos(a), y+r*math.Sin(a), w, ink)
+		}
+	}
+}
+
+func (c *pngCanvas) polyline(role string, xs, ys []float64) {
+	ink, w := c.ink(role)
+	for i := 1; i < len(xs); i++ {
+		x0, y0 := c.pt(xs[i-1], ys[i-1])
+		x1, y1 := c.pt(xs[i], ys[i])
+		c.stroke(x0, y0, x1, y1, w, ink)
+	}
+}
+
+func (c *pngCanvas) text(role string, x, y float64, align byte, s string) {
+	k := int(math.Max(1, math.Round(2*pngScale())))
+	if role == "title" {
+		k += k / 2
+	}
+	x, y = c.pt(x, y)
+	rs := []rune(s)
+	// Each glyph is 5 pixels and a space wide, as measured by textWidth.
+	w := float64(k * (6*len(rs) - 1))
+	switch align {
+	case 'C':
+		x -= w / 2
+	case 'R':
+		x -= w
+	}
+	left := int(math.Round(x))
+	top := int(math.Round(y - float64(7*k)/2))
+	ink, _ := c.ink(role)
+	for i, r := range rs {
+		for row, bits := range pngGlyph(r) {
+			for col := 0; col < 5; col++ {
+				if bits&(0x10>>uint(col)) == 0 {
+					continue
+				}
+				x0, y0 := left+k*(6*i+col), top+k*row
+				for yy := y0; yy < y0+k; yy++ {
+					for xx := x0; xx < x0+k; xx++ {
+						c.img.SetRGBA(xx, yy, ink)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (c *pngCanvas) tooltip(string) {}
+
+func (c *pngCanvas) group(name string) {
+	c.style = style{}
+	if name != "" {
+		c.style = styleOf(name)
+	}
+}
+
+// Close writes the PNG image.
+func (c *pngCanvas) close() error {
+	return png.Encode(c.w, c.img)
+}
+
+// PngFont is a 5×7 pixel font of the printable ASCII characters,
+// from space to tilde. Each glyph is 7 rows, top to bottom,
+// with the leftmost pixel of a row in bit 4.
+var pngFont = [95][7]byte{
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00}, // ' '
+	{0x04, 0x04, 0x04, 0x04, 0x04, 0x00, 0x04}, // '!'
+	{0x0a, 0x0a, 0x0a, 0x00, 0x00, 0x00, 0x00}, // '"'
+	{0x0a, 0x0a, 0x1f, 0x0a, 0x1f, 0x0a, 0x0a}, // '#'
+	{0x04, 0x0f, 0x14, 0x0e, 0x05, 0x1e, 0x04}, // '$'
+	{0x18, 0x19, 0x02, 0x04, 0x08, 0x13, 0x03}, // '%'
+	{0x0c, 0x12, 0x14, 0x08, 0x15, 0x12, 0x0d}, // '&'
+	{0x0c, 0x04, 0x08, 0x00, 0x00, 0x00, 0x00}, // '\''
+	{0x02, 0x04, 0x08, 0x08, 0x08, 0x04, 0x02}, // '('
+	{0x08, 0x04, 0x02, 0x02, 0x02, 0x04, 0x08}, // ')'
+	{0x00, 0x04, 0x15, 0x0e, 0x15, 0x04, 0x00}, // '*'
+	{0x00, 0x04, 0x04, 0x1f, 0x04, 0x04, 0x00}, // '+'
+	{0x00, 0x00, 0x00, 0x00, 0x0c, 0x04, 0x08}, // ','
+	{0x00, 0x00, 0x00, 0x1f, 0x00, 0x00, 0x00}, // '-'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x0c, 0x0c}, // '.'
+	{0x00, 0x01, 0x02, 0x04, 0x08, 0x10, 0x00}, // '/'
+	{0x0e, 0x11, 0x13, 0x15, 0x19, 0x11, 0x0e}, // '0'
+	{0x04, 0x0c, 0x04, 0x04, 0x04, 0x04, 0x0e}, // '1'
+	{0x0e, 0x11, 0x01, 0x02, 0x04, 0x08, 0x1f}, // '2'
+	{0x1f, 0x02, 0x04, 0x02, 0x01, 0x11, 0x0e}, // '3'
+	{0x02, 0x06, 0x0a, 0x12, 0x1f, 0x02, 0x02}, // '4'
+	{0x1f, 0x10, 0x1e, 0x01, 0x01, 0x11, 0x0e}, // '5'
+	{0x06, 0x08, 0x10, 0x1e, 0x11, 0x11, 0x0e}, // '6'
+	{0x1f, 0x01, 0x02, 0x04, 0x08, 0x08, 0x08}, // '7'
+	{0x0e, 0x11, 0x11, 0x0e, 0x11, 0x11, 0x0e}, // '8'
+	{0x0e, 0x11, 0x11, 0x0f, 0x01, 0x02, 0x0c}, // '9'
+	{0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x0c, 0x00}, // ':'
+	{0x00, 0x0c, 0x0c, 0x00, 0x0c, 0x04, 0x08}, // ';'
+	{0x02, 0x04, 0x08, 0x10, 0x08, 0x04, 0x02}, // '<'
+	{0x00, 0x00, 0x1f, 0x00, 0x1f, 0x00, 0x00}, // '='
+	{0x08, 0x04, 0x02, 0x01, 0x02, 0x04, 0x08}, // '>'
+	{0x0e, 0x11, 0x01, 0x02, 0x04, 0x00, 0x04}, // '?'
+	{0x0e, 0x11, 0x01, 0x0d, 0x15, 0x15, 0x0e}, // '@'
+	{0x0e, 0x11, 0x11, 0x11, 0x1f, 0x11, 0x11}, // 'A'
+	{0x1e, 0x11, 0x11, 0x1e, 0x11, 0x11, 0x1e}, // 'B'
+	{0x0e, 0x11, 0x10, 0x10, 0x10, 0x11, 0x0e}, // 'C'
+	{0x1c, 0x12, 0x11, 0x11, 0x11, 0x12, 0x1c}, // 'D'
+	{0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x1f}, // 'E'
+	{0x1f, 0x10, 0x10, 0x1e, 0x10, 0x10, 0x10}, // 'F'
+	{0x0e, 0x11, 0x10, 0x17, 0x11, 0x11, 0x0f}, // 'G'
+	{0x11, 0x11, 0x11, 0x1f, 0x11, 0x11, 0x11}, // 'H'
+	{0x0e, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e}, // 'I'
+	{0x07, 0x02, 0x02, 0x02, 0x02, 0x12, 0x0c}, // 'J'
+	{0x11, 0x12, 0x14, 0x18, 0x14, 0x12, 0x11}, // 'K'
+	{0x10, 0x10, 0x10, 0x10, 0x10, 0x10, 0x1f}, // 'L'
+	{0x11, 0x1b, 0x15, 0x15, 0x11, 0x11, 0x11}, // 'M'
+	{0x11, 0x11, 0x19, 0x15, 0x13, 0x11, 0x11}, // 'N'
+	{0x0e, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e}, // 'O'
+	{0x1e, 0x11, 0x11, 0x1e, 0x10, 0x10, 0x10}, // 'P'
+	{0x0e, 0x11, 0x11, 0x11, 0x15, 0x12, 0x0d}, // 'Q'
+	{0x1e, 0x11, 0x11, 0x1e, 0x14, 0x12, 0x11}, // 'R'
+	{0x0f, 0x10, 0x10, 0x0e, 0x01, 0x01, 0x1e}, // 'S'
+	{0x1f, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04}, // 'T'
+	{0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x0e}, // 'U'
+	{0x11, 0x11, 0x11, 0x11, 0x11, 0x0a, 0x04}, // 'V'
+	{0x11, 0x11, 0x11, 0x15, 0x15, 0x15, 0x0a}, // 'W'
+	{0x11, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x11}, // 'X'
+	{0x11, 0x11, 0x11, 0x0a, 0x04, 0x04, 0x04}, // 'Y'
+	{0x1f, 0x01, 0x02, 0x04, 0x08, 0x10, 0x1f}, // 'Z'
+	{0x0e, 0x08, 0x08, 0x08, 0x08, 0x08, 0x0e}, // '['
+	{0x00, 0x10, 0x08, 0x04, 0x02, 0x01, 0x00}, // '\\'
+	{0x0e, 0x02, 0x02, 0x02, 0x02, 0x02, 0x0e}, // ']'
+	{0x04, 0x0a, 0x11, 0x00, 0x00, 0x00, 0x00}, // '^'
+	{0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x1f}, // '_'
+	{0x08, 0x04, 0x02, 0x00, 0x00, 0x00, 0x00}, // '`'
+	{0x00, 0x00, 0x0e, 0x01, 0x0f, 0x11, 0x0f}, // 'a'
+	{0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x1e}, // 'b'
+	{0x00, 0x00, 0x0e, 0x10, 0x10, 0x11, 0x0e}, // 'c'
+	{0x01, 0x01, 0x0d, 0x13, 0x11, 0x11, 0x0f}, // 'd'
+	{0x00, 0x00, 0x0e, 0x11, 0x1f, 0x10, 0x0e}, // 'e'
+	{0x06, 0x09, 0x08, 0x1c, 0x08, 0x08, 0x08}, // 'f'
+	{0x00, 0x0f, 0x11, 0x11, 0x0f, 0x01, 0x0e}, // 'g'
+	{0x10, 0x10, 0x16, 0x19, 0x11, 0x11, 0x11}, // 'h'
+	{0x04, 0x00, 0x0c, 0x04, 0x04, 0x04, 0x0e}, // 'i'
+	{0x02, 0x00, 0x06, 0x02, 0x02, 0x12, 0x0c}, // 'j'
+	{0x10, 0x10, 0x12, 0x14, 0x18, 0x14, 0x12}, // 'k'
+	{0x0c, 0x04, 0x04, 0x04, 0x04, 0x04, 0x0e}, // 'l'
+	{0x00, 0x00, 0x1a, 0x15, 0x15, 0x11, 0x11}, // 'm'
+	{0x00, 0x00, 0x16, 0x19, 0x11, 0x11, 0x11}, // 'n'
+	{0x00, 0x00, 0x0e, 0x11, 0x11, 0x11, 0x0e}, // 'o'
+	{0x00, 0x00, 0x1e, 0x11, 0x1e, 0x10, 0x10}, // 'p'
+	{0x00, 0x00, 0x0d, 0x13, 0x0f, 0x01, 0x01}, // 'q'
+	{0x00, 0x00, 0x16, 0x19, 0x10, 0x10, 0x10}, // 'r'
+	{0x00, 0x00, 0x0e, 0x10, 0x0e, 0x01, 0x1e}, // 's'
+	{0x08, 0x08, 0x1c, 0x08, 0x08, 0x09, 0x06}, // 't'
+	{0x00, 0x00, 0x11, 0x11, 0x11, 0x13, 0x0d}, // 'u'
+	{0x00, 0x00, 0x11, 0x11, 0x11, 0x0a, 0x04}, // 'v'
+	{0x00, 0x00, 0x11, 0x11, 0x15, 0x15, 0x0a}, // 'w'
+	{0x00, 0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11}, // 'x'
+	{0x00, 0x00, 0x11, 0x11, 0x0f, 0x01, 0x0e}, // 'y'
+	{0x00, 0x00, 0x1f, 0x02, 0x04, 0x08, 0x1f}, // 'z'
+	{0x02, 0x04, 0x04, 0x08, 0x04, 0x04, 0x02}, // '{'
+	{0x04, 0x04, 0x04, 0x04, 0x04, 0x04, 0x04}, // '|'
+	{0x08, 0x04, 0x04, 0x02, 0x04, 0x04, 0x08}, // '}'
+	{0x00, 0x00, 0x08, 0x15, 0x02, 0x00, 0x00}, // '~'
+}
+
+// PngExtraGlyphs are glyphs, in the style of pngFont,
+// of the characters beyond ASCII most common in units and labels.
+var pngExtraGlyphs = map[rune][7]byte{
+	'µ': {0x00, 0x11, 0x11, 0x11, 0x13, 0x1d, 0x10},
+	'μ': {0x00, 0x11, 0x11, 0x11, 0x13, 0x1d, 0x10},
+	'±': {0x04, 0x04, 0x1f, 0x04, 0x04, 0x00, 0x1f},
+	'×': {0x00, 0x11, 0x0a, 0x04, 0x0a, 0x11, 0x00},
+	'°': {0x0c, 0x12, 0x12, 0x0c, 0x00, 0x00, 0x00},
+}
+
+// PngGlyph returns the glyph of a character:
+// from pngFont or pngExtraGlyphs, or otherwise a question mark.
+// Every glyph comes from the built-in font, never from the fonts of the host,
+// so a PNG image is the same, byte for byte, wherever box draws it.
+func pngGlyph(r rune) [7]byte {
+	if r >= ' ' && r <= '~' {
+		return pngFont[r-' ']
+	}
+	if g, ok := pngExtraGlyphs[r]; ok {
+		return g
+	}
+	return pngFont['?'-' ']
+}
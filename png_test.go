@@ -0,0 +1,43 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestCheckSize tests that a size or -dpi that is not positive,
+// or is too large to draw, is a usage error.
+func TestCheckSize(t *testing.T) {
+	defer resetFlags()
+	for _, test := range []struct {
+		flag, value string
+		ok          bool
+	}{
+		{"dpi", "1e9", false},
+		{"dpi", "0", false},
+		{"dpi", "-96", false},
+		{"dpi", "NaN", false},
+		{"dpi", "192", true},
+		{"width", "0", false},
+		{"height", "-1", false},
+		{"width", "40000", false},
+		{"width", "1600", true},
+	} {
+		resetFlags()
+		if err := flag.Set("o", "png"); err != nil {
+			t.Fatal(err)
+		}
+		if err := flag.Set(test.flag, test.value); err != nil {
+			t.Fatal(err)
+		}
+		err := run(strings.NewReader("a 1 2 3 4"), ioutil.Discard)
+		if test.ok && err != nil {
+			t.Errorf("-%s %s: %v", test.flag, test.value, err)
+		}
+		if !test.ok && exitStatus(err) != exitUsage {
+			t.Errorf("-%s %s: exit status %d, want %d", test.flag, test.value, exitStatus(err), exitUsage)
+		}
+	}
+}
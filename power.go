@@ -0,0 +1,153 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Power runs the power command with the given arguments
+// and returns the exit status.
+//
+// Power reads data sets from a file, or standard input,
+// as box reads its input, and for each data set,
+// estimates from its standard deviation the number of samples
+// needed to detect a change of -effect in the mean
+// with a two-sided two-sample t test at significance level -alpha
+// with probability -power, assuming both the old and new samples are that size.
+// The effect is a percentage of the mean, as in -effect 5%,
+// or an absolute change, as in -effect 0.25.
+// It also prints the smallest change that the existing sample size can detect,
+// so that a benchmark with too few runs is evident before its plot is trusted.
+func power(args []string) int {
+	fs := flag.NewFlagSet("power", flag.ContinueOnError)
+	effect := fs.String("effect", "5%", "`change` in the mean to detect: a percentage of the mean, or an absolute value")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the test")
+	pow := fs.Float64("power", 0.8, "probability of detecting the change")
+	parseFlags(fs, args)
+	// The file may come before the flags, as in box power FILE -effect 2%.
+	var file string
+	if fs.NArg() > 0 {
+		file = fs.Arg(0)
+		parseFlags(fs, fs.Args()[1:])
+	}
+	rel, size, err := parseEffect(*effect)
+	if err == nil && !(*alpha > 0 && *alpha < 1 && *pow > 0 && *pow < 1) {
+		err = fmt.Errorf("-alpha and -power must be between 0 and 1")
+	}
+	if fs.NArg() > 0 || err != nil {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "box power: %v\n", err)
+		}
+		fmt.Fprintln(os.Stderr, "usage: box power [FILE] [-effect 5%] [-alpha 0.05] [-power 0.8]")
+		return exitUsage
+	}
+	in := io.Reader(os.Stdin)
+	if file != "" {
+		f, err := os.Open(file)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "box power: %v\n", err)
+			return exitParse
+		}
+		defer f.Close()
+		in = f
+	}
+	boxes, err := readInput(in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box power: %v\n", err)
+		return exitStatus(withStatus(exitParse, err))
+	}
+	summarize(boxes)
+	for _, b := range boxes {
+		fmt.Println(powerLine(b, rel, size, *alpha, *pow))
+	}
+	return exitOK
+}
+
+// ParseEffect parses an -effect value,
+// returning whether it is relative to the mean, and its size:
+// a fraction of the mean, or an absolute change.
+func parseEffect(s string) (rel bool, size float64, err error) {
+	rel = strings.HasSuffix(s, "%")
+	size, err = strconv.ParseFloat(strings.TrimSuffix(s, "%"), 64)
+	if err != nil || !(size > 0) || math.IsInf(size, 0) {
+		return false, 0, fmt.Errorf("Bad effect: %s", s)
+	}
+	if rel {
+		size /= 100
+	}
+	return rel, size, nil
+}
+
+// PowerLine returns the line of power output for a box:
+// its name, sample size, mean, and standard deviation,
+// the sample size needed to detect the effect,
+// and the smallest effect detectable at its own sample size.
+func powerLine(b box, rel bool, size, alpha, pow float64) string {
+	if b.n < 2 || math.IsNaN(b.stddev) {
+		return fmt.Sprintf("%s: n=%d; need at least 2 values to estimate the variance", b.name, b.n)
+	}
+	delta := size
+	if rel {
+		delta = size * math.Abs(b.mean)
+	}
+	need := "∞"
+	if delta > 0 {
+		need = strconv.Itoa(sampleSize(b.stddev, delta, alpha, pow))
+	}
+	mde := minEffect(b.stddev, b.n, alpha, pow)
+	detect := formatValue(mde)
+	if rel {
+		detect = formatValue(100*mde/math.Abs(b.mean)) + "%"
+	}
+	return fmt.Sprintf("%s: n=%d mean=%s stddev=%s; need n=%s per sample to detect %s; n=%d detects %s",
+		b.name, b.n, formatValue(b.mean), formatValue(b.stddev), need, effectString(rel, size), b.n, detect)
+}
+
+// EffectString returns an effect formatted as it was given to -effect.
+func effectString(rel bool, size float64) string {
+	if rel {
+		return formatValue(100*size) + "%"
+	}
+	return formatValue(size)
+}
+
+// MaxSampleSize bounds the sample sizes that sampleSize searches,
+// past which a change is reported as undetectable.
+const maxSampleSize = 1 << 30
+
+// SampleSize returns the smallest size n of each of two samples
+// with standard deviation sd such that a two-sided t test at level alpha
+// detects a difference of delta in their means with probability pow:
+// the smallest n with (t(1-α/2) + t(pow)) · sd · √(2/n) ≤ delta,
+// where the t quantiles have 2(n-1) degrees of freedom.
+func sampleSize(sd, delta, alpha, pow float64) int {
+	lo, hi := 2, 2
+	for minEffect(sd, hi, alpha, pow) > delta {
+		if hi >= maxSampleSize {
+			return maxSampleSize
+		}
+		lo, hi = hi, hi*2
+	}
+	for lo < hi {
+		mid := (lo + hi) / 2
+		if minEffect(sd, mid, alpha, pow) > delta {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+	return hi
+}
+
+// MinEffect returns the smallest difference in the means
+// of two samples of size n with standard deviation sd
+// that a two-sided t test at level alpha detects with probability pow.
+func minEffect(sd float64, n int, alpha, pow float64) float64 {
+	df := 2 * float64(n-1)
+	return (tQuantile(1-alpha/2, df) + tQuantile(pow, df)) * sd * math.Sqrt(2/float64(n))
+}
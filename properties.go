@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+)
+
+// CheckQuantiles checks properties of the five-number summary
+// over random inputs, for each -quantile-method,
+// and returns a description of each failure.
+// The inputs are drawn from a fixed seed, so failures are reproducible.
+//
+// The properties are that
+// the statistics are ordered: min ≤ q1 ≤ q2 ≤ q3 ≤ max;
+// they match a reference implementation, for the methods that have one;
+// they do not depend on the order of the values;
+// negating the values mirrors them: q1(-x) = -q3(x),
+// for the methods that are symmetric;
+// and computing them leaves the values unchanged.
+func checkQuantiles(trials int) []string {
+	defer func(method string) { *quantileMethod = method }(*quantileMethod)
+	var fails []string
+	for _, method := range quantileMethods {
+		*quantileMethod = method
+		for _, f := range checkMethod(trials) {
+			fails = append(fails, "-quantile-method "+method+": "+f)
+		}
+	}
+	return fails
+}
+
+// CheckMethod checks the properties of checkQuantiles
+// for the current -quantile-method.
+func checkMethod(trials int) []string {
+	const tolerance = 1e-9
+	var fails []string
+	fail := func(vs []float64, format string, args ...interface{}) {
+		fails = append(fails, fmt.Sprintf("%v: ", vs)+fmt.Sprintf(format, args...))
+	}
+	// Types 1, 3, and 4 treat the low and high ends differently.
+	symmetric := *quantileMethod != "1" && *quantileMethod != "3" && *quantileMethod != "4"
+	rng := rand.New(rand.NewSource(1))
+	for t := 0; t < trials && len(fails) < 10; t++ {
+		vs := make([]float64, 1+rng.Intn(40))
+		for i := range vs {
+			// Draw from few distinct values some of the time to get ties.
+			if t%2 == 0 {
+				vs[i] = float64(rng.Intn(5))
+			} else {
+				vs[i] = rng.NormFloat64() * 100
+			}
+		}
+		orig := append([]float64(nil), vs...)
+		got := summary(vs)
+		for i := range vs {
+			if vs[i] != orig[i] {
+				fail(orig, "modified to %v", vs)
+				break
+			}
+		}
+		for i := 1; i < len(got); i++ {
+			if got[i] < got[i-1] {
+				fail(vs, "out of order: %v", got)
+			}
+		}
+		if want, ok := reference(vs); ok {
+			for i := range got {
+				if math.Abs(got[i]-want[i]) > tolerance {
+					fail(vs, "got %v, reference %v", got, want)
+					break
+				}
+			}
+		}
+		shuffled := append([]float64(nil), vs...)
+		rng.Shuffle(len(shuffled), func(i, j int) { shuffled[i], shuffled[j] = shuffled[j], shuffled[i] })
+		if s := summary(shuffled); s != got {
+			fail(vs, "got %v, permuted %v", got, s)
+		}
+		neg := make([]float64, len(vs))
+		for i, v := range vs {
+			neg[i] = -v
+		}
+		n := summary(neg)
+		for i := range got {
+			if !symmetric {
+				break
+			}
+			if math.Abs(got[i]+n[len(n)-1-i]) > tolerance {
+				fail(vs, "got %v, negated %v", got, n)
+				break
+			}
+		}
+	}
+	return fails
+}
+
+// Summary returns the five-number summary of the values as an array.
+func summary(vs []float64) [5]float64 {
+	var s [5]float64
+	s[0], s[1], s[2], s[3], s[4] = stats5(vs)
+	return s
+}
+
+// Reference returns the five-number summary of the values
+// by a reference implementation of the current -quantile-method,
+// or false if it has none.
+func reference(vs []float64) ([5]float64, bool) {
+	switch *quantileMethod {
+	case "tukey":
+		return fivenum(vs), true
+	case "2", "7", "8":
+		x := append([]float64(nil), vs...)
+		sort.Float64s(x)
+		s := [5]float64{x[0], 0, 0, 0, x[len(x)-1]}
+		for i, p := range []float64{0.25, 0.5, 0.75} {
+			s[i+1] = hyndmanFan(x, p, *quantileMethod)
+		}
+		return s, true
+	}
+	return [5]float64{}, false
+}
+
+// HyndmanFan is a reference implementation of the p-quantiles
+// of Hyndman-Fan types 2, 7, and 8 of sorted values,
+// transcribed from the definitions of their paper.
+// Type 2 is the order statistic at np, rounded up,
+// or the average of the two around it if np is an integer.
+// Types 7 and 8 interpolate between the order statistics whose
+// plotting positions p_k are (k-1)/(n-1) and (k-1/3)/(n+1/3).
+func hyndmanFan(x []float64, p float64, typ string) float64 {
+	n := float64(len(x))
+	if typ == "2" {
+		np := n * p
+		if k := math.Round(np); math.Abs(np-k) < 1e-9 {
+			return (x[int(math.Max(k, 1))-1] + x[int(math.Min(k+1, n))-1]) / 2
+		}
+		return x[int(math.Ceil(np))-1]
+	}
+	pk := func(k float64) float64 { return (k - 1) / (n - 1) }
+	if typ == "8" {
+		pk = func(k float64) float64 { return (k - 1.0/3) / (n + 1.0/3) }
+	}
+	if n == 1 || p <= pk(1) {
+		return x[0]
+	}
+	if p >= pk(n) {
+		return x[len(x)-1]
+	}
+	k := 1.0
+	for pk(k+1) < p {
+		k++
+	}
+	w := (p - pk(k)) / (pk(k+1) - pk(k))
+	return x[int(k)-1] + w*(x[int(k)]-x[int(k)-1])
+}
+
+// Fivenum is a reference implementation of Tukey's five-number summary,
+// transcribed from R's fivenum, using insertion sort and 1-based depths.
+func fivenum(vs []float64) [5]float64 {
+	x := append([]float64(nil), vs...)
+	for i := 1; i < len(x); i++ {
+		for j := i; j > 0 && x[j] < x[j-1]; j-- {
+			x[j], x[j-1] = x[j-1], x[j]
+		}
+	}
+	n := float64(len(x))
+	n4 := math.Floor((n+3)/2) / 2
+	d := [5]float64{1, n4, (n + 1) / 2, n + 1 - n4, n}
+	var s [5]float64
+	for i, di := range d {
+		s[i] = 0.5 * (x[int(math.Floor(di))-1] + x[int(math.Ceil(di))-1])
+	}
+	return s
+}
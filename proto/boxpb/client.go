@@ -0,0 +1,254 @@
+// Package boxpb is a Go client of the gRPC service of box serve,
+// defined in proto/box.proto.
+//
+// It is written by hand, not generated by protoc:
+// like the server, it speaks gRPC over the HTTP/2 support of net/http
+// and encodes the messages itself, so that it needs only the standard library.
+// Programs that already use grpc-go can instead generate a client
+// from proto/box.proto, with which the server works the same.
+//
+// A program plots its input with Plot:
+//
+//	c := boxpb.NewClient("http://localhost:8080")
+//	out, err := c.Plot(ctx, &boxpb.PlotRequest{
+//		Input: input,
+//		Flags: map[string]string{"o": "svg", "t": "Latency"},
+//	})
+//
+// or streams samples with StreamSamples, Send, and CloseAndRecv.
+package boxpb
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PlotRequest is the request of the Plot method.
+type PlotRequest struct {
+	// Input is the data, in any of the input formats of box.
+	Input []byte
+	// Flags are box flags by name, without the leading -,
+	// such as {"t": "Latency", "mean-ci": "true"}.
+	Flags map[string]string
+}
+
+// Samples is a message of the stream of the StreamSamples method.
+type Samples struct {
+	// Flags are box flags, as for PlotRequest.
+	// Those of every message of a stream are applied, in order.
+	Flags map[string]string
+	// Name is the name of the data set.
+	Name string
+	// Values are the values of the data set.
+	Values []float64
+}
+
+// PlotResponse is the response of both methods.
+type PlotResponse struct {
+	// Output is the output of box.
+	Output []byte
+}
+
+// An Error is a gRPC status other than OK returned by the server.
+type Error struct {
+	// Code is the gRPC status code, such as 3 for INVALID_ARGUMENT
+	// or 8 for RESOURCE_EXHAUSTED.
+	Code    int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("box: gRPC status %d: %s", e.Code, e.Message)
+}
+
+// A Client calls the methods of the Box service of a server.
+type Client struct {
+	// URL is the base URL of the server, such as http://localhost:8080.
+	URL string
+	// Token, if non-empty, is sent as a bearer token,
+	// for a server run with -tokens or -token-file.
+	Token string
+	// HTTP is the HTTP client of the calls.
+	// It must speak HTTP/2, as the default of NewClient does,
+	// over TLS for https URLs and unencrypted for http URLs.
+	HTTP *http.Client
+}
+
+// NewClient returns a Client of the server at the base URL
+// with an HTTP client that speaks HTTP/2, with or without TLS.
+func NewClient(url string) *Client {
+	protocols := new(http.Protocols)
+	protocols.SetHTTP2(true)
+	protocols.SetUnencryptedHTTP2(true)
+	return &Client{URL: url, HTTP: &http.Client{Transport: &http.Transport{Protocols: protocols}}}
+}
+
+// Plot plots the input, like a POST to /v1/plot.
+func (c *Client) Plot(ctx context.Context, req *PlotRequest) (*PlotResponse, error) {
+	msg := appendFlags(nil, 2, req.Flags)
+	msg = appendBytes(msg, 1, req.Input)
+	return c.call(ctx, "Plot", bytes.NewReader(frame(msg)))
+}
+
+// A SampleStream is the client stream of a StreamSamples call.
+type SampleStream struct {
+	w    *io.PipeWriter
+	resp chan result
+}
+
+type result struct {
+	resp *PlotResponse
+	err  error
+}
+
+// StreamSamples begins a StreamSamples call, which plots the samples sent on the stream
+// when it is closed by CloseAndRecv.
+// Samples with the same name are merged into one data set.
+func (c *Client) StreamSamples(ctx context.Context) (*SampleStream, error) {
+	r, w := io.Pipe()
+	s := &SampleStream{w: w, resp: make(chan result, 1)}
+	go func() {
+		resp, err := c.call(ctx, "StreamSamples", r)
+		r.CloseWithError(errors.New("box: StreamSamples call ended"))
+		s.resp <- result{resp, err}
+	}()
+	return s, nil
+}
+
+// Send sends samples on the stream.
+func (s *SampleStream) Send(m *Samples) error {
+	msg := appendFlags(nil, 1, m.Flags)
+	msg = appendBytes(msg, 2, []byte(m.Name))
+	values := make([]byte, 0, 8*len(m.Values))
+	for _, v := range m.Values {
+		values = binary.LittleEndian.AppendUint64(values, math.Float64bits(v))
+	}
+	msg = appendBytes(msg, 3, values)
+	_, err := s.w.Write(frame(msg))
+	return err
+}
+
+// CloseAndRecv closes the stream and returns the response.
+func (s *SampleStream) CloseAndRecv() (*PlotResponse, error) {
+	s.w.Close()
+	r := <-s.resp
+	return r.resp, r.err
+}
+
+// Call calls a method with a request body of framed messages
+// and returns its response.
+func (c *Client) call(ctx context.Context, method string, body io.Reader) (*PlotResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(c.URL, "/")+"/box.v1.Box/"+method, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/grpc")
+	req.Header.Set("TE", "trailers")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	hc := c.HTTP
+	if hc == nil {
+		hc = NewClient(c.URL).HTTP
+	}
+	resp, err := hc.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("box: HTTP status %s: %s", resp.Status, bytes.TrimSpace(data))
+	}
+	var hdr [5]byte
+	var msg []byte
+	if _, err := io.ReadFull(resp.Body, hdr[:]); err == nil {
+		msg = make([]byte, binary.BigEndian.Uint32(hdr[1:]))
+		if _, err := io.ReadFull(resp.Body, msg); err != nil {
+			return nil, err
+		}
+	} else if err != io.EOF {
+		return nil, err
+	}
+	io.Copy(io.Discard, resp.Body)
+	if code, _ := strconv.Atoi(resp.Trailer.Get("Grpc-Status")); code != 0 {
+		text, _ := url.PathUnescape(resp.Trailer.Get("Grpc-Message"))
+		return nil, &Error{Code: code, Message: text}
+	}
+	out, err := decodeOutput(msg)
+	if err != nil {
+		return nil, err
+	}
+	return &PlotResponse{Output: out}, nil
+}
+
+// Frame returns a message with the length prefix of an uncompressed gRPC message.
+func frame(msg []byte) []byte {
+	buf := make([]byte, 5, 5+len(msg))
+	binary.BigEndian.PutUint32(buf[1:], uint32(len(msg)))
+	return append(buf, msg...)
+}
+
+// AppendBytes appends a length-delimited field to a protocol buffer message.
+func appendBytes(buf []byte, field int, data []byte) []byte {
+	buf = binary.AppendUvarint(buf, uint64(field)<<3|2)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	return append(buf, data...)
+}
+
+// AppendFlags appends a map<string, string> field, in key order.
+func appendFlags(buf []byte, field int, flags map[string]string) []byte {
+	var keys []string
+	for k := range flags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		entry := appendBytes(nil, 1, []byte(k))
+		entry = appendBytes(entry, 2, []byte(flags[k]))
+		buf = appendBytes(buf, field, entry)
+	}
+	return buf
+}
+
+// DecodeOutput returns field 1 of a PlotResponse message.
+func decodeOutput(msg []byte) ([]byte, error) {
+	var out []byte
+	for len(msg) > 0 {
+		key, n := binary.Uvarint(msg)
+		if n <= 0 {
+			return nil, errors.New("box: bad protocol buffer key")
+		}
+		msg = msg[n:]
+		var data []byte
+		switch key & 7 {
+		case 0:
+			if _, n = binary.Uvarint(msg); n <= 0 {
+				return nil, errors.New("box: bad protocol buffer varint")
+			}
+			msg = msg[n:]
+		case 2:
+			l, n := binary.Uvarint(msg)
+			if n <= 0 || uint64(len(msg)-n) < l {
+				return nil, errors.New("box: bad protocol buffer length")
+			}
+			data, msg = msg[n:n+int(l)], msg[n+int(l):]
+		default:
+			return nil, fmt.Errorf("box: unexpected protocol buffer wire type %d", key&7)
+		}
+		if key>>3 == 1 {
+			out = data
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// QuantileMethods are the -quantile-method values, in order:
+// Tukey's hinges, and the Hyndman-Fan types 1 to 9 of R's quantile function.
+var quantileMethods = []string{"tukey", "1", "2", "3", "4", "5", "6", "7", "8", "9"}
+
+// CheckQuantileMethod returns an error if -quantile-method is not a known method,
+// or if it is one that -exact cannot compute.
+func checkQuantileMethod() error {
+	t, ok := quantileType(*quantileMethod)
+	if !ok {
+		return fmt.Errorf("Unknown -quantile-method: %s", *quantileMethod)
+	}
+	if *exact && t != 0 {
+		return fmt.Errorf("-exact computes Tukey's hinges, not -quantile-method %s", *quantileMethod)
+	}
+	return nil
+}
+
+// QuantileType returns the Hyndman-Fan type of a -quantile-method,
+// or 0 for tukey, and whether the method is known.
+func quantileType(method string) (int, bool) {
+	if method == "tukey" {
+		return 0, true
+	}
+	t, err := strconv.Atoi(method)
+	return t, err == nil && t >= 1 && t <= 9
+}
+
+// QuantileMethodOf returns the boxplot.Method of -quantile-method,
+// which checkQuantileMethod has checked.
+func quantileMethodOf() boxplot.Method {
+	t, _ := quantileType(*quantileMethod)
+	return boxplot.Method(t)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"unicode"
+)
+
+// ReadRecords reads the record token format:
+// a sequence of records of the form <name>: <number>* ;
+// The name is everything up to the colon, less surrounding space,
+// so it may contain spaces or look like a number.
+// Records end with a semicolon, the end of the input,
+// or, if -line-records is set, the end of the line.
+// Every token in a record after the colon must be a number.
+func readRecords(r io.Reader) ([]box, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	ends := ";"
+	if *lineRecords {
+		ends = ";\n"
+	}
+	s := string(data)
+	var boxes []box
+	var arena floatArena
+	for {
+		s = strings.TrimLeftFunc(s, func(r rune) bool { return unicode.IsSpace(r) || r == ';' })
+		if s == "" {
+			return boxes, nil
+		}
+		i := strings.IndexByte(s, ':')
+		if i < 0 {
+			return nil, fmt.Errorf("missing ':' after record name %q", strings.Fields(s)[0])
+		}
+		name := strings.TrimSpace(s[:i])
+		if name == "" {
+			return nil, fmt.Errorf("missing record name")
+		}
+		s = s[i+1:]
+		end := strings.IndexAny(s, ends)
+		if end < 0 {
+			end = len(s)
+		}
+		vs := valueList{arena: &arena}
+		for _, f := range strings.Fields(s[:end]) {
+			if err := vs.parse(f); err != nil {
+				return nil, fmt.Errorf("record %s: bad value %q", name, f)
+			}
+		}
+		s = s[end:]
+		boxes = append(boxes, vs.box(name))
+	}
+}
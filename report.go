@@ -0,0 +1,466 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"io/ioutil"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Report runs the report command with the given arguments
+// and returns the exit status.
+//
+// Report compares two directories of result files, old and new.
+// Every file of each directory is read, as box reads its input,
+// and the data sets of the same name are merged, as in a collection.
+// The data sets of the same name in old and new are then compared:
+// by the change in their medians, Cohen's d effect size,
+// and the p-value of a Mann-Whitney U test,
+// or of Welch's t test for data sets read from sketches,
+// which have no raw values.
+// A change with a p-value below -alpha is an improvement or a regression;
+// larger values are worse, unless -higher-better is set.
+//
+// The report is a self-contained HTML page, written to -o,
+// with a summary of the regressions and improvements,
+// a table of every comparison, and a plot of each old and new pair.
+// The exit status is exitRegression if there are regressions.
+//
+// With -equivalence, a margin such as 5% of the old mean or an absolute 0.25,
+// report also tests whether the new mean of each data set is within the margin
+// of the old, by two one-sided tests at -alpha; see tost.
+// With -non-inferiority, a margin as for -equivalence, it instead tests
+// whether the new mean is less than the margin worse than the old,
+// by a one-sided test at -alpha; see nonInferiority.
+// It prints a PASS or FAIL line for each on standard output,
+// adds the results to the report, and the exit status is exitRegression
+// if any data set fails the test, whatever the other tests find,
+// so that a significant change within the margin passes.
+func report(args []string) int {
+	fs := flag.NewFlagSet("report", flag.ContinueOnError)
+	out := fs.String("o", "report.html", "output `file`")
+	alpha := fs.Float64("alpha", 0.05, "significance level of the tests")
+	higherBetter := fs.Bool("higher-better", false, "treat increases as improvements")
+	equiv := fs.String("equivalence", "", "`margin` within which to test that the new means are equivalent to the old: a percentage of the old mean, or an absolute value")
+	nonInf := fs.String("non-inferiority", "", "test that the new means are less than this `margin` worse than the old: a percentage of the old mean, or an absolute value")
+	parseFlags(fs, args)
+	// The directories may come before the flags, as in box report old/ new/ -o r.html.
+	var dirs []string
+	for fs.NArg() > 0 && len(dirs) < 2 {
+		dirs = append(dirs, fs.Arg(0))
+		parseFlags(fs, fs.Args()[1:])
+	}
+	var rel bool
+	var margin float64
+	var err error
+	// Claim is what the -equivalence or -non-inferiority test shows if it passes.
+	var claim string
+	switch {
+	case *equiv != "" && *nonInf != "":
+		err = fmt.Errorf("-equivalence and -non-inferiority cannot be used together")
+	case *equiv != "":
+		if rel, margin, err = parseEffect(*equiv); err != nil {
+			err = fmt.Errorf("Bad -equivalence margin: %s", *equiv)
+		}
+		claim = "within ±" + *equiv
+	case *nonInf != "":
+		if rel, margin, err = parseEffect(*nonInf); err != nil {
+			err = fmt.Errorf("Bad -non-inferiority margin: %s", *nonInf)
+		}
+		claim = "less than " + *nonInf + " worse"
+	}
+	if len(dirs) != 2 || fs.NArg() > 0 || err != nil {
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "box report: %v\n", err)
+		}
+		fmt.Fprintln(os.Stderr, "usage: box report OLD NEW [-o report.html] [-alpha 0.05] [-higher-better] [-equivalence 5% | -non-inferiority 5%]")
+		return exitUsage
+	}
+	old, err := readResultDir(dirs[0])
+	var new []box
+	if err == nil {
+		new, err = readResultDir(dirs[1])
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box report: %v\n", err)
+		return exitParse
+	}
+	cs := compareAll(old, new, *alpha, *higherBetter)
+	switch {
+	case *equiv != "":
+		testEquivalence(cs, rel, margin, *alpha)
+	case *nonInf != "":
+		testNonInferiority(cs, rel, margin, *alpha, *higherBetter)
+	}
+	if claim != "" {
+		if err := writeEquivalence(os.Stdout, cs, claim); err != nil {
+			fmt.Fprintf(os.Stderr, "box report: %v\n", err)
+			return exitOutput
+		}
+	}
+	if err := writeReportFile(*out, dirs[0], dirs[1], claim, cs); err != nil {
+		fmt.Fprintf(os.Stderr, "box report: %v\n", err)
+		return exitOutput
+	}
+	for _, c := range cs {
+		if claim != "" && c.equiv != nil && !c.equiv.pass || claim == "" && c.verdict == "regression" {
+			return exitRegression
+		}
+	}
+	return exitOK
+}
+
+// ReadResultDir reads every regular file of a directory,
+// except hidden files, in name order,
+// and returns the summarized boxes of their data sets merged by name.
+func readResultDir(dir string) ([]box, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	cs := &collections{sets: make(map[string]*collection)}
+	for _, info := range infos {
+		if !info.Mode().IsRegular() || strings.HasPrefix(info.Name(), ".") {
+			continue
+		}
+		path := filepath.Join(dir, info.Name())
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := cs.append(dir, data); err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+	}
+	c, ok := cs.sets[dir]
+	if !ok {
+		return nil, fmt.Errorf("%s: no data sets", dir)
+	}
+	boxes := c.boxes()
+	summarize(boxes)
+	return boxes, nil
+}
+
+// A comparison is the comparison of the old and new data sets of a name.
+// Old or new is nil if the name is only in the other directory.
+type comparison struct {
+	name     string
+	old, new *box
+	// Delta is the change of the median relative to the old median.
+	delta float64
+	// D is Cohen's d: the difference of the means over the pooled standard deviation.
+	d float64
+	p float64
+	// Verdict is regression, improvement, or ~ for no significant change.
+	verdict string
+	// Equiv is the result of the -equivalence or -non-inferiority test, if any.
+	equiv *equivalence
+}
+
+// CompareAll compares the old and new boxes of each name,
+// in the order of the old boxes, followed by names only in new.
+func compareAll(old, new []box, alpha float64, higherBetter bool) []comparison {
+	news := make(map[string]*box)
+	for i := range new {
+		news[new[i].name] = &new[i]
+	}
+	var cs []comparison
+	seen := make(map[string]bool)
+	for i := range old {
+		o := &old[i]
+		seen[o.name] = true
+		c := comparison{name: o.name, old: o, new: news[o.name]}
+		if c.new != nil {
+			c.compare(alpha, higherBetter)
+		}
+		cs = append(cs, c)
+	}
+	for i := range new {
+		if !seen[new[i].name] {
+			cs = append(cs, comparison{name: new[i].name, new: &new[i]})
+		}
+	}
+	return cs
+}
+
+// Compare computes the statistics and verdict of a comparison of two boxes.
+func (c *comparison) compare(alpha float64, higherBetter bool) {
+	o, n := c.old, c.new
+	c.delta = (n.q2 - o.q2) / math.Abs(o.q2)
+	pooled := math.Sqrt(((float64(o.n)-1)*o.stddev*o.stddev + (float64(n.n)-1)*n.stddev*n.stddev) /
+		float64(o.n+n.n-2))
+	c.d = (n.mean - o.mean) / pooled
+	if o.values != nil && n.values != nil {
+		c.p = mannWhitney(o.values, n.values)
+	} else {
+		c.p = welch(*o, *n)
+	}
+	c.verdict = "~"
+	if c.p < alpha {
+		worse := n.q2 > o.q2
+		if higherBetter {
+			worse = !worse
+		}
+		c.verdict = "improvement"
+		if worse {
+			c.verdict = "regression"
+		}
+	}
+}
+
+// MannWhitney returns the two-sided p-value of the Mann-Whitney U test
+// of two samples, from the normal approximation
+// with a continuity and tie correction.
+// The approximation is rough for samples of fewer than about 8 values.
+func mannWhitney(a, b []float64) float64 {
+	type ranked struct {
+		v     float64
+		fromA bool
+	}
+	all := make([]ranked, 0, len(a)+len(b))
+	for _, v := range a {
+		all = append(all, ranked{v, true})
+	}
+	for _, v := range b {
+		all = append(all, ranked{v, false})
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].v < all[j].v })
+	var rankA, ties float64
+	for i := 0; i < len(all); {
+		j := i
+		for j < len(all) && all[j].v == all[i].v {
+			j++
+		}
+		rank := float64(i+j+1) / 2
+		for k := i; k < j; k++ {
+			if all[k].fromA {
+				rankA += rank
+			}
+		}
+		t := float64(j - i)
+		ties += t*t*t - t
+		i = j
+	}
+	na, nb := float64(len(a)), float64(len(b))
+	n := na + nb
+	u := rankA - na*(na+1)/2
+	sd := math.Sqrt(na * nb / 12 * (n + 1 - ties/(n*(n-1))))
+	if sd == 0 || math.IsNaN(sd) {
+		return 1
+	}
+	z := math.Max(math.Abs(u-na*nb/2)-0.5, 0) / sd
+	return math.Erfc(z / math.Sqrt2)
+}
+
+// Welch returns the two-sided p-value of Welch's t test
+// of the means of two boxes.
+func welch(a, b box) float64 {
+	if a.n < 2 || b.n < 2 {
+		return 1
+	}
+	se, df := welchSE(a, b)
+	if se == 0 {
+		if a.mean == b.mean {
+			return 1
+		}
+		return 0
+	}
+	t := (b.mean - a.mean) / se
+	return 2 * (1 - tCDF(math.Abs(t), df))
+}
+
+// WelchSE returns the standard error of the difference of the means of two boxes,
+// each of at least two values, and its Welch-Satterthwaite degrees of freedom.
+func welchSE(a, b box) (se, df float64) {
+	va, vb := a.stddev*a.stddev/float64(a.n), b.stddev*b.stddev/float64(b.n)
+	df = (va + vb) * (va + vb) / (va*va/float64(a.n-1) + vb*vb/float64(b.n-1))
+	return math.Sqrt(va + vb), df
+}
+
+// WriteReportFile writes the HTML report of the comparisons to a file.
+func writeReportFile(path, oldDir, newDir, claim string, cs []comparison) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := writeReport(f, oldDir, newDir, claim, cs); err != nil {
+		f.Close()
+		return fmt.Errorf("Write failed: %v", err)
+	}
+	return f.Close()
+}
+
+// A reportRow is a comparison formatted for the report.
+type reportRow struct {
+	Name, Old, New, Delta, D, P, Verdict string
+	// Equiv is the result of the -equivalence or -non-inferiority test, pass or fail, if any.
+	Equiv string
+	Plot  *reportPlot
+}
+
+// A reportPlot is the SVG coordinates of an old and new box pair.
+type reportPlot struct {
+	Boxes [2]reportBox
+	Ticks []reportTick
+}
+
+type reportBox struct {
+	Label string
+	// X0 and X1 are the sides of the box, C its center,
+	// and C0 and C1 the ends of its whisker caps.
+	X0, X1, C, C0, C1       float64
+	Min, Q1, Q2, Q3, Max, H float64
+}
+
+type reportTick struct {
+	Y     float64
+	Label string
+}
+
+// Dimensions of the report plots.
+const (
+	reportW, reportH = 260.0, 180.0
+	reportLeft       = 50.0
+	reportPad        = 10.0
+	reportBottom     = 20.0 // Room for the box labels.
+)
+
+// WriteReport writes the HTML report of the comparisons,
+// with the results of their equivalence tests if there is a claim
+// of -equivalence or -non-inferiority, as for writeEquivalence.
+func writeReport(w io.Writer, oldDir, newDir, claim string, cs []comparison) error {
+	var rows []reportRow
+	var regressions, improvements, failures []string
+	for _, c := range cs {
+		r := reportRow{Name: c.name, Old: "-", New: "-", Delta: "-", D: "-", P: "-"}
+		if c.old != nil {
+			r.Old = formatValue(c.old.q2)
+		}
+		if c.new != nil {
+			r.New = formatValue(c.new.q2)
+		}
+		switch {
+		case c.old == nil:
+			r.Verdict = "only in new"
+		case c.new == nil:
+			r.Verdict = "only in old"
+		default:
+			r.Delta = fmt.Sprintf("%+.1f%%", 100*c.delta)
+			r.D = formatValue(c.d)
+			r.P = formatValue(c.p)
+			r.Verdict = c.verdict
+			r.Plot = newReportPlot(*c.old, *c.new)
+		}
+		switch c.verdict {
+		case "regression":
+			regressions = append(regressions, c.name+" "+r.Delta)
+		case "improvement":
+			improvements = append(improvements, c.name+" "+r.Delta)
+		}
+		if e := c.equiv; e != nil {
+			r.Equiv = "pass"
+			if !e.pass {
+				r.Equiv = "fail"
+				failures = append(failures, c.name)
+			}
+		}
+		rows = append(rows, r)
+	}
+	return reportPage.Execute(w, struct {
+		Title                     string
+		Old, New                  string
+		Regressions, Improvements []string
+		Claim                     string
+		Failures                  []string
+		Rows                      []reportRow
+	}{*title, oldDir, newDir, regressions, improvements, claim, failures, rows})
+}
+
+// NewReportPlot returns the coordinates of the plot of an old and new box,
+// on a value axis spanning both.
+func newReportPlot(old, new box) *reportPlot {
+	lo, hi := math.Min(old.min, new.min), math.Max(old.max, new.max)
+	if lo == hi {
+		lo, hi = lo-1, hi+1
+	}
+	y := func(v float64) float64 {
+		return reportPad + (reportH-reportPad-reportBottom)*(hi-v)/(hi-lo)
+	}
+	p := &reportPlot{}
+	for i, b := range []box{old, new} {
+		c := reportLeft + (reportW-reportLeft)*(float64(i)+0.5)/2
+		p.Boxes[i] = reportBox{
+			Label: []string{"old", "new"}[i],
+			X0:    c - 20, X1: c + 20, C: c, C0: c - 10, C1: c + 10,
+			Min: y(b.min), Q1: y(b.q1), Q2: y(b.q2), Q3: y(b.q3), Max: y(b.max),
+			H: y(b.q1) - y(b.q3),
+		}
+	}
+	for i := 0; i <= 4; i++ {
+		v := lo + (hi-lo)*float64(i)/4
+		p.Ticks = append(p.Ticks, reportTick{Y: y(v), Label: formatValue(v)})
+	}
+	return p
+}
+
+var reportPage = template.Must(template.New("report").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{with .Title}}{{.}}{{else}}box report{{end}}</title>
+<style>
+body { font: 13px sans-serif; margin: 1em; }
+table { border-collapse: collapse; }
+th, td { padding: 0.2em 0.6em; text-align: right; border-bottom: 1px solid #ddd; }
+th:first-child, td:first-child { text-align: left; }
+.regression { color: #a00; }
+.improvement { color: #070; }
+.fail { color: #a00; }
+.pass { color: #070; }
+.plots { display: flex; flex-wrap: wrap; gap: 1em; }
+figure { margin: 0; }
+figcaption { text-align: center; }
+svg line, svg rect { stroke: black; fill: none; }
+svg text { font-size: 10px; }
+</style>
+</head>
+<body>
+<h2>{{with .Title}}{{.}}{{else}}box report{{end}}</h2>
+<p>Comparing {{.Old}} (old) with {{.New}} (new).</p>
+<h3>Summary</h3>
+{{if .Regressions}}<p class="regression">Regressions ({{len .Regressions}}): {{range $i, $r := .Regressions}}{{if $i}}, {{end}}{{$r}}{{end}}</p>
+{{else}}<p>No regressions.</p>
+{{end}}{{if .Improvements}}<p class="improvement">Improvements ({{len .Improvements}}): {{range $i, $r := .Improvements}}{{if $i}}, {{end}}{{$r}}{{end}}</p>
+{{end}}{{if .Claim}}{{if .Failures}}<p class="fail">Not shown to be {{.Claim}} ({{len .Failures}}): {{range $i, $f := .Failures}}{{if $i}}, {{end}}{{$f}}{{end}}</p>
+{{else}}<p>All {{.Claim}}.</p>
+{{end}}{{end}}<h3>Comparisons</h3>
+<table>
+<tr><th>name</th><th>old median</th><th>new median</th><th>change</th><th>Cohen's d</th><th>p</th><th></th>{{if $.Claim}}<th>{{$.Claim}}</th>{{end}}</tr>
+{{range .Rows}}<tr><td>{{.Name}}</td><td>{{.Old}}</td><td>{{.New}}</td><td>{{.Delta}}</td><td>{{.D}}</td><td>{{.P}}</td><td class="{{.Verdict}}">{{.Verdict}}</td>{{if $.Claim}}<td class="{{.Equiv}}">{{.Equiv}}</td>{{end}}</tr>
+{{end}}</table>
+<h3>Plots</h3>
+<div class="plots">
+{{range .Rows}}{{$name := .Name}}{{with .Plot}}<figure>
+<svg width="260" height="180">
+<line x1="50" y1="10" x2="50" y2="160"/>
+{{range .Ticks}}<text x="46" y="{{.Y}}" dy="3" text-anchor="end">{{.Label}}</text>
+{{end}}{{range .Boxes}}<rect x="{{.X0}}" y="{{.Q3}}" width="40" height="{{.H}}"/>
+<line x1="{{.X0}}" y1="{{.Q2}}" x2="{{.X1}}" y2="{{.Q2}}" stroke-width="2"/>
+<line x1="{{.C}}" y1="{{.Q1}}" x2="{{.C}}" y2="{{.Min}}"/>
+<line x1="{{.C}}" y1="{{.Q3}}" x2="{{.C}}" y2="{{.Max}}"/>
+<line x1="{{.C0}}" y1="{{.Min}}" x2="{{.C1}}" y2="{{.Min}}"/>
+<line x1="{{.C0}}" y1="{{.Max}}" x2="{{.C1}}" y2="{{.Max}}"/>
+<text x="{{.C}}" y="175" text-anchor="middle">{{.Label}}</text>
+{{end}}</svg>
+<figcaption>{{$name}}</figcaption>
+</figure>
+{{end}}{{end}}</div>
+</body>
+</html>
+`))
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// TestReadResultDirMixedFormats tests that readResultDir
+// detects the format of each file of the directory separately.
+func TestReadResultDirMixedFormats(t *testing.T) {
+	defer resetFlags()
+	dir := t.TempDir()
+	files := map[string]string{
+		"1.txt": "a 1 2 3\n",
+		"2.csv": "b,c\n1,2\n3,4\n5,6\n",
+	}
+	for name, data := range files {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), []byte(data), 0666); err != nil {
+			t.Fatal(err)
+		}
+	}
+	boxes, err := readResultDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]float64{"a": 2, "b": 3, "c": 4}
+	if len(boxes) != len(want) {
+		t.Errorf("got %d data sets, want %d", len(boxes), len(want))
+	}
+	for _, b := range boxes {
+		if m, ok := want[b.name]; !ok || b.n != 3 || b.q2 != m {
+			t.Errorf("data set %q: n=%d, median=%v", b.name, b.n, b.q2)
+		}
+	}
+	if *format != "auto" {
+		t.Errorf("-format is %q after reading, want auto", *format)
+	}
+}
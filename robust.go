@@ -0,0 +1,98 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// RobustTrim is the fraction of the values of each box at either end
+// that -robust-range leaves out of the value range.
+const robustTrim = 0.005
+
+// RobustGain is the fraction of the value scale by which
+// an end of the -robust-range must narrow it to be set.
+const robustGain = 0.1
+
+// SetRobustRange sets, with -robust-range, the ends of the value range
+// not pinned by -ymin or -ymax to those of the values of the boxes
+// without the lowest and highest robustTrim of each box,
+// so that a few extreme values, such as a long garbage collection pause,
+// do not squeeze the boxes into a sliver of the plot.
+// An end is only set if it narrows the range by at least robustGain of its scale,
+// so that the ordinary tails of the boxes are not cut off for little gain.
+// The values beyond an end are drawn at the edge of the range, as beyond -ymin and -ymax,
+// and counted in the notes of their boxes; see robustNotes.
+// The ends of boxes read from sketches are the quantiles of their sketches,
+// and those of boxes of summary statistics alone are their minimum and maximum.
+func setRobustRange(boxes []box) {
+	if !*robustRange {
+		return
+	}
+	min, max := minMax(boxes)
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, b := range boxes {
+		if b.n == 0 {
+			continue
+		}
+		blo, bhi := b.trimmedRange()
+		lo, hi = math.Min(lo, blo), math.Max(hi, bhi)
+	}
+	if !(min < max) {
+		return
+	}
+	tr := valueScale(min, max, 0, 1)
+	if math.IsNaN(rangeMin) && tr(lo) > robustGain && !(*logScale && *logZero != "symlog" && lo <= logFloor) {
+		rangeMin = lo
+	}
+	if math.IsNaN(rangeMax) && tr(hi) < 1-robustGain {
+		rangeMax = hi
+	}
+}
+
+// TrimmedRange returns the robustTrim and 1-robustTrim quantiles of a box,
+// by Hyndman-Fan type 7.
+func (b box) trimmedRange() (lo, hi float64) {
+	switch {
+	case len(b.values) > 0:
+		vs := append([]float64(nil), b.values...)
+		var ranks []int
+		for _, p := range []float64{robustTrim, 1 - robustTrim} {
+			j, _ := boxplot.QuantilePosition(len(vs), p, 7)
+			for _, i := range []float64{j, j + 1} {
+				ranks = append(ranks, int(math.Max(1, math.Min(float64(len(vs)), i)))-1)
+			}
+		}
+		boxplot.Select(vs, ranks)
+		return boxplot.Quantile(vs, robustTrim, 7), boxplot.Quantile(vs, 1-robustTrim, 7)
+	case b.digest != nil:
+		return b.digest.quantile(robustTrim), b.digest.quantile(1 - robustTrim)
+	}
+	return b.min, b.max
+}
+
+// RobustNotes returns, with -robust-range, notes of the number of values of a box
+// below and above the value range, drawn at its edges.
+func robustNotes(b box) []string {
+	if !*robustRange {
+		return nil
+	}
+	below, above := 0, 0
+	for _, v := range b.values {
+		switch {
+		case v < rangeMin:
+			below++
+		case v > rangeMax:
+			above++
+		}
+	}
+	var ns []string
+	if above > 0 {
+		ns = append(ns, fmt.Sprintf("%d above", above))
+	}
+	if below > 0 {
+		ns = append(ns, fmt.Sprintf("%d below", below))
+	}
+	return ns
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// RunPlotCmd, for -run, starts the -plotcmd with a pipe as its standard input,
+// calls plot to write the output of box to the pipe, as it would to standard output,
+// and then closes the pipe and waits for the command to exit,
+// so that box -run < data shows the plot without a pipeline to remember.
+// The command is run directly, not by a shell,
+// and its standard output and standard error are those of box.
+// Errors of box are printed as without -run, not sent to the command.
+func runPlotCmd(plot func(out io.Writer) error) error {
+	args := strings.Fields(*plotCmd)
+	if len(args) == 0 {
+		return withStatus(exitUsage, fmt.Errorf("-run needs a -plotcmd"))
+	}
+	cmd := exec.Command(args[0], args[1:]...)
+	cmd.Stdout, cmd.Stderr = os.Stdout, os.Stderr
+	w, err := cmd.StdinPipe()
+	if err == nil {
+		err = cmd.Start()
+	}
+	if err != nil {
+		return withStatus(exitOutput, fmt.Errorf("-run: %v", err))
+	}
+	err = plot(w)
+	w.Close()
+	// A command that fails, perhaps having closed the pipe early,
+	// explains a failure to write to it better than the failed write.
+	if werr := cmd.Wait(); werr != nil && (err == nil || exitStatus(err) == exitOutput) {
+		err = withStatus(exitOutput, fmt.Errorf("-run: %s: %v", args[0], werr))
+	}
+	return err
+}
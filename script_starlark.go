@@ -0,0 +1,166 @@
+//go:build starlark
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"go.starlark.net/starlark"
+)
+
+// A script is a loaded -script file.
+type script struct {
+	path    string
+	thread  *starlark.Thread
+	globals starlark.StringDict
+}
+
+// LoadScript loads and runs a Starlark -script file,
+// whose global functions are the hooks.
+func loadScript(path string) (*script, error) {
+	thread := &starlark.Thread{
+		Name:  "box",
+		Print: func(_ *starlark.Thread, msg string) { fmt.Fprintln(os.Stderr, msg) },
+	}
+	globals, err := starlark.ExecFile(thread, path, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &script{path: path, thread: thread, globals: globals}, nil
+}
+
+// Call calls the named hook with the arguments,
+// and returns false if the script does not define it.
+func (s *script) call(hook string, args ...starlark.Value) (starlark.Value, bool, error) {
+	fn, ok := s.globals[hook]
+	if !ok {
+		return nil, false, nil
+	}
+	v, err := starlark.Call(s.thread, fn, args, nil)
+	if err != nil {
+		return nil, true, fmt.Errorf("%s: %s: %v", s.path, hook, err)
+	}
+	return v, true, nil
+}
+
+// Ingest calls the ingest hook with the name and values of each box
+// that has raw values, replacing the values by those returned,
+// or dropping the box if the hook returns None.
+func (s *script) ingest(boxes []box) ([]box, error) {
+	if _, ok := s.globals["ingest"]; !ok {
+		return boxes, nil
+	}
+	var kept []box
+	for _, b := range boxes {
+		if b.values == nil {
+			kept = append(kept, b)
+			continue
+		}
+		elems := make([]starlark.Value, len(b.values))
+		for i, v := range b.values {
+			elems[i] = starlark.Float(v)
+		}
+		v, _, err := s.call("ingest", starlark.String(b.name), starlark.NewList(elems))
+		if err != nil {
+			return nil, err
+		}
+		if v == starlark.None {
+			continue
+		}
+		iter := starlark.Iterate(v)
+		if iter == nil {
+			return nil, fmt.Errorf("%s: ingest: %s returned %s, not a list", s.path, b.name, v.Type())
+		}
+		var vs []float64
+		var x starlark.Value
+		for iter.Next(&x) {
+			f, ok := starlark.AsFloat(x)
+			if !ok {
+				iter.Done()
+				return nil, fmt.Errorf("%s: ingest: %s returned a %s value", s.path, b.name, x.Type())
+			}
+			vs = append(vs, f)
+		}
+		iter.Done()
+		kept = append(kept, newBox(b.name, vs))
+	}
+	return kept, nil
+}
+
+// Annotate calls the annotate hook with the statistics of each box,
+// and sets the notes of the box to the string or strings returned.
+func (s *script) annotate(boxes []box) error {
+	for i := range boxes {
+		b := &boxes[i]
+		v, ok, err := s.call("annotate", scriptStats(*b))
+		if err != nil || !ok {
+			return err
+		}
+		if b.scriptNotes, err = scriptStrings(v); err != nil {
+			return fmt.Errorf("%s: annotate: %s: %v", s.path, b.name, err)
+		}
+	}
+	return nil
+}
+
+// Label calls the label hook with the statistics of each box,
+// and sets the name of the box to the string returned, unless it is None.
+func (s *script) label(boxes []box) error {
+	for i := range boxes {
+		b := &boxes[i]
+		v, ok, err := s.call("label", scriptStats(*b))
+		if err != nil || !ok {
+			return err
+		}
+		if v == starlark.None {
+			continue
+		}
+		name, ok := starlark.AsString(v)
+		if !ok {
+			return fmt.Errorf("%s: label: %s: returned %s, not a string", s.path, b.name, v.Type())
+		}
+		b.name = name
+	}
+	return nil
+}
+
+// ScriptStats returns a dict of the statistics of a box for the hooks.
+func scriptStats(b box) *starlark.Dict {
+	d := starlark.NewDict(9)
+	d.SetKey(starlark.String("name"), starlark.String(b.name))
+	d.SetKey(starlark.String("n"), starlark.MakeInt(b.n))
+	for _, kv := range []struct {
+		k string
+		v float64
+	}{{"min", b.min}, {"q1", b.q1}, {"median", b.q2}, {"q3", b.q3}, {"max", b.max}, {"mean", b.mean}, {"stddev", b.stddev}} {
+		d.SetKey(starlark.String(kv.k), starlark.Float(kv.v))
+	}
+	return d
+}
+
+// ScriptStrings returns the strings of a hook result
+// that is None, a string, or a list of strings.
+func scriptStrings(v starlark.Value) ([]string, error) {
+	if v == starlark.None {
+		return nil, nil
+	}
+	if s, ok := starlark.AsString(v); ok {
+		return []string{s}, nil
+	}
+	iter := starlark.Iterate(v)
+	if iter == nil {
+		return nil, fmt.Errorf("returned %s, not a string or list", v.Type())
+	}
+	defer iter.Done()
+	var ss []string
+	var x starlark.Value
+	for iter.Next(&x) {
+		s, ok := starlark.AsString(x)
+		if !ok {
+			return nil, fmt.Errorf("returned a %s in the list", x.Type())
+		}
+		ss = append(ss, s)
+	}
+	return ss, nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A backend is an output format exercised by selftest.
+type backend struct {
+	// Ext is the file extension of the golden outputs of the backend.
+	ext string
+	// Args are the flags that select the backend.
+	args []string
+	// Same reports whether an output matches the golden output.
+	same func(got, want []byte) bool
+	// Check, if non-nil, returns an error if an output is malformed.
+	check func(out []byte) error
+}
+
+// Backends are the backends exercised by selftest.
+var backends = []backend{
+	{ext: ".plot", same: bytes.Equal, check: checkPlot},
+	{ext: ".geometry.json", args: []string{"-geometry", "json"}, same: bytes.Equal},
+	{ext: ".plan", args: []string{"-plan"}, same: bytes.Equal},
+	{ext: ".html", args: []string{"-html"}, same: bytes.Equal},
+	{ext: ".svg", args: []string{"-o", "svg"}, same: bytes.Equal},
+	{ext: ".png", args: []string{"-o", "png"}, same: bytes.Equal},
+	{ext: ".eps", args: []string{"-o", "eps"}, same: bytes.Equal},
+	{ext: ".pic", args: []string{"-o", "pic"}, same: bytes.Equal},
+	{ext: ".gp", args: []string{"-o", "gnuplot"}, same: bytes.Equal},
+	{ext: ".vl.json", args: []string{"-o", "vega"}, same: bytes.Equal},
+	{ext: ".term", args: []string{"-o", "term"}, same: bytes.Equal},
+}
+
+// Selftest runs the selftest command with the given arguments
+// and returns the exit status.
+//
+// Selftest first checks properties of the quantile computations
+// over random inputs; see checkQuantiles.
+//
+// Each case of the corpus is a .txt file.
+// Its first line may be of the form #flags: <flag>*
+// giving flags for the case, and the rest is its input.
+// The output of each backend is compared to the golden file
+// with the name of the case and the extension of the backend,
+// and, for backends with a check, such as checkPlot for plot(1), checked itself.
+// A case without a golden file for a backend, such as -matrix for -o gnuplot,
+// is one that the backend cannot draw, and must be a usage error.
+func selftest(args []string) int {
+	fs := flag.NewFlagSet("selftest", flag.ContinueOnError)
+	update := fs.Bool("update", false, "rewrite the golden outputs")
+	parseFlags(fs, args)
+	dir := filepath.Join("testdata", "selftest")
+	if fs.NArg() > 0 {
+		dir = fs.Arg(0)
+	}
+	// The width of -o term output not written to a terminal is $COLUMNS,
+	// which the golden outputs have at 80.
+	os.Setenv("COLUMNS", "80")
+	cases, err := filepath.Glob(filepath.Join(dir, "*.txt"))
+	if err != nil || len(cases) == 0 {
+		fmt.Fprintf(os.Stderr, "box selftest: no cases in %s\n", dir)
+		return 1
+	}
+	failed := 0
+	const trials = 10000
+	for _, f := range checkQuantiles(trials) {
+		fmt.Printf("FAIL quantiles: %s\n", f)
+		failed++
+	}
+	for _, c := range cases {
+		data, err := ioutil.ReadFile(c)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "box selftest: %v\n", err)
+			return 1
+		}
+		var caseArgs []string
+		if bytes.HasPrefix(data, []byte("#flags:")) {
+			i := bytes.IndexByte(data, '\n')
+			if i < 0 {
+				i = len(data)
+			}
+			caseArgs = strings.Fields(string(data[len("#flags:"):i]))
+			data = data[i:]
+		}
+		for _, be := range backends {
+			got, err := render(data, append(caseArgs, be.args...))
+			golden := strings.TrimSuffix(c, ".txt") + be.ext
+			_, statErr := os.Stat(golden)
+			switch {
+			case exitStatus(err) == exitUsage && os.IsNotExist(statErr):
+			case err != nil:
+				fmt.Printf("FAIL %s: %v\n", golden, err)
+				failed++
+			case be.check != nil && be.check(got) != nil:
+				fmt.Printf("FAIL %s: malformed output: %v\n", golden, be.check(got))
+				failed++
+			case *update:
+				if err := ioutil.WriteFile(golden, got, 0666); err != nil {
+					fmt.Fprintf(os.Stderr, "box selftest: %v\n", err)
+					return 1
+				}
+			default:
+				want, err := ioutil.ReadFile(golden)
+				if err != nil || !be.same(got, want) {
+					fmt.Printf("FAIL %s: output differs from golden\n", golden)
+					failed++
+				}
+			}
+		}
+	}
+	if failed > 0 {
+		fmt.Printf("FAIL %d of %d\n", failed, len(cases)*len(backends))
+		return 1
+	}
+	fmt.Printf("ok %d cases, %d backends, %d quantile trials\n", len(cases), len(backends), trials)
+	return 0
+}
+
+// Render returns the output of box for an input with the given flags, and -q.
+// The flags are reset to their defaults beforehand.
+// The warnings of the cases, such as of censored values, are expected,
+// so -q keeps them from cluttering the results of selftest.
+func render(input []byte, args []string) ([]byte, error) {
+	resetFlags()
+	if err := flag.CommandLine.Parse(append([]string{"-q"}, args...)); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	err := run(bytes.NewReader(input), &out)
+	return out.Bytes(), err
+}
+
+// ResetFlags resets the flags, and the state read from them, to their defaults.
+// The flags of the testing package, in go test, are left alone.
+func resetFlags() {
+	flag.VisitAll(func(f *flag.Flag) {
+		if !strings.HasPrefix(f.Name, "test.") {
+			f.Value.Set(f.DefValue)
+		}
+	})
+	annotations = nil
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestSelftest tests that every case of testdata/selftest
+// matches its golden outputs, as box selftest does.
+func TestSelftest(t *testing.T) {
+	defer resetFlags()
+	t.Setenv("COLUMNS", "80")
+	out, err := os.Create(filepath.Join(t.TempDir(), "stdout"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	stdout := os.Stdout
+	defer func() { os.Stdout = stdout }()
+	os.Stdout = out
+	status := selftest(nil)
+	os.Stdout = stdout
+	if status != 0 {
+		results, _ := ioutil.ReadFile(out.Name())
+		t.Errorf("box selftest: exit status %d\n%s", status, results)
+	}
+}
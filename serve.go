@@ -0,0 +1,334 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+)
+
+// ServeFlags are the flags that requests to the server may set.
+// The others are refused, because they would give access to the server's files
+// or programs, such as -manifest, -trend, -script, and -plugin,
+// or make no sense for a request, such as -consume and -run.
+// A new flag is refused until it is added here.
+var serveFlags = map[string]bool{
+	"all-metrics":     true,
+	"approx":          true,
+	"autocorr":        true,
+	"axis":            true,
+	"bench":           true,
+	"budget":          true,
+	"captions":        true,
+	"ci-level":        true,
+	"csv":             true,
+	"dpi":             true,
+	"exact":           true,
+	"explain":         true,
+	"export":          true,
+	"format":          true,
+	"geometry":        true,
+	"group-by":        true,
+	"group-sep":       true,
+	"heat":            true,
+	"height":          true,
+	"horizontal":      true,
+	"html":            true,
+	"id-column":       true,
+	"in-place":        true,
+	"inset":           true,
+	"line-records":    true,
+	"lines":           true,
+	"lint":            true,
+	"log":             true,
+	"log-zero":        true,
+	"log2":            true,
+	"matrix":          true,
+	"mean-ci":         true,
+	"meta":            true,
+	"metric":          true,
+	"modes":           true,
+	"names":           true,
+	"notch":           true,
+	"o":               true,
+	"otlp-group":      true,
+	"pivot":           true,
+	"plan":            true,
+	"precision":       true,
+	"q":               true,
+	"quantile-method": true,
+	"robust-range":    true,
+	"runorder":        true,
+	"sep":             true,
+	"share-y":         true,
+	"sort":            true,
+	"stats":           true,
+	"stats-format":    true,
+	"style":           true,
+	"t":               true,
+	"text":            true,
+	"whisker-k":       true,
+	"whiskers":        true,
+	"width":           true,
+	"ymax":            true,
+	"ymin":            true,
+	"ytick-labels":    true,
+	"yticks":          true,
+}
+
+// Serve runs the serve command with the given arguments
+// and returns the exit status.
+//
+// Serve runs an HTTP server that plots data sent to it.
+// A POST to /v1/plot plots the request body,
+// with the flags given by the query parameters,
+// such as /v1/plot?t=Latency&mean-ci=true,
+// and responds with the output.
+// A POST to /v1/collections/<name>/data appends the data sets
+// of the request body to the named collection, and
+// a GET of /v1/collections/<name>/plot plots the collection so far;
+// see collections.
+// The server is also an OTLP/HTTP receiver:
+// a POST of an OTLP/JSON export request to /v1/traces or /v1/metrics
+// appends its spans or histograms to the -otlp-collection collection.
+// With -ui, the server also serves a web page at /
+// for entering data and choosing flags, with a live preview.
+//
+// The server also serves the gRPC service defined in proto/box.proto,
+// with the same flags and data as the HTTP API.
+//
+// The input of each request is limited, so that no client can exhaust
+// the memory of the server: bodies by -max-body, and data sets,
+// of requests and of collections, by -max-datasets and -max-values,
+// and plots by -max-pixels and -max-dpi.
+// Input beyond a limit is refused with status 413 and a JSON body
+// naming the limit; see httpError.
+// Every error response has a JSON body of the same shape.
+//
+// With -tls-cert and -tls-key, the server serves HTTPS.
+// With -tokens or -token-file, requests to /v1/ and gRPC requests must carry
+// one of the tokens in an Authorization: Bearer header, or gRPC metadata.
+// The web page itself needs no token, but its requests do,
+// so it has a field for entering one.
+func serve(args []string) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	addr := fs.String("addr", "localhost:8080", "address to listen on")
+	ui := fs.Bool("ui", false, "serve a web page for plotting at /")
+	ttl := fs.Duration("ttl", 24*time.Hour, "time after its last update that a collection expires")
+	tlsCert := fs.String("tls-cert", "", "TLS certificate `file`; serve HTTPS if set with -tls-key")
+	tlsKey := fs.String("tls-key", "", "TLS private key `file`")
+	tokenList := fs.String("tokens", "", "comma-separated bearer `tokens` required of API requests")
+	tokenFile := fs.String("token-file", "", "`file` of bearer tokens required of API requests, one per line")
+	otlp := fs.String("otlp-collection", "otlp", "`name` of the collection that OTLP data is appended to")
+	maxBody := fs.Int64("max-body", 32<<20, "largest request body in `bytes`, after decompression; 0 for no limit")
+	maxSets := fs.Int("max-datasets", 10000, "most data sets of a request or collection; 0 for no limit")
+	maxValues := fs.Int("max-values", 10000000, "most values of a data set of a request or collection; 0 for no limit")
+	maxPixels := fs.Int64("max-pixels", 4096*4096, "most `pixels` of the width by height of a plot; 0 for no limit")
+	maxDPI := fs.Int64("max-dpi", 1200, "largest -dpi of a plot; 0 for no limit")
+	parseFlags(fs, args)
+	if (*tlsCert == "") != (*tlsKey == "") {
+		fmt.Fprintln(os.Stderr, "box serve: -tls-cert and -tls-key must be set together")
+		return exitUsage
+	}
+	tokens, err := readTokens(*tokenList, *tokenFile)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box serve: %v\n", err)
+		return exitUsage
+	}
+	limits = serveLimits{body: *maxBody, sets: *maxSets, values: *maxValues, pixels: *maxPixels, dpi: *maxDPI}
+	cs := &collections{ttl: *ttl, sets: make(map[string]*collection)}
+	api := http.NewServeMux()
+	api.HandleFunc("/v1/plot", handlePlot)
+	api.Handle("/v1/collections/", cs)
+	api.HandleFunc("/v1/traces", cs.receiveOTLP(*otlp))
+	api.HandleFunc("/v1/metrics", cs.receiveOTLP(*otlp))
+	api.HandleFunc("/box.v1.Box/", handleGRPC)
+	mux := http.NewServeMux()
+	if len(tokens) > 0 {
+		mux.Handle("/v1/", requireToken(tokens, api))
+		mux.Handle("/box.v1.Box/", requireToken(tokens, api))
+	} else {
+		mux.Handle("/v1/", api)
+		mux.Handle("/box.v1.Box/", api)
+	}
+	if *ui {
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/" {
+				httpStatusError(w, http.StatusNotFound, "not found")
+				return
+			}
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			uiPage.Execute(w, nil)
+		})
+	}
+	server := &http.Server{Addr: *addr, Handler: mux, Protocols: new(http.Protocols)}
+	server.Protocols.SetHTTP1(true)
+	server.Protocols.SetHTTP2(true)
+	// gRPC clients connect without TLS using HTTP/2 with prior knowledge.
+	server.Protocols.SetUnencryptedHTTP2(true)
+	if *tlsCert != "" {
+		err = server.ListenAndServeTLS(*tlsCert, *tlsKey)
+	} else {
+		err = server.ListenAndServe()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "box serve: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// RenderMu serializes renders, since the flags are global.
+var renderMu sync.Mutex
+
+// HandlePlot plots the body of a POST request
+// with the flags given by its query parameters.
+func handlePlot(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		httpStatusError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	input, err := readBody(r.Body)
+	if err != nil {
+		httpError(w, err)
+		return
+	}
+	renderMu.Lock()
+	defer renderMu.Unlock()
+	if err := setQueryFlags(r.URL.Query()); err != nil {
+		httpError(w, err)
+		return
+	}
+	writePlot(w, func(out io.Writer) error { return run(bytes.NewReader(input), out) })
+}
+
+// SetQueryFlags resets the flags to their defaults
+// and sets those given by the query parameters.
+// Unlike parsing command-line flags, bad values are returned as errors,
+// as is a limitError for a plot larger than the limits.
+// The caller must hold renderMu.
+func setQueryFlags(query url.Values) error {
+	resetFlags()
+	for name, values := range query {
+		if flag.Lookup(name) == nil || !serveFlags[name] {
+			return fmt.Errorf("bad flag %s", name)
+		}
+		for _, v := range values {
+			if err := flag.Set(name, v); err != nil {
+				return fmt.Errorf("bad value %q for flag %s: %v", v, name, err)
+			}
+		}
+	}
+	return limits.checkSize()
+}
+
+// WritePlot calls plot to write output with the current flags
+// and writes the output as an HTTP response,
+// with its content type set from the flags.
+// The caller must hold renderMu.
+func writePlot(w http.ResponseWriter, plot func(io.Writer) error) {
+	var out bytes.Buffer
+	if err := plot(&out); err != nil {
+		httpError(w, err)
+		return
+	}
+	switch {
+	case *html:
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	case *geometry == "json":
+		w.Header().Set("Content-Type", "application/json")
+	case *outFormat == "svg":
+		w.Header().Set("Content-Type", "image/svg+xml")
+	case *outFormat == "png":
+		w.Header().Set("Content-Type", "image/png")
+	default:
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Write(out.Bytes())
+}
+
+var uiPage = template.Must(template.New("ui").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>box</title>
+<style>
+body { font: 13px sans-serif; margin: 1em; display: flex; gap: 1em; }
+#controls { width: 22em; }
+textarea { width: 100%; height: 20em; font-family: monospace; }
+label { display: block; margin: 0.3em 0; }
+iframe { width: 860px; height: 620px; border: 1px solid #ccc; }
+#error { color: #a00; white-space: pre-wrap; }
+</style>
+</head>
+<body>
+<div id="controls">
+<textarea id="data" placeholder="Paste data or drop a file here">linear 1 2 3 4 5 6
+exponential 2 4 8 16 32 64</textarea>
+<label>Token <input id="token" type="password"></label>
+<label>Title <input data-flag="t"></label>
+<label>Format <select data-flag="format">
+<option>auto</option><option>tokens</option><option>lines</option><option>records</option>
+<option>csv</option><option>tsv</option><option>tdigest</option><option>ddsketch</option>
+<option>hdr</option><option>jmh</option><option>pytest</option><option>hyperfine</option><option>criterion</option>
+<option>otlp</option>
+</select></label>
+<label>Sort <select data-flag="sort">
+<option value="">input order</option><option>name</option><option>n</option><option>median</option>
+<option>mean</option><option>cv</option><option>spread</option>
+</select></label>
+<label>Precision <input data-flag="precision" type="number" value="3"></label>
+<label><input data-flag="mean-ci" type="checkbox"> mean and confidence interval</label>
+<label><input data-flag="modes" type="checkbox"> mark multimodal data sets</label>
+<label><input data-flag="captions" type="checkbox"> sample count captions</label>
+<label><input data-flag="exact" type="checkbox"> exact integers</label>
+<div id="error"></div>
+</div>
+<iframe id="preview"></iframe>
+<script>
+const data = document.getElementById("data");
+const controls = document.querySelectorAll("[data-flag]");
+let timer = null;
+
+async function update() {
+	const q = new URLSearchParams({html: "true"});
+	for (const c of controls) {
+		const v = c.type === "checkbox" ? String(c.checked) : c.value;
+		if (v !== "" && v !== "false") q.set(c.dataset.flag, v);
+	}
+	const headers = {};
+	const token = document.getElementById("token").value;
+	if (token !== "") headers["Authorization"] = "Bearer " + token;
+	const resp = await fetch("/v1/plot?" + q, {method: "POST", body: data.value, headers: headers});
+	const text = await resp.text();
+	document.getElementById("error").textContent = resp.ok ? "" : JSON.parse(text).error;
+	if (resp.ok) document.getElementById("preview").srcdoc = text;
+}
+
+function schedule() {
+	clearTimeout(timer);
+	timer = setTimeout(update, 300);
+}
+
+data.addEventListener("input", schedule);
+document.getElementById("token").addEventListener("input", schedule);
+for (const c of controls) c.addEventListener("input", schedule);
+data.addEventListener("dragover", e => e.preventDefault());
+data.addEventListener("drop", async e => {
+	e.preventDefault();
+	if (e.dataTransfer.files.length > 0) {
+		data.value = await e.dataTransfer.files[0].text();
+		schedule();
+	}
+});
+update();
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestServeFlagsExist(t *testing.T) {
+	for name := range serveFlags {
+		if flag.Lookup(name) == nil {
+			t.Errorf("serveFlags has %s, which is not a flag", name)
+		}
+	}
+}
+
+func TestServeRefusesFileFlags(t *testing.T) {
+	defer resetFlags()
+	dir := t.TempDir()
+	for _, name := range []string{"manifest", "trend", "spec", "annotations", "style-file", "script", "plugin", "snapshot", "plotcmd", "run"} {
+		path := filepath.Join(dir, name)
+		query := url.Values{name: {path}}
+		if name == "run" {
+			query = url.Values{name: {"true"}}
+		}
+		r := httptest.NewRequest(http.MethodPost, "/v1/plot?"+query.Encode(), strings.NewReader("a 1 2 3"))
+		w := httptest.NewRecorder()
+		handlePlot(w, r)
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("-%s: status %d, want %d", name, w.Code, http.StatusBadRequest)
+		}
+		var body struct{ Error string }
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error == "" {
+			t.Errorf("-%s: body %q, want a JSON error", name, w.Body.String())
+		}
+		if _, err := os.Stat(path); err == nil {
+			t.Errorf("-%s: a request wrote %s", name, path)
+		}
+	}
+}
+
+func TestServePlot(t *testing.T) {
+	defer resetFlags()
+	r := httptest.NewRequest(http.MethodPost, "/v1/plot?t=Latency&o=svg", strings.NewReader("a 1 2 3"))
+	w := httptest.NewRecorder()
+	handlePlot(w, r)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "Latency") {
+		t.Errorf("status %d, body %q, want 200 and a plot titled Latency", w.Code, w.Body.String())
+	}
+}
+
+func TestServePlotSizeLimits(t *testing.T) {
+	defer resetFlags()
+	defer func(l serveLimits) { limits = l }(limits)
+	limits = serveLimits{pixels: 4096 * 4096, dpi: 1200}
+	for _, test := range []struct {
+		query string
+		limit string
+	}{
+		{"o=png&width=30000&height=30000", "max-pixels"},
+		{"o=png&dpi=1e9", "max-dpi"},
+		{"o=png&dpi=NaN", "max-dpi"},
+		{"o=png&width=4096&height=4096&dpi=1200", ""},
+	} {
+		r := httptest.NewRequest(http.MethodPost, "/v1/plot?"+test.query, strings.NewReader("a 1 2 3"))
+		w := httptest.NewRecorder()
+		handlePlot(w, r)
+		if test.limit == "" {
+			if w.Code != http.StatusOK {
+				t.Errorf("%s: status %d, want %d", test.query, w.Code, http.StatusOK)
+			}
+			continue
+		}
+		var body struct{ Limit string }
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || w.Code != http.StatusRequestEntityTooLarge || body.Limit != test.limit {
+			t.Errorf("%s: status %d, body %q, want %d and limit %s", test.query, w.Code, w.Body.String(), http.StatusRequestEntityTooLarge, test.limit)
+		}
+	}
+}
@@ -0,0 +1,362 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Compression is the t-digest compression parameter
+// used for the sketches that box exports.
+const compression = 100
+
+// A digest summarizes a distribution as a list of weighted centroids
+// sorted by their means, in the manner of Dunning's t-digest.
+type digest struct {
+	min, max  float64
+	centroids []centroid
+}
+
+type centroid struct {
+	mean, weight float64
+}
+
+// NewDigest returns a t-digest of a sorted slice of values.
+// Centroids are merged according to the k1 scale function,
+// so there are roughly compression of them.
+func newDigest(vs []float64, compression float64) *digest {
+	d := &digest{}
+	if len(vs) == 0 {
+		return d
+	}
+	d.min, d.max = vs[0], vs[len(vs)-1]
+	k := func(q float64) float64 { return compression / (2 * math.Pi) * math.Asin(2*q-1) }
+	n := float64(len(vs))
+	var c centroid
+	left := 0.0
+	for _, v := range vs {
+		if c.weight > 0 && k((left+c.weight+1)/n)-k(left/n) > 1 {
+			d.centroids = append(d.centroids, c)
+			left += c.weight
+			c = centroid{}
+		}
+		c.weight++
+		c.mean += (v - c.mean) / c.weight
+	}
+	d.centroids = append(d.centroids, c)
+	return d
+}
+
+// Count returns the total weight of the digest.
+func (d *digest) count() float64 {
+	n := 0.0
+	for _, c := range d.centroids {
+		n += c.weight
+	}
+	return n
+}
+
+// Quantile returns an estimate of the q-quantile of the digest.
+// Each centroid is taken to sit at the middle of its weight,
+// and values between centroids are linearly interpolated,
+// with the minimum and maximum pinned to the ends.
+func (d *digest) quantile(q float64) float64 {
+	if len(d.centroids) == 0 {
+		return math.NaN()
+	}
+	t := q * d.count()
+	prevPos, prevMean := 0.0, d.min
+	cum := 0.0
+	for _, c := range d.centroids {
+		pos := cum + c.weight/2
+		if t < pos {
+			return interpolate(t, prevPos, prevMean, pos, c.mean)
+		}
+		prevPos, prevMean = pos, c.mean
+		cum += c.weight
+	}
+	return interpolate(t, prevPos, prevMean, cum, d.max)
+}
+
+// Interpolate returns the y value at x on the line through (x0, y0) and (x1, y1).
+func interpolate(x, x0, y0, x1, y1 float64) float64 {
+	if x1 == x0 {
+		return y0
+	}
+	return y0 + (x-x0)/(x1-x0)*(y1-y0)
+}
+
+// DigestBox returns a box summarizing a digest.
+func digestBox(name string, d *digest) box {
+	b := box{name: name, digest: d}
+	if len(d.centroids) > 0 {
+		b.min, b.max = d.min, d.max
+		b.q1, b.q2, b.q3 = d.quantile(0.25), d.quantile(0.5), d.quantile(0.75)
+		b.n = int(math.Round(d.count()))
+		b.mean, b.stddev = d.meanStddev()
+	}
+	return b
+}
+
+// ReadSketches reads lines of the form <name> <base64 sketch>,
+// decoding each sketch with the given function.
+func readSketches(r io.Reader, decode func([]byte) (*digest, error)) ([]box, error) {
+	var boxes []box
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(nil, 64*1024*1024)
+	for scanner.Scan() {
+		fs := strings.Fields(scanner.Text())
+		if len(fs) == 0 {
+			continue
+		}
+		if len(fs) != 2 {
+			return nil, fmt.Errorf("expected <name> <sketch>, got %d fields", len(fs))
+		}
+		data, err := base64.StdEncoding.DecodeString(fs[1])
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fs[0], err)
+		}
+		d, err := decode(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", fs[0], err)
+		}
+		boxes = append(boxes, digestBox(fs[0], d))
+	}
+	return boxes, scanner.Err()
+}
+
+func readTDigests(r io.Reader) ([]box, error) { return readSketches(r, decodeTDigest) }
+
+func readDDSketches(r io.Reader) ([]box, error) { return readSketches(r, decodeDDSketch) }
+
+// WriteSketches writes each box as a line of the form <name> <base64 t-digest>.
+func writeSketches(boxes []box, w io.Writer) error {
+	for _, b := range boxes {
+		d := b.digest
+		if d == nil {
+			vs := append([]float64(nil), b.values...)
+			sort.Float64s(vs)
+			d = newDigest(vs, compression)
+		}
+		s := base64.StdEncoding.EncodeToString(encodeTDigest(d))
+		if _, err := fmt.Fprintf(w, "%s %s\n", b.name, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// The verbose t-digest encoding of the reference Java MergingDigest.
+const tdigestVerbose = 1
+
+// EncodeTDigest returns the verbose MergingDigest encoding of a digest:
+// big-endian encoding, min, max, compression, centroid count,
+// then the weight and mean of each centroid.
+func encodeTDigest(d *digest) []byte {
+	var buf bytes.Buffer
+	put := func(v interface{}) { binary.Write(&buf, binary.BigEndian, v) }
+	put(int32(tdigestVerbose))
+	put(d.min)
+	put(d.max)
+	put(float64(compression))
+	put(int32(len(d.centroids)))
+	for _, c := range d.centroids {
+		put(c.weight)
+		put(c.mean)
+	}
+	return buf.Bytes()
+}
+
+// DecodeTDigest decodes the verbose MergingDigest encoding of a t-digest.
+func decodeTDigest(data []byte) (*digest, error) {
+	r := bytes.NewReader(data)
+	var hdr struct {
+		Encoding    int32
+		Min, Max    float64
+		Compression float64
+		N           int32
+	}
+	if err := binary.Read(r, binary.BigEndian, &hdr); err != nil {
+		return nil, fmt.Errorf("t-digest header: %v", err)
+	}
+	if hdr.Encoding != tdigestVerbose {
+		return nil, fmt.Errorf("unsupported t-digest encoding %d", hdr.Encoding)
+	}
+	if hdr.N < 0 || int(hdr.N) > r.Len()/16 {
+		return nil, fmt.Errorf("bad t-digest centroid count %d", hdr.N)
+	}
+	d := &digest{min: hdr.Min, max: hdr.Max, centroids: make([]centroid, hdr.N)}
+	for i := range d.centroids {
+		c := &d.centroids[i]
+		binary.Read(r, binary.BigEndian, &c.weight)
+		binary.Read(r, binary.BigEndian, &c.mean)
+	}
+	return d, nil
+}
+
+// DecodeDDSketch decodes a DDSketch protocol buffer
+// with a logarithmic index mapping into a digest
+// with one centroid per non-empty bin.
+func decodeDDSketch(data []byte) (*digest, error) {
+	var gamma, offset, zeros float64
+	var pos, neg []centroid
+	err := protoFields(data, func(field int, v protoValue) error {
+		var err error
+		switch field {
+		case 1:
+			err = protoFields(v.bytes, func(field int, v protoValue) error {
+				switch field {
+				case 1:
+					gamma = v.float()
+				case 2:
+					offset = v.float()
+				case 3:
+					if v.varint != 0 {
+						return errors.New("unsupported DDSketch interpolation")
+					}
+				}
+				return nil
+			})
+		case 2:
+			pos, err = ddStore(v.bytes)
+		case 3:
+			neg, err = ddStore(v.bytes)
+		case 4:
+			zeros = v.float()
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if gamma <= 1 {
+		return nil, fmt.Errorf("bad DDSketch gamma %g", gamma)
+	}
+	value := func(i float64) float64 { return 2 * math.Pow(gamma, i-offset) / (1 + gamma) }
+	d := &digest{}
+	for _, c := range pos {
+		d.centroids = append(d.centroids, centroid{mean: value(c.mean), weight: c.weight})
+	}
+	for _, c := range neg {
+		d.centroids = append(d.centroids, centroid{mean: -value(c.mean), weight: c.weight})
+	}
+	if zeros > 0 {
+		d.centroids = append(d.centroids, centroid{mean: 0, weight: zeros})
+	}
+	sort.Slice(d.centroids, func(i, j int) bool { return d.centroids[i].mean < d.centroids[j].mean })
+	if len(d.centroids) > 0 {
+		d.min, d.max = d.centroids[0].mean, d.centroids[len(d.centroids)-1].mean
+	}
+	return d, nil
+}
+
+// DdStore decodes a DDSketch Store message
+// into centroids whose means are bin indices.
+func ddStore(data []byte) ([]centroid, error) {
+	var cs, contiguous []centroid
+	var start int64
+	err := protoFields(data, func(field int, v protoValue) error {
+		switch field {
+		case 1:
+			var c centroid
+			err := protoFields(v.bytes, func(field int, v protoValue) error {
+				switch field {
+				case 1:
+					c.mean = float64(zigzag(v.varint))
+				case 2:
+					c.weight = v.float()
+				}
+				return nil
+			})
+			cs = append(cs, c)
+			return err
+		case 2:
+			if v.wire == 1 {
+				contiguous = append(contiguous, centroid{weight: v.float()})
+				return nil
+			}
+			if len(v.bytes)%8 != 0 {
+				return errors.New("bad packed DDSketch bin counts")
+			}
+			for i := 0; i < len(v.bytes); i += 8 {
+				w := math.Float64frombits(binary.LittleEndian.Uint64(v.bytes[i:]))
+				contiguous = append(contiguous, centroid{weight: w})
+			}
+		case 3:
+			start = zigzag(v.varint)
+		}
+		return nil
+	})
+	for i, c := range contiguous {
+		if c.weight > 0 {
+			cs = append(cs, centroid{mean: float64(start + int64(i)), weight: c.weight})
+		}
+	}
+	return cs, err
+}
+
+// A protoValue is the value of a protocol buffer field.
+type protoValue struct {
+	wire   int
+	varint uint64
+	bytes  []byte
+}
+
+// Float returns the value of a fixed 64-bit field as a float64.
+func (v protoValue) float() float64 { return math.Float64frombits(v.varint) }
+
+// ZigZag decodes a zig-zag encoded signed integer.
+func zigzag(u uint64) int64 { return int64(u>>1) ^ -int64(u&1) }
+
+// ProtoFields calls f for each field of a protocol buffer message.
+// Fixed 64- and 32-bit values are returned in the varint field of protoValue.
+func protoFields(data []byte, f func(int, protoValue) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 {
+			return errors.New("bad protocol buffer key")
+		}
+		data = data[n:]
+		v := protoValue{wire: int(key & 7)}
+		switch v.wire {
+		case 0:
+			v.varint, n = binary.Uvarint(data)
+			if n <= 0 {
+				return errors.New("bad protocol buffer varint")
+			}
+			data = data[n:]
+		case 1:
+			if len(data) < 8 {
+				return errors.New("short protocol buffer fixed64")
+			}
+			v.varint = binary.LittleEndian.Uint64(data)
+			data = data[8:]
+		case 2:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || uint64(len(data)-n) < l {
+				return errors.New("bad protocol buffer length")
+			}
+			v.bytes = data[n : n+int(l)]
+			data = data[n+int(l):]
+		case 5:
+			if len(data) < 4 {
+				return errors.New("short protocol buffer fixed32")
+			}
+			v.varint = uint64(binary.LittleEndian.Uint32(data))
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported protocol buffer wire type %d", v.wire)
+		}
+		if err := f(int(key>>3), v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
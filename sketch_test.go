@@ -0,0 +1,163 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TDigestBlob returns the verbose MergingDigest encoding
+// of centroids of weight 1 at the given means, written out field by field.
+func tdigestBlob(min, max float64, means ...float64) []byte {
+	var buf bytes.Buffer
+	put := func(v interface{}) { binary.Write(&buf, binary.BigEndian, v) }
+	put(int32(1))
+	put(min)
+	put(max)
+	put(float64(100))
+	put(int32(len(means)))
+	for _, m := range means {
+		put(float64(1))
+		put(m)
+	}
+	return buf.Bytes()
+}
+
+// DdsketchBlob returns a DDSketch message with gamma 2 and index offset 0,
+// so bin i holds the value 2·2ⁱ/3, with the positive bins from 0
+// counted contiguously, and the negative bins as a map of index to count.
+func ddsketchBlob(pos []float64, neg map[int64]float64) []byte {
+	fixed := func(buf []byte, field int, v float64) []byte {
+		buf = binary.AppendUvarint(buf, uint64(field)<<3|1)
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(v))
+	}
+	var mapping []byte
+	mapping = fixed(mapping, 1, 2)
+	mapping = fixed(mapping, 2, 0)
+	var packed []byte
+	for _, c := range pos {
+		packed = binary.LittleEndian.AppendUint64(packed, math.Float64bits(c))
+	}
+	var negStore []byte
+	for i, c := range neg {
+		var bin []byte
+		bin = binary.AppendUvarint(bin, 1<<3)
+		bin = binary.AppendUvarint(bin, uint64(i<<1^i>>63))
+		bin = fixed(bin, 2, c)
+		negStore = protoAppendBytes(negStore, 1, bin)
+	}
+	var sketch []byte
+	sketch = protoAppendBytes(sketch, 1, mapping)
+	sketch = protoAppendBytes(sketch, 2, protoAppendBytes(nil, 2, packed))
+	sketch = protoAppendBytes(sketch, 3, negStore)
+	return sketch
+}
+
+func sketchInput(name string, blob []byte) string {
+	return name + " " + base64.StdEncoding.EncodeToString(blob) + "\n"
+}
+
+// TestReadSketches tests that t-digests and DDSketches decode
+// to boxes with the quantiles of their centroids.
+func TestReadSketches(t *testing.T) {
+	for _, test := range []struct {
+		name  string
+		read  func(string) ([]box, error)
+		input string
+		want  [5]float64
+		n     int
+	}{
+		{
+			name:  "tdigest",
+			read:  func(s string) ([]box, error) { return readTDigests(strings.NewReader(s)) },
+			input: sketchInput("a", tdigestBlob(0, 6, 1, 2, 3, 4, 5)),
+			// Each centroid sits at the middle of its weight, and the ends at min and max.
+			want: [5]float64{0, 1.75, 3, 4.25, 6},
+			n:    5,
+		},
+		{
+			name:  "ddsketch",
+			read:  func(s string) ([]box, error) { return readDDSketches(strings.NewReader(s)) },
+			input: sketchInput("a", ddsketchBlob([]float64{1, 1, 1, 1, 1}, map[int64]float64{0: 1})),
+			// The bins are at -2/3, 2/3, 4/3, 8/3, 16/3, and 32/3.
+			want: [5]float64{-2.0 / 3, 2.0 / 3, 2, 16.0 / 3, 32.0 / 3},
+			n:    6,
+		},
+	} {
+		boxes, err := test.read(test.input)
+		if err != nil {
+			t.Errorf("%s: %v", test.name, err)
+			continue
+		}
+		if len(boxes) != 1 {
+			t.Errorf("%s: got %d boxes, want 1", test.name, len(boxes))
+			continue
+		}
+		b := boxes[0]
+		got := [5]float64{b.min, b.q1, b.q2, b.q3, b.max}
+		for i := range got {
+			if math.Abs(got[i]-test.want[i]) > 1e-12 {
+				t.Errorf("%s: five-number summary %v, want %v", test.name, got, test.want)
+				break
+			}
+		}
+		if b.name != "a" || b.n != test.n {
+			t.Errorf("%s: got box %s of %d values, want a of %d", test.name, b.name, b.n, test.n)
+		}
+	}
+}
+
+// TestWriteSketchesRoundTrip tests that the t-digests written by -export tdigest
+// read back as the same digests.
+func TestWriteSketchesRoundTrip(t *testing.T) {
+	var out bytes.Buffer
+	vs := []float64{5, 1, 4, 2, 3}
+	if err := writeSketches([]box{newBox("a", vs)}, &out); err != nil {
+		t.Fatal(err)
+	}
+	boxes, err := readTDigests(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(boxes) != 1 || boxes[0].name != "a" || boxes[0].n != 5 || boxes[0].min != 1 || boxes[0].max != 5 || boxes[0].q2 != 3 {
+		t.Errorf("got %+v, want a of 5 values from 1 to 5 with median 3", boxes)
+	}
+}
+
+// TestReadSketchesMalformed tests that malformed sketch input is an error.
+func TestReadSketchesMalformed(t *testing.T) {
+	blob := tdigestBlob(0, 6, 1, 2, 3)
+	badEncoding := append([]byte(nil), blob...)
+	binary.BigEndian.PutUint32(badEncoding, 2)
+	badCount := append([]byte(nil), blob...)
+	binary.BigEndian.PutUint32(badCount[28:], 1000)
+	for _, input := range []string{
+		"a\n",
+		"a b c\n",
+		"a !!!\n",
+		sketchInput("a", blob[:10]),
+		sketchInput("a", badEncoding),
+		sketchInput("a", badCount),
+	} {
+		if _, err := readTDigests(strings.NewReader(input)); err == nil {
+			t.Errorf("readTDigests(%q) succeeded, want an error", input)
+		}
+	}
+
+	var gamma1 []byte
+	gamma1 = binary.AppendUvarint(gamma1, 1<<3|1)
+	gamma1 = binary.LittleEndian.AppendUint64(gamma1, math.Float64bits(1))
+	for _, input := range []string{
+		sketchInput("a", []byte{0xff}),
+		sketchInput("a", protoAppendBytes(nil, 1, gamma1)),
+		sketchInput("a", append(protoAppendBytes(nil, 2, []byte{2<<3 | 2, 3, 1, 2, 3}), ddsketchBlob(nil, nil)...)),
+		sketchInput("a", protoAppendBytes(nil, 1, []byte{3 << 3, 1})),
+	} {
+		if _, err := readDDSketches(strings.NewReader(input)); err == nil {
+			t.Errorf("readDDSketches(%q) succeeded, want an error", input)
+		}
+	}
+}
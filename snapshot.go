@@ -0,0 +1,138 @@
+package main
+
+import (
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SnapshotTime is the layout of the time in the name of a snapshot file,
+// which sorts the names in the order they were written.
+const snapshotTime = "20060102T150405.000Z"
+
+// TakeSnapshot writes a snapshot of the plot of the boxes,
+// removes the oldest snapshots beyond the newest -keep,
+// and, for HTML output, rewrites the index of the snapshots.
+func takeSnapshot(boxes []box, t time.Time) error {
+	path, err := writeSnapshot(boxes, t)
+	if err != nil {
+		return fmt.Errorf("Snapshot failed: %v", err)
+	}
+	warnf("wrote snapshot %s", path)
+	paths, err := snapshots()
+	if err != nil {
+		return fmt.Errorf("Snapshot failed: %v", err)
+	}
+	if *keep > 0 && len(paths) > *keep {
+		for _, p := range paths[:len(paths)-*keep] {
+			if err := os.Remove(p); err != nil {
+				return fmt.Errorf("Snapshot failed: %v", err)
+			}
+		}
+		paths = paths[len(paths)-*keep:]
+	}
+	if *html {
+		if err := writeSnapshotIndex(paths); err != nil {
+			return fmt.Errorf("Snapshot failed: %v", err)
+		}
+	}
+	return nil
+}
+
+// WriteSnapshot writes the plot of the boxes to a new file,
+// named by the -snapshot prefix, the time in UTC, and the output format,
+// and returns the name of the file.
+func writeSnapshot(boxes []box, t time.Time) (string, error) {
+	path := *snapshot + "-" + t.UTC().Format(snapshotTime) + outputExt()
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0666)
+	if err != nil {
+		return "", err
+	}
+	if err := output(boxes, f, t); err != nil {
+		f.Close()
+		return "", err
+	}
+	return path, f.Close()
+}
+
+// Snapshots returns the names of the snapshot files
+// of the -snapshot prefix and the output format, oldest first.
+// Files that only look like snapshots are not included,
+// so -keep never removes them.
+func snapshots() ([]string, error) {
+	ext := outputExt()
+	matches, err := filepath.Glob(*snapshot + "-*" + ext)
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, p := range matches {
+		stamp := strings.TrimSuffix(strings.TrimPrefix(p, *snapshot+"-"), ext)
+		if _, err := time.Parse(snapshotTime, stamp); err == nil {
+			paths = append(paths, p)
+		}
+	}
+	return paths, nil
+}
+
+// OutputExt returns the file extension of the output requested by the flags.
+func outputExt() string {
+	switch {
+	case *export != "":
+		return "." + *export
+	case *geometry != "":
+		return "." + *geometry
+	case *plan:
+		return ".plan"
+	case *html:
+		return ".html"
+	}
+	return "." + *outFormat
+}
+
+// WriteSnapshotIndex writes index.html, beside the snapshots,
+// linking to each of them, newest first.
+func writeSnapshotIndex(paths []string) error {
+	var links []htmlLink
+	for i := len(paths) - 1; i >= 0; i-- {
+		name := filepath.Base(paths[i])
+		links = append(links, htmlLink{Text: name, Href: name})
+	}
+	f, err := os.Create(filepath.Join(filepath.Dir(*snapshot), "index.html"))
+	if err != nil {
+		return err
+	}
+	heading := *title
+	if heading == "" {
+		heading = "Snapshots"
+	}
+	if err := snapshotIndex.Execute(f, struct {
+		Title string
+		Links []htmlLink
+	}{heading, links}); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+var snapshotIndex = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font: 14px sans-serif; margin: 2em; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<ul>
+{{range .Links}}<li><a href="{{.Href}}">{{.Text}}</a></li>
+{{end}}</ul>
+</body>
+</html>
+`))
@@ -0,0 +1,9 @@
+//go:build !unix
+
+package main
+
+import "os"
+
+// NotifySnapshot returns nil on platforms without SIGUSR1,
+// so no snapshots are requested.
+func notifySnapshot() <-chan os.Signal { return nil }
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// SniffLen is the length of the input prefix examined by sniffFormat.
+const sniffLen = 64 * 1024
+
+// DetectFormat returns the name of the format of the input
+// and a reader that reads the entire input.
+func detectFormat(r io.Reader) (string, io.Reader) {
+	br := bufio.NewReaderSize(r, sniffLen)
+	prefix, err := br.Peek(sniffLen)
+	return sniffFormat(prefix, err != nil), br
+}
+
+// SniffFormat returns the name of the format of an input
+// guessed from its prefix; all is true if the prefix is the entire input.
+//
+// JSON inputs are told apart by the keys of the benchmark tools' results
+// and of OTLP export requests.
+// HdrHistogram output is recognized by its compressed histograms
+// or its percentile table header,
+// and t-digest sketches by the base64 of their encoding number.
+// Delimiter-separated values are recognized by
+// a tab or comma count that is the same, and non-zero, on every line,
+// except that tab-separated lines of a name followed by numbers
+// are read the same way, and more leniently, as the legacy token format.
+// Go benchmark output is recognized by a benchmark result line.
+// Records are recognized by a first record of the form <name>: <number>*.
+// Anything else is the legacy token format.
+func sniffFormat(prefix []byte, all bool) string {
+	s := string(prefix)
+	if !all {
+		if i := strings.LastIndexByte(s, '\n'); i >= 0 {
+			s = s[:i]
+		}
+	}
+	trimmed := strings.TrimSpace(s)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		switch {
+		case strings.Contains(s, `"resourceSpans"`) || strings.Contains(s, `"resourceMetrics"`):
+			return "otlp"
+		case strings.Contains(s, `"primaryMetric"`):
+			return "jmh"
+		case strings.Contains(s, `"benchmarks"`):
+			return "pytest"
+		case strings.Contains(s, `"results"`) && strings.Contains(s, `"command"`):
+			return "hyperfine"
+		case strings.Contains(s, `"reason"`) || strings.Contains(s, `"iters"`):
+			return "criterion"
+		}
+	}
+
+	var lines []string
+	for _, l := range strings.Split(s, "\n") {
+		if l = strings.TrimRight(l, "\r"); strings.TrimSpace(l) != "" {
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) == 0 {
+		return "tokens"
+	}
+	for _, l := range lines {
+		if _, _, ok := parseGoBench(l); ok {
+			return "gobench"
+		}
+	}
+	if strings.Contains(s, ",HIST") || strings.Contains(lines[0], "Percentile") {
+		return "hdr"
+	}
+	if fs := strings.Fields(lines[0]); len(fs) == 2 && len(fs[1]) > 5 &&
+		strings.HasPrefix(fs[1], "AAAAA") && strings.IndexByte("QRST", fs[1][5]) >= 0 {
+		return "tdigest"
+	}
+	if sameCount(lines, "\t") && !rowsOfNumbers(lines) {
+		return "tsv"
+	}
+	if sameCount(lines, ",") {
+		return "csv"
+	}
+	if i := strings.IndexByte(lines[0], ':'); i > 0 {
+		rest := lines[0][i+1:]
+		if j := strings.IndexByte(rest, ';'); j >= 0 {
+			rest = rest[:j]
+		}
+		ok := true
+		for _, f := range strings.Fields(rest) {
+			if _, err := strconv.ParseFloat(strings.TrimPrefix(f, ">"), 64); err != nil {
+				ok = false
+			}
+		}
+		if ok {
+			return "records"
+		}
+	}
+	return "tokens"
+}
+
+// SameCount returns whether every line contains the same, non-zero,
+// number of occurrences of the delimiter.
+func sameCount(lines []string, delim string) bool {
+	n := strings.Count(lines[0], delim)
+	for _, l := range lines[1:] {
+		if strings.Count(l, delim) != n {
+			return false
+		}
+	}
+	return n > 0
+}
+
+// RowsOfNumbers returns whether every field but the first of every line
+// is a number.
+func rowsOfNumbers(lines []string) bool {
+	for _, l := range lines {
+		fs := strings.Fields(l)
+		for _, f := range fs[1:] {
+			if _, err := strconv.ParseFloat(f, 64); err != nil {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// A spec declares a complete figure, for -spec:
+// its inputs, the flags with which they are read, transformed, grouped,
+// styled, and annotated, and the files to which it is written.
+//
+// A spec is a file in a subset of TOML:
+//
+//	# Encoding latency, before and after the new buffer pool.
+//	[input]
+//	files = ["old.txt", "new.txt"]
+//	basename = true
+//
+//	[transform]
+//	log = true
+//	whiskers = "p5,p95"
+//
+//	[style]
+//	style = ["old:color=gray,line=dashed", "new:color=blue"]
+//
+//	[annotate]
+//	t = "Encode latency"
+//	axis = true
+//	text = ["bottom:ns per op"]
+//
+//	[[output]]
+//	file = "latency.svg"
+//
+//	[[output]]
+//	file = "latency.png"
+//	width = 1600
+//	height = 1200
+//
+// The keys of the tables are the names of flags, and their values
+// are those of the flags: strings, numbers, or booleans.
+// An array sets a flag once for each of its elements,
+// as repeating the flag on the command line does.
+// The tables only organize a spec; a flag may be set in any of them, or before them.
+// The files key of the input table lists the input files.
+// Each output table names a file and may set flags for that output alone,
+// such as its size; its format is by the extension of the file,
+// .svg, .png, .eps, .pic, .gp, .vl.json, or .plot, unless it sets o or html.
+// Paths are relative to the current directory, as on the command line.
+// With -o or -html on the command line, the output tables are skipped,
+// and the figure is written on standard output in that format instead.
+//
+// Strings are in double quotes, with backslash escapes,
+// or in single quotes, without.
+// Keys are bare, tables are not nested, and arrays may span lines.
+type spec struct {
+	path  string
+	flags []specEntry
+	// Inputs are the input files of the input table.
+	inputs  []string
+	outputs []specOutput
+	// Given are the names of the flags set on the command line,
+	// which override the spec.
+	given map[string]bool
+}
+
+// A specEntry is a flag and its values in a spec.
+type specEntry struct {
+	name   string
+	values []string
+	// Line is the line number of the entry in the spec.
+	line int
+}
+
+// A specOutput is an output table of a spec.
+type specOutput struct {
+	file  string
+	flags []specEntry
+	line  int
+}
+
+// SpecTables are the names of the tables of a spec, other than output.
+var specTables = map[string]bool{
+	"input":     true,
+	"transform": true,
+	"group":     true,
+	"style":     true,
+	"annotate":  true,
+}
+
+// SpecFormats maps the extensions of the files of spec outputs to -o formats.
+var specFormats = []struct{ ext, format string }{
+	{".svg", "svg"},
+	{".png", "png"},
+	{".eps", "eps"},
+	{".pic", "pic"},
+	{".gp", "gnuplot"},
+	{".vl.json", "vega"},
+	{".plot", "plot"},
+}
+
+// ErrSpecOpen is returned by specValue for an array that is not closed by the end of its input.
+var errSpecOpen = errors.New("Unclosed array")
+
+var specKey = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// ReadSpec reads the spec at a path.
+func readSpec(path string) (*spec, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	sp := &spec{path: path}
+	table := ""
+	keys, tables := make(map[string]bool), make(map[string]bool)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if strings.HasPrefix(text, "[") {
+			name, err := specTable(text)
+			if err != nil {
+				return nil, fmt.Errorf("%s:%d: %v", path, line, err)
+			}
+			switch {
+			case name == "[output]":
+				sp.outputs = append(sp.outputs, specOutput{line: line})
+			case specTables[name]:
+			default:
+				return nil, fmt.Errorf("%s:%d: Unknown table: %s", path, line, name)
+			}
+			if tables[name] && name != "[output]" {
+				return nil, fmt.Errorf("%s:%d: Duplicate table: %s", path, line, name)
+			}
+			table, keys, tables[name] = name, make(map[string]bool), true
+			continue
+		}
+
+		eq := strings.Index(text, "=")
+		if eq < 0 {
+			return nil, fmt.Errorf("%s:%d: Missing = after key", path, line)
+		}
+		key := strings.TrimSpace(text[:eq])
+		if !specKey.MatchString(key) {
+			return nil, fmt.Errorf("%s:%d: Bad key: %s", path, line, key)
+		}
+		if keys[key] {
+			return nil, fmt.Errorf("%s:%d: Duplicate key: %s", path, line, key)
+		}
+		keys[key] = true
+		start, value := line, text[eq+1:]
+		values, err := specValue(value)
+		for err == errSpecOpen && scanner.Scan() {
+			line++
+			value += "\n" + scanner.Text()
+			values, err = specValue(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s: %v", path, start, key, err)
+		}
+
+		e := specEntry{name: key, values: values, line: start}
+		switch {
+		case table == "input" && key == "files":
+			sp.inputs = append(sp.inputs, values...)
+		case table == "[output]" && key == "file":
+			if len(values) != 1 {
+				return nil, fmt.Errorf("%s:%d: An output has one file", path, start)
+			}
+			sp.outputs[len(sp.outputs)-1].file = values[0]
+		case key == "spec" || flag.Lookup(key) == nil:
+			return nil, fmt.Errorf("%s:%d: Unknown flag: %s", path, start, key)
+		case table == "[output]":
+			// Each output restores the flags that it sets by their values as strings,
+			// which those of the repeatable flags are not.
+			if _, ok := flag.Lookup(key).Value.(flag.Getter); !ok {
+				return nil, fmt.Errorf("%s:%d: -%s cannot be set for one output", path, start, key)
+			}
+			o := &sp.outputs[len(sp.outputs)-1]
+			o.flags = append(o.flags, e)
+		default:
+			sp.flags = append(sp.flags, e)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	for _, o := range sp.outputs {
+		if o.file == "" {
+			return nil, fmt.Errorf("%s:%d: Output has no file", path, o.line)
+		}
+	}
+	return sp, nil
+}
+
+// SpecTable returns the name of the table of a table header line,
+// in brackets for an array of tables, such as [output].
+func specTable(text string) (string, error) {
+	if i := strings.Index(text, "#"); i >= 0 {
+		text = strings.TrimSpace(text[:i])
+	}
+	array := strings.HasPrefix(text, "[[")
+	name := strings.TrimPrefix(strings.TrimPrefix(text, "["), "[")
+	if array && !strings.HasSuffix(name, "]]") || !strings.HasSuffix(name, "]") {
+		return "", fmt.Errorf("Bad table: %s", text)
+	}
+	name = strings.TrimSpace(strings.TrimSuffix(strings.TrimSuffix(name, "]"), "]"))
+	if !specKey.MatchString(name) {
+		return "", fmt.Errorf("Bad table: %s", text)
+	}
+	if array {
+		return "[" + name + "]", nil
+	}
+	return name, nil
+}
+
+// SpecValue parses the value of a key, followed by an optional comment,
+// and returns it as the values of a flag:
+// the elements of an array, or else the value alone.
+// It returns errSpecOpen if the value is an array that s does not close.
+func specValue(s string) ([]string, error) {
+	s = strings.TrimLeft(s, " \t")
+	var values []string
+	var err error
+	if strings.HasPrefix(s, "[") {
+		s = s[1:]
+		for {
+			s = specSkip(s)
+			if s == "" {
+				return nil, errSpecOpen
+			}
+			if s[0] == ']' {
+				s = s[1:]
+				break
+			}
+			var v string
+			if v, s, err = specScalar(s); err != nil {
+				return nil, err
+			}
+			values = append(values, v)
+			s = specSkip(s)
+			switch {
+			case s == "":
+				return nil, errSpecOpen
+			case s[0] == ',':
+				s = s[1:]
+			case s[0] != ']':
+				return nil, fmt.Errorf("Missing , in array")
+			}
+		}
+	} else {
+		var v string
+		if v, s, err = specScalar(s); err != nil {
+			return nil, err
+		}
+		values = []string{v}
+	}
+	if s = strings.TrimLeft(s, " \t"); s != "" && s[0] != '#' {
+		return nil, fmt.Errorf("Unexpected text after value: %s", s)
+	}
+	return values, nil
+}
+
+// SpecSkip returns s without its leading white space, newlines, and comments.
+func specSkip(s string) string {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if !strings.HasPrefix(s, "#") {
+			return s
+		}
+		i := strings.IndexByte(s, '\n')
+		if i < 0 {
+			return ""
+		}
+		s = s[i:]
+	}
+}
+
+// SpecScalar parses a string, number, or boolean at the start of s,
+// and returns it as the value of a flag and the rest of s.
+func specScalar(s string) (string, string, error) {
+	switch {
+	case strings.HasPrefix(s, `"`):
+		for i := 1; i < len(s) && s[i] != '\n'; i++ {
+			switch s[i] {
+			case '\\':
+				i++
+			case '"':
+				v, err := strconv.Unquote(s[:i+1])
+				if err != nil {
+					return "", "", fmt.Errorf("Bad string: %s", s[:i+1])
+				}
+				return v, s[i+1:], nil
+			}
+		}
+		return "", "", fmt.Errorf("Unterminated string")
+	case strings.HasPrefix(s, "'"):
+		i := strings.IndexAny(s[1:], "'\n")
+		if i < 0 || s[1+i] != '\'' {
+			return "", "", fmt.Errorf("Unterminated string")
+		}
+		return s[1 : 1+i], s[2+i:], nil
+	}
+	i := strings.IndexAny(s, " \t\r\n,]#")
+	if i < 0 {
+		i = len(s)
+	}
+	v := s[:i]
+	if v == "true" || v == "false" {
+		return v, s[i:], nil
+	}
+	n := strings.Replace(v, "_", "", -1)
+	if _, err := strconv.ParseFloat(n, 64); err != nil || v == "" {
+		return "", "", fmt.Errorf("Bad value: %s", v)
+	}
+	return n, s[i:], nil
+}
+
+// Apply sets the flags of a spec, other than those of its outputs,
+// except the given flags, set on the command line, which override them,
+// and sets the input files to those of the spec
+// unless files are named on the command line.
+func (sp *spec) apply(given map[string]bool) error {
+	sp.given = given
+	if err := sp.set(sp.flags); err != nil {
+		return err
+	}
+	if len(inputFiles) == 0 {
+		inputFiles = sp.inputs
+	}
+	return nil
+}
+
+// Set sets flags of the spec that are not set on the command line.
+func (sp *spec) set(es []specEntry) error {
+	for _, e := range es {
+		if sp.given[e.name] {
+			continue
+		}
+		for _, v := range e.values {
+			if err := flag.Set(e.name, v); err != nil {
+				return fmt.Errorf("%s:%d: -%s: %v", sp.path, e.line, e.name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// WritesOutputs returns whether the figure is written to the outputs of the spec:
+// whether it has any, and neither -o nor -html is set on the command line,
+// which write the figure on standard output instead.
+func (sp *spec) writesOutputs() bool {
+	return len(sp.outputs) > 0 && !sp.given["o"] && !sp.given["html"]
+}
+
+// WriteOutputs writes the figure to each output of the spec in turn,
+// reading the input files again for each, or standard input once.
+// The flags set by an output, and those that drawing changes,
+// are restored before the next.
+func (sp *spec) writeOutputs() error {
+	var data []byte
+	if len(inputFiles) == 0 {
+		var err error
+		if data, err = ioutil.ReadAll(os.Stdin); err != nil {
+			return withStatus(exitParse, fmt.Errorf("Read failed: %v", err))
+		}
+	}
+	for _, o := range sp.outputs {
+		saved := make(map[string]string)
+		flag.VisitAll(func(f *flag.Flag) {
+			if _, ok := f.Value.(flag.Getter); ok {
+				saved[f.Name] = f.Value.String()
+			}
+		})
+		err := sp.write(o, bytes.NewReader(data))
+		flag.VisitAll(func(f *flag.Flag) {
+			if v, ok := saved[f.Name]; ok && f.Value.String() != v {
+				f.Value.Set(v)
+			}
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Write writes the figure to the file of an output.
+func (sp *spec) write(o specOutput, in io.Reader) error {
+	if err := sp.set(o.flags); err != nil {
+		return withStatus(exitUsage, err)
+	}
+	format := ""
+	for _, e := range o.flags {
+		if e.name == "o" {
+			format = e.values[len(e.values)-1]
+		}
+	}
+	if format == "" && !*html {
+		for _, f := range specFormats {
+			if format == "" && strings.HasSuffix(o.file, f.ext) {
+				format = f.format
+			}
+		}
+		if format == "" {
+			return withStatus(exitUsage, fmt.Errorf("%s:%d: No format for %s; set o", sp.path, o.line, o.file))
+		}
+		*outFormat = format
+	}
+	f, err := os.Create(o.file)
+	if err != nil {
+		return withStatus(exitOutput, fmt.Errorf("Write failed: %v", err))
+	}
+	err = run(in, f)
+	if cerr := f.Close(); err == nil && cerr != nil {
+		err = withStatus(exitOutput, fmt.Errorf("Write failed: %v", cerr))
+	}
+	return err
+}
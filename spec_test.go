@@ -0,0 +1,87 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// WriteSpecFiles writes a spec of an input file and an SVG output to dir,
+// and returns the paths of the spec and its output.
+func writeSpecFiles(t *testing.T, dir string) (string, string) {
+	input := filepath.Join(dir, "a.txt")
+	output := filepath.Join(dir, "latency.svg")
+	spec := filepath.Join(dir, "fig.toml")
+	text := "[input]\nfiles = [\"" + input + "\"]\n\n[annotate]\nt = \"Latency\"\n\n[[output]]\nfile = \"" + output + "\"\n"
+	if err := ioutil.WriteFile(input, []byte("a 1 2 3 4 5\n"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(spec, []byte(text), 0666); err != nil {
+		t.Fatal(err)
+	}
+	return spec, output
+}
+
+func TestSpecOutputs(t *testing.T) {
+	defer resetFlags()
+	defer func() { inputFiles = nil }()
+	resetFlags()
+	specFile, output := writeSpecFiles(t, t.TempDir())
+	sp, err := readSpec(specFile)
+	if err == nil {
+		err = sp.apply(map[string]bool{})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sp.writesOutputs() {
+		t.Fatal("writesOutputs() = false without -o, want true")
+	}
+	if err := sp.writeOutputs(); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, []byte("<svg")) || !bytes.Contains(data, []byte("Latency")) {
+		t.Errorf("%s is not an SVG plot titled Latency:\n%s", output, data)
+	}
+}
+
+// TestSpecCommandLineFormat tests that -o on the command line
+// writes the figure on standard output in that format,
+// and leaves the outputs of the spec alone.
+func TestSpecCommandLineFormat(t *testing.T) {
+	defer resetFlags()
+	defer func() { inputFiles = nil }()
+	resetFlags()
+	specFile, output := writeSpecFiles(t, t.TempDir())
+	if err := flag.Set("o", "term"); err != nil {
+		t.Fatal(err)
+	}
+	sp, err := readSpec(specFile)
+	if err == nil {
+		err = sp.apply(map[string]bool{"o": true})
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sp.writesOutputs() {
+		t.Fatal("writesOutputs() = true with -o term, want false")
+	}
+	var out bytes.Buffer
+	if err := run(strings.NewReader(""), &out); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "Latency") || !strings.Contains(out.String(), "┌") {
+		t.Errorf("output is not a terminal plot titled Latency:\n%s", out.String())
+	}
+	if _, err := os.Stat(output); err == nil {
+		t.Errorf("-o term wrote %s", output)
+	}
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// MeanStddev returns the mean and sample standard deviation of a digest,
+// treating each centroid as weight copies of its mean.
+func (d *digest) meanStddev() (mean, sd float64) {
+	n := d.count()
+	for _, c := range d.centroids {
+		mean += c.mean * c.weight
+	}
+	mean /= n
+	for _, c := range d.centroids {
+		sd += c.weight * (c.mean - mean) * (c.mean - mean)
+	}
+	return mean, math.Sqrt(sd / (n - 1))
+}
+
+// MeanCI returns the confidence interval of the mean of the box
+// at the -ci-level confidence level, using Student's t distribution.
+// The interval is NaN for fewer than two values.
+func (b box) meanCI() (lo, hi float64) {
+	if b.n < 2 {
+		return math.NaN(), math.NaN()
+	}
+	n := float64(b.n)
+	h := tQuantile((1+*ciLevel)/2, n-1) * b.stddev / math.Sqrt(n)
+	return b.mean - h, b.mean + h
+}
+
+// CheckCILevel returns an error if -ci-level is not strictly between 0 and 1.
+func checkCILevel() error {
+	if !(*ciLevel > 0 && *ciLevel < 1) {
+		return fmt.Errorf("Bad -ci-level: %s; it must be between 0 and 1", formatValue(*ciLevel))
+	}
+	return nil
+}
+
+// TQuantile returns the p-quantile of Student's t distribution
+// with df degrees of freedom.
+func tQuantile(p, df float64) float64 {
+	switch {
+	case math.IsNaN(p):
+		return math.NaN()
+	case p >= 1:
+		return math.Inf(1)
+	case p == 0.5:
+		return 0
+	case p < 0.5:
+		return -tQuantile(1-p, df)
+	}
+	// The bracket doubles at most until it overflows to infinity,
+	// even if rounding keeps tCDF below p.
+	lo, hi := 0.0, 1.0
+	for tCDF(hi, df) < p && !math.IsInf(hi, 1) {
+		lo, hi = hi, hi*2
+	}
+	for i := 0; i < 100 && hi-lo > 1e-12*hi; i++ {
+		mid := (lo + hi) / 2
+		if tCDF(mid, df) < p {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// TCDF returns the cumulative distribution function of Student's t distribution
+// with df degrees of freedom.
+func tCDF(t, df float64) float64 {
+	tail := 0.5 * betaInc(df/2, 0.5, df/(df+t*t))
+	if t < 0 {
+		return tail
+	}
+	return 1 - tail
+}
+
+// BetaInc returns the regularized incomplete beta function I_x(a, b).
+func betaInc(a, b, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	if x >= 1 {
+		return 1
+	}
+	la, _ := math.Lgamma(a)
+	lb, _ := math.Lgamma(b)
+	lab, _ := math.Lgamma(a + b)
+	front := math.Exp(lab - la - lb + a*math.Log(x) + b*math.Log(1-x))
+	if x < (a+1)/(a+b+2) {
+		return front * betaCF(a, b, x) / a
+	}
+	return 1 - front*betaCF(b, a, 1-x)/b
+}
+
+// BetaCF evaluates the continued fraction for the incomplete beta function
+// by the modified Lentz method.
+func betaCF(a, b, x float64) float64 {
+	const tiny = 1e-300
+	c, d := 1.0, 1-(a+b)*x/(a+1)
+	if math.Abs(d) < tiny {
+		d = tiny
+	}
+	d = 1 / d
+	h := d
+	for m := 1.0; m <= 300; m++ {
+		aa := m * (b - m) * x / ((a + 2*m - 1) * (a + 2*m))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		h *= d * c
+		aa = -(a + m) * (a + b + m) * x / ((a + 2*m) * (a + 2*m + 1))
+		d = 1 + aa*d
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = 1 + aa/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+	return h
+}
+
+// CV returns the coefficient of variation of the box:
+// its standard deviation relative to its mean.
+func (b box) cv() float64 { return b.stddev / b.mean }
+
+// Spread returns the interquartile range of the box relative to its median.
+func (b box) spread() float64 { return (b.q3 - b.q1) / b.q2 }
+
+// SortKeys maps the statistics by which boxes can be sorted
+// to functions returning them.
+var sortKeys = map[string]func(box) float64{
+	"n":      func(b box) float64 { return float64(b.n) },
+	"median": func(b box) float64 { return b.q2 },
+	"mean":   func(b box) float64 { return b.mean },
+	"cv":     box.cv,
+	"spread": box.spread,
+}
+
+// SortBoxes stably sorts the boxes in increasing order
+// of their name or a statistic from sortKeys or a plugin,
+// or in decreasing order if the key begins with -.
+func sortBoxes(boxes []box, key string) error {
+	desc := strings.HasPrefix(key, "-")
+	key = strings.TrimPrefix(key, "-")
+	less := func(i, j int) bool { return boxes[i].name < boxes[j].name }
+	if key != "name" {
+		stat, ok := sortKeys[key]
+		if !ok {
+			var err error
+			if stat, ok, err = pluginStat(key, boxes); err != nil {
+				return err
+			}
+		}
+		if !ok {
+			return withStatus(exitUsage, fmt.Errorf("unknown sort key %q", key))
+		}
+		less = func(i, j int) bool { return stat(boxes[i]) < stat(boxes[j]) }
+	}
+	if desc {
+		asc := less
+		less = func(i, j int) bool { return asc(j, i) }
+	}
+	sort.SliceStable(boxes, less)
+	return nil
+}
+
+// Autocorr returns the lag-1 autocorrelation of the values of the box
+// in input order, or NaN if there are fewer than three values
+// or the box has no raw values.
+func (b box) autocorr() float64 {
+	vs := b.values
+	if len(vs) < 3 {
+		return math.NaN()
+	}
+	var num, den float64
+	for i, v := range vs {
+		d := v - b.mean
+		den += d * d
+		if i > 0 {
+			num += d * (vs[i-1] - b.mean)
+		}
+	}
+	return num / den
+}
+
+// Correlated returns whether -autocorr is set and the magnitude
+// of the lag-1 autocorrelation of the box exceeds it.
+func (b box) correlated() bool {
+	return *autocorr > 0 && math.Abs(b.autocorr()) > *autocorr
+}
+
+// Modes returns the number of peaks in the kernel density estimate of the box,
+// computed by summarize if -modes is set, or by kdeModes otherwise.
+func (b box) modes() int {
+	if b.hasModes {
+		return b.modeCount
+	}
+	return b.kdeModes()
+}
+
+// KdeModes returns the number of peaks in a Gaussian kernel density estimate
+// of the distribution of the box, with Silverman's rule-of-thumb bandwidth.
+// The density is estimated on a grid after binning the values,
+// and peaks lower than a tenth of the highest are ignored as noise,
+// as are peaks not separated from the previous one
+// by a dip of at least a tenth of the lower of the two.
+// Boxes with no values have no modes.
+func (b box) kdeModes() int {
+	const (
+		gridSize = 512
+		minPeak  = 0.1
+		minDip   = 0.1
+	)
+	points := b.centroids()
+	if len(points) == 0 {
+		return 0
+	}
+	h := 0.9 * math.Min(b.stddev, (b.q3-b.q1)/1.34) * math.Pow(float64(b.n), -0.2)
+	lo, hi := b.min-3*h, b.max+3*h
+	dx := (hi - lo) / (gridSize - 1)
+	// A range or bandwidth beyond float64, as of values near ±math.MaxFloat64,
+	// or of infinite or NaN values, has no grid to estimate the density on.
+	if !(h > 0) || math.IsInf(h, 0) || !(dx > 0) || math.IsInf(dx, 0) {
+		return 1
+	}
+	var bins [gridSize]float64
+	for _, p := range points {
+		if math.IsNaN(p.mean) {
+			continue
+		}
+		i := math.Max(0, math.Min(gridSize-1, math.Round((p.mean-lo)/dx)))
+		bins[int(i)] += p.weight
+	}
+	var density [gridSize]float64
+	reach := int(math.Ceil(4 * h / dx))
+	for i := range density {
+		for j := i - reach; j <= i+reach; j++ {
+			if j >= 0 && j < gridSize && bins[j] > 0 {
+				u := float64(i-j) * dx / h
+				density[i] += bins[j] * math.Exp(-u*u/2)
+			}
+		}
+	}
+	peak := 0.0
+	for _, d := range density {
+		peak = math.Max(peak, d)
+	}
+	modes := 0
+	last, valley := 0.0, 0.0
+	for i := 1; i < gridSize-1; i++ {
+		d := density[i]
+		valley = math.Min(valley, d)
+		if d <= density[i-1] || d < density[i+1] || d < minPeak*peak {
+			continue
+		}
+		switch {
+		case modes == 0 || valley < (1-minDip)*math.Min(last, d):
+			modes++
+			last = d
+		default:
+			last = math.Max(last, d)
+		}
+		valley = d
+	}
+	return modes
+}
+
+// Multimodal returns whether -modes is set
+// and the box appears to have more than one mode.
+func (b box) multimodal() bool {
+	return *modes && b.modes() > 1
+}
+
+// Centroids returns the values of the box as weighted points:
+// the centroids of its digest, or each value with a weight of one.
+func (b box) centroids() []centroid {
+	if b.digest != nil {
+		return b.digest.centroids
+	}
+	cs := make([]centroid, len(b.values))
+	for i, v := range b.values {
+		cs[i] = centroid{mean: v, weight: 1}
+	}
+	return cs
+}
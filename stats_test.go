@@ -0,0 +1,89 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"math"
+	"strings"
+	"testing"
+)
+
+// TestKDEModesExtremeValues tests that kdeModes finds one mode
+// in values whose range or bandwidth is beyond float64,
+// instead of binning them out of its grid.
+func TestKDEModesExtremeValues(t *testing.T) {
+	for _, vs := range [][]float64{
+		{1e308, -1e308},
+		{1e308, -1e308, 5, 6, 7},
+		{math.NaN(), 1, 2, 3, 4},
+		{math.Inf(1), 1, 2, 3},
+	} {
+		bs := []box{newBox("a", vs)}
+		summarize(bs)
+		if n := bs[0].kdeModes(); n != 1 {
+			t.Errorf("kdeModes(%v) = %d, want 1", vs, n)
+		}
+	}
+}
+
+// TestCILevelUsage tests that a -ci-level outside (0, 1) is a usage error,
+// rather than hanging the search for the t quantile.
+func TestCILevelUsage(t *testing.T) {
+	defer resetFlags()
+	for _, test := range []struct {
+		level string
+		want  int
+	}{
+		{"95", exitUsage},
+		{"1", exitUsage},
+		{"0", exitUsage},
+		{"-0.5", exitUsage},
+		{"NaN", exitUsage},
+		{"0.9", exitOK},
+	} {
+		resetFlags()
+		if err := flag.Set("mean-ci", "true"); err != nil {
+			t.Fatal(err)
+		}
+		if err := flag.Set("ci-level", test.level); err != nil {
+			t.Fatal(err)
+		}
+		err := run(strings.NewReader("a 1 2 3"), ioutil.Discard)
+		if got := exitStatus(err); got != test.want {
+			t.Errorf("-ci-level %s: exit status %d (%v), want %d", test.level, got, err, test.want)
+		}
+	}
+}
+
+// TestTQuantileBounded tests that tQuantile returns for every p.
+func TestTQuantileBounded(t *testing.T) {
+	for _, p := range []float64{1, 1.5, 0, -1, math.NaN(), 1 - 1e-17, 1e-300} {
+		tQuantile(p, 3)
+	}
+	if q := tQuantile(1, 3); !math.IsInf(q, 1) {
+		t.Errorf("tQuantile(1, 3) = %v, want +Inf", q)
+	}
+}
+
+// TestSortUsage tests that an unknown -sort key is a usage error.
+func TestSortUsage(t *testing.T) {
+	defer resetFlags()
+	for _, test := range []struct {
+		key  string
+		want int
+	}{
+		{"bogus", exitUsage},
+		{"-bogus", exitUsage},
+		{"median", exitOK},
+		{"-name", exitOK},
+	} {
+		resetFlags()
+		if err := flag.Set("sort", test.key); err != nil {
+			t.Fatal(err)
+		}
+		err := run(strings.NewReader("a 1 2 3 b 4 5 6"), ioutil.Discard)
+		if got := exitStatus(err); got != test.want {
+			t.Errorf("-sort %s: exit status %d (%v), want %d", test.key, got, err, test.want)
+		}
+	}
+}
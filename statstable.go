@@ -0,0 +1,102 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// StatsColumns are the columns of the -stats table, in order.
+var statsColumns = []string{"name", "n", "min", "q1", "median", "q3", "max", "mean", "stddev"}
+
+// WriteStats writes, for -stats, the statistics of each box
+// in the -stats-format, tsv or json.
+func writeStats(boxes []box, w io.Writer) error {
+	switch *statsFormat {
+	case "tsv":
+		return writeStatsTSV(boxes, w)
+	case "json":
+		return writeStatsJSON(boxes, w)
+	}
+	return fmt.Errorf("Unknown stats format: %s", *statsFormat)
+}
+
+// WriteStatsTSV writes a table of the statistics of each box,
+// in the order of the boxes, as tab-separated values with a header row of statsColumns.
+// Values are formatted with -precision, and exactly with -exact,
+// and the statistics of a data set with no values are empty.
+// Names are quoted as by encoding/csv if they need to be.
+func writeStatsTSV(boxes []box, w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Comma = '\t'
+	cw.Write(statsColumns)
+	for _, b := range boxes {
+		row := make([]string, len(statsColumns))
+		row[0], row[1] = b.name, strconv.Itoa(b.n)
+		if b.n > 0 {
+			row[2], row[3], row[4], row[5], row[6] = b.statLabels()
+			row[7], row[8] = formatValue(b.mean), formatValue(b.stddev)
+			if b.exact != nil {
+				row[7] = formatExact(b.exact.mean)
+			}
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// StatsJSON is the statistics of a box written by -stats-format json.
+// Statistics that are undefined, such as those of a data set with no values,
+// or the standard deviation of one with a single value, are null.
+type statsJSON struct {
+	Name   string       `json:"name"`
+	N      int          `json:"n"`
+	Min    *json.Number `json:"min"`
+	Q1     *json.Number `json:"q1"`
+	Median *json.Number `json:"median"`
+	Q3     *json.Number `json:"q3"`
+	Max    *json.Number `json:"max"`
+	Mean   *json.Number `json:"mean"`
+	Stddev *json.Number `json:"stddev"`
+}
+
+// WriteStatsJSON writes a JSON array of the statistics of each box,
+// in the order of the boxes, as statsJSON objects.
+// Values are formatted as by writeStatsTSV, with -precision, and exactly with -exact,
+// so that they agree with every other output.
+func writeStatsJSON(boxes []box, w io.Writer) error {
+	ss := make([]statsJSON, 0, len(boxes))
+	for _, b := range boxes {
+		s := statsJSON{Name: b.name, N: b.n}
+		if b.n > 0 {
+			min, q1, q2, q3, max := b.statLabels()
+			mean := formatValue(b.mean)
+			if b.exact != nil {
+				mean = formatExact(b.exact.mean)
+			}
+			s.Min, s.Q1, s.Median, s.Q3, s.Max = statsNumber(min), statsNumber(q1), statsNumber(q2), statsNumber(q3), statsNumber(max)
+			s.Mean, s.Stddev = statsNumber(mean), statsNumber(formatValue(b.stddev))
+		}
+		ss = append(ss, s)
+	}
+	data, err := json.MarshalIndent(ss, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// StatsNumber returns a formatted value as a JSON number,
+// or nil if it is NaN or infinite, which JSON numbers cannot be.
+func statsNumber(s string) *json.Number {
+	if strings.HasSuffix(s, "Inf") || s == "NaN" {
+		return nil
+	}
+	n := json.Number(s)
+	return &n
+}
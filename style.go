@@ -0,0 +1,177 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"image/color"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// A style is the color and line style of the shapes of a box.
+// The zero style is the default: black, solid lines.
+type style struct {
+	// Color is one of the names in styleColors, or empty for black.
+	color string
+	// Line is dashed or dotted, or empty for solid.
+	line string
+}
+
+// StyleColors are the colors of styles,
+// named as in plot(1), SVG, and HTML, with their values for PNG output.
+var styleColors = map[string]color.RGBA{
+	"black":   {0x00, 0x00, 0x00, 0xff},
+	"gray":    {0x80, 0x80, 0x80, 0xff},
+	"red":     {0xff, 0x00, 0x00, 0xff},
+	"green":   {0x00, 0x80, 0x00, 0xff},
+	"blue":    {0x00, 0x00, 0xff, 0xff},
+	"yellow":  {0xff, 0xff, 0x00, 0xff},
+	"orange":  {0xff, 0xa5, 0x00, 0xff},
+	"purple":  {0x80, 0x00, 0x80, 0xff},
+	"brown":   {0xa5, 0x2a, 0x2a, 0xff},
+	"magenta": {0xff, 0x00, 0xff, 0xff},
+	"cyan":    {0x00, 0xff, 0xff, 0xff},
+}
+
+// StyleDashes are the line styles of styles,
+// with the SVG dash arrays drawing them.
+var styleDashes = map[string]string{
+	"solid":  "",
+	"dashed": "6 4",
+	"dotted": "2 3",
+}
+
+// A styleRule styles the boxes whose names match its pattern.
+type styleRule struct {
+	text    string
+	pattern *regexp.Regexp
+	style   style
+}
+
+// StyleFlag is the value of the repeatable -style flag.
+type styleFlag []styleRule
+
+// Styles are the style rules, in order.
+var styles styleFlag
+
+// StyleFileFlag is the value of the -style-file flag,
+// which adds the rules of a file to styles.
+type styleFileFlag string
+
+func init() {
+	flag.Var(&styles, "style", "style the boxes whose names match a `rule` of the form REGEX:color=gray,line=dashed; may be repeated")
+	flag.Var(new(styleFileFlag), "style-file", "add the -style rules of a `file`, one per line")
+}
+
+func (s *styleFlag) String() string {
+	var ts []string
+	for _, r := range *s {
+		ts = append(ts, r.text)
+	}
+	return strings.Join(ts, " ")
+}
+
+func (s *styleFlag) values() []string {
+	var vs []string
+	for _, r := range *s {
+		vs = append(vs, r.text)
+	}
+	return vs
+}
+
+// Set adds a style rule of the form REGEX:key=value,key=value,
+// where the keys are color, one of the names of styleColors,
+// and line, one of solid, dashed, or dotted.
+// An empty value clears the rules.
+func (s *styleFlag) Set(rule string) error {
+	if rule == "" {
+		*s = nil
+		return nil
+	}
+	i := strings.LastIndexByte(rule, ':')
+	if i < 0 {
+		return fmt.Errorf("style rule %q is not of the form REGEX:key=value,...", rule)
+	}
+	re, err := regexp.Compile(rule[:i])
+	if err != nil {
+		return err
+	}
+	r := styleRule{text: rule, pattern: re}
+	for _, kv := range strings.Split(rule[i+1:], ",") {
+		k, v := kv, ""
+		if j := strings.IndexByte(kv, '='); j >= 0 {
+			k, v = kv[:j], kv[j+1:]
+		}
+		switch k {
+		case "color":
+			if _, ok := styleColors[v]; !ok {
+				return fmt.Errorf("unknown style color %q", v)
+			}
+			r.style.color = v
+		case "line":
+			if _, ok := styleDashes[v]; !ok {
+				return fmt.Errorf("unknown style line %q", v)
+			}
+			r.style.line = v
+		default:
+			return fmt.Errorf("unknown style key %q", k)
+		}
+	}
+	*s = append(*s, r)
+	return nil
+}
+
+func (f *styleFileFlag) String() string { return string(*f) }
+
+// Set adds the style rules of a file, one per line.
+// Blank lines and lines beginning with # are ignored.
+// An empty value does nothing, since -style clears the rules.
+func (f *styleFileFlag) Set(path string) error {
+	*f = styleFileFlag(path)
+	if path == "" {
+		return nil
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		rule := strings.TrimSpace(scanner.Text())
+		if rule == "" || strings.HasPrefix(rule, "#") {
+			continue
+		}
+		if err := styles.Set(rule); err != nil {
+			return fmt.Errorf("%s:%d: %v", path, line, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// StyleOf returns the style of the named box:
+// the keys of the rules matching the name,
+// with those of later rules replacing those of earlier rules.
+func styleOf(name string) style {
+	var st style
+	for _, r := range styles {
+		if !r.pattern.MatchString(name) {
+			continue
+		}
+		if r.style.color != "" {
+			st.color = r.style.color
+		}
+		if r.style.line != "" {
+			st.line = r.style.line
+		}
+	}
+	if st.line == "solid" {
+		st.line = ""
+	}
+	if st.color == "black" {
+		st.color = ""
+	}
+	return st
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// An svgCanvas draws an SVG image.
+// Each shape has its role as its class,
+// and the shapes of each box are in a group with the box's name as its data-name,
+// so that the image can be styled or scripted.
+type svgCanvas struct {
+	w   io.Writer
+	buf bytes.Buffer
+	// InBox is whether a box group is open.
+	inBox bool
+	// Style is the style of the open box.
+	style style
+}
+
+// Pt returns the SVG coordinates of a point,
+// whose canvas coordinates have the origin at the bottom left.
+func svgPt(x, y float64) (float64, float64) {
+	return x * float64(*width), (1 - y) * float64(*height)
+}
+
+func (c *svgCanvas) line(role string, x0, y0, x1, y1 float64) {
+	x0, y0 = svgPt(x0, y0)
+	x1, y1 = svgPt(x1, y1)
+	fmt.Fprintf(&c.buf, "<line class=%q%s x1=\"%.2f\" y1=\"%.2f\" x2=\"%.2f\" y2=\"%.2f\"/>\n", role, c.attrs(), x0, y0, x1, y1)
+}
+
+func (c *svgCanvas) box(role string, x0, y0, x1, y1 float64) {
+	x0, y0 = svgPt(x0, y0)
+	x1, y1 = svgPt(x1, y1)
+	if x1 < x0 {
+		x0, x1 = x1, x0
+	}
+	if y1 < y0 {
+		y0, y1 = y1, y0
+	}
+	fmt.Fprintf(&c.buf, "<rect class=%q%s x=\"%.2f\" y=\"%.2f\" width=\"%.2f\" height=\"%.2f\"/>\n", role, c.attrs(), x0, y0, x1-x0, y1-y0)
+}
+
+func (c *svgCanvas) circle(role string, x, y, r float64) {
+	x, y = svgPt(x, y)
+	fmt.Fprintf(&c.buf, "<circle class=%q%s cx=\"%.2f\" cy=\"%.2f\" r=\"%.2f\"/>\n", role, c.attrs(), x, y, r*float64(*width))
+}
+
+func (c *svgCanvas) polyline(role string, xs, ys []float64) {
+	fmt.Fprintf(&c.buf, "<polyline class=%q%s points=\"", role, c.attrs())
+	for i := range xs {
+		x, y := svgPt(xs[i], ys[i])
+		if i > 0 {
+			c.buf.WriteByte(' ')
+		}
+		fmt.Fprintf(&c.buf, "%.2f,%.2f", x, y)
+	}
+	c.buf.WriteString("\"/>\n")
+}
+
+// SvgAnchors maps plot(1) alignments to SVG text anchors.
+var svgAnchors = map[byte]string{'L': "start", 'C': "middle", 'R': "end"}
+
+func (c *svgCanvas) text(role string, x, y float64, align byte, s string) {
+	x, y = svgPt(x, y)
+	fmt.Fprintf(&c.buf, "<text class=%q%s x=\"%.2f\" y=\"%.2f\" text-anchor=%q>", role, c.textAttrs(), x, y, svgAnchors[align])
+	xml.EscapeText(&c.buf, []byte(s))
+	c.buf.WriteString("</text>\n")
+}
+
+// Tooltip adds a title element to the last shape,
+// which browsers show when the pointer is over it.
+func (c *svgCanvas) tooltip(text string) {
+	b := c.buf.Bytes()
+	if !bytes.HasSuffix(b, []byte("/>\n")) {
+		return
+	}
+	start := bytes.LastIndexByte(b[:len(b)-1], '\n') + 1
+	tag := b[start+1:]
+	tag = tag[:bytes.IndexByte(tag, ' ')]
+	tag = append([]byte(nil), tag...)
+	c.buf.Truncate(len(b) - len("/>\n"))
+	c.buf.WriteString("><title>")
+	xml.EscapeText(&c.buf, []byte(text))
+	fmt.Fprintf(&c.buf, "</title></%s>\n", tag)
+}
+
+func (c *svgCanvas) group(name string) {
+	if c.inBox {
+		c.buf.WriteString("</g>\n")
+	}
+	c.inBox = name != ""
+	c.style = style{}
+	if c.inBox {
+		c.style = styleOf(name)
+		c.buf.WriteString(`<g class="box" data-name="`)
+		xml.EscapeText(&c.buf, []byte(name))
+		c.buf.WriteString("\">\n")
+	}
+}
+
+// Attrs returns the style attribute of a shape of a styled box,
+// or the empty string if the box is not styled.
+// Being inline, it overrides svgStyle.
+func (c *svgCanvas) attrs() string {
+	var ss []string
+	if c.style.color != "" {
+		ss = append(ss, "stroke: "+c.style.color)
+	}
+	if c.style.line != "" {
+		ss = append(ss, "stroke-dasharray: "+styleDashes[c.style.line])
+	}
+	if len(ss) == 0 {
+		return ""
+	}
+	return ` style="` + strings.Join(ss, "; ") + `"`
+}
+
+// TextAttrs returns the style attribute of a text of a styled box.
+func (c *svgCanvas) textAttrs() string {
+	if c.style.color == "" {
+		return ""
+	}
+	return ` style="fill: ` + c.style.color + `"`
+}
+
+// Close writes the SVG image.
+func (c *svgCanvas) close() error {
+	c.group("")
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "<svg xmlns=\"http://www.w3.org/2000/svg\" width=\"%d\" height=\"%d\" viewBox=\"0 0 %d %d\">\n",
+		*width, *height, *width, *height)
+	buf.WriteString(svgStyle)
+	fmt.Fprintf(&buf, "<rect width=\"%d\" height=\"%d\" style=\"fill: white; stroke: none\"/>\n", *width, *height)
+	buf.Write(c.buf.Bytes())
+	buf.WriteString("</svg>\n")
+	_, err := c.w.Write(buf.Bytes())
+	return err
+}
+
+// SvgStyle styles the shapes of SVG output by their roles.
+// Text is vertically centered on its point, as in plot(1).
+const svgStyle = `<style>
+line, rect, circle, polyline { fill: none; stroke: black; }
+text { font: 11px sans-serif; dominant-baseline: middle; }
+.median { stroke-width: 2; }
+.shade { stroke: #ccc; }
+.heat { stroke: #888; }
+.tick { fill: #444; }
+.zoom { stroke: #888; stroke-dasharray: 4 3; }
+.title { font-size: 15px; }
+</style>
+`
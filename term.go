@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// WriteTerm writes the boxes as text for a terminal, for -o term,
+// cols columns wide, drawn with Unicode box-drawing characters,
+// so that distributions can be eyeballed over ssh without any graphics.
+// Each box is drawn on three rows, across a shared horizontal value scale,
+// with its name at the left of its middle row:
+//
+//	             ┌─────┬───────┐
+//	encode  ├────┤     │       ├──────────┤  •
+//	             └─────┴───────┘
+//
+// The whiskers are by -whiskers, and outliers are bullets.
+// Below the boxes is the value axis, with ticks as for -axis.
+// The scale is logarithmic with -log or -log2, and spans -ymin and -ymax if set.
+// Names longer than a third of the width are shortened with an ellipsis.
+// The flags it cannot draw, such as -matrix and -notch, are rejected by checkFormat.
+// Infinite values are drawn at the ends of the scale, and NaNs are left out,
+// as is a box whose statistics are NaN, leaving only its name.
+func writeTerm(boxes []box, title string, w io.Writer, cols int) error {
+	nameW := 0
+	for _, b := range boxes {
+		nameW = maxInt(nameW, utf8.RuneCountInString(b.name))
+	}
+	nameW = minInt(nameW, cols/3)
+	plotW := maxInt(cols-nameW-2, 10)
+
+	var sb strings.Builder
+	line := func(name string, row []rune) {
+		sb.WriteString(strings.TrimRight(termPad(name, nameW)+"  "+string(row), " "))
+		sb.WriteByte('\n')
+	}
+	if title != "" {
+		sb.WriteString(strings.Repeat(" ", maxInt(0, (nameW+2+plotW-utf8.RuneCountInString(title))/2)))
+		sb.WriteString(title + "\n")
+	}
+
+	min, max := math.Inf(1), math.Inf(-1)
+	for _, b := range boxes {
+		if b.n == 0 {
+			continue
+		}
+		for _, v := range []float64{b.min, b.q1, b.q2, b.q3, b.max} {
+			if !math.IsInf(v, 0) && !math.IsNaN(v) {
+				min, max = math.Min(min, v), math.Max(max, v)
+			}
+		}
+	}
+	if math.IsInf(min, 1) {
+		for _, b := range boxes {
+			line(termShorten(b.name, nameW), nil)
+		}
+		_, err := io.WriteString(w, sb.String())
+		return err
+	}
+	min, max = pinned(min, max)
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+	tr := valueScale(min, max, 0, float64(plotW-1))
+	tr = clampTr(tr, 0, float64(plotW-1))
+	// Col returns the column of a value, or -1 for NaN, which has none.
+	col := func(v float64) int {
+		if math.IsNaN(v) {
+			return -1
+		}
+		return int(math.Round(tr(v)))
+	}
+
+	for _, b := range boxes {
+		top, mid, bot := termRow(plotW), termRow(plotW), termRow(plotW)
+		if b.n == 0 {
+			line("", nil)
+			line(b.name, nil)
+			line("", nil)
+			continue
+		}
+		lo, hi, out := b.whiskers()
+		cl, c1, c2, c3, ch := col(lo), col(b.q1), col(b.q2), col(b.q3), col(hi)
+		if cl < 0 || c1 < 0 || c2 < 0 || c3 < 0 || ch < 0 {
+			line("", nil)
+			line(termShorten(b.name, nameW), nil)
+			line("", nil)
+			continue
+		}
+		for c := cl; c <= ch; c++ {
+			mid[c] = '─'
+		}
+		for c := c1; c <= c3; c++ {
+			top[c], mid[c], bot[c] = '─', ' ', '─'
+		}
+		mid[cl], mid[ch] = '├', '┤'
+		top[c1], mid[c1], bot[c1] = '┌', '┤', '└'
+		top[c3], mid[c3], bot[c3] = '┐', '├', '┘'
+		if c1 == cl {
+			mid[c1] = '│'
+		}
+		if c3 == ch {
+			mid[c3] = '│'
+		}
+		switch {
+		case c1 == c3:
+			top[c2], mid[c2], bot[c2] = '┬', '┼', '┴'
+		case c2 != c1 && c2 != c3:
+			top[c2], mid[c2], bot[c2] = '┬', '│', '┴'
+		}
+		for _, v := range out {
+			if c := col(v); c >= 0 && mid[c] == ' ' {
+				mid[c] = '•'
+			}
+		}
+		line("", top)
+		line(termShorten(b.name, nameW), mid)
+		line("", bot)
+	}
+
+	// The axis is a rule with a mark at each tick, above the labels of the ticks
+	// that fit without running into the one before.
+	axis, labels := termRow(plotW), termRow(plotW)
+	for c := range axis {
+		axis[c] = '─'
+	}
+	ticks, tickLabels := axisLabels(min, max)
+	end := -1
+	for i, v := range ticks {
+		if v < min || v > max {
+			continue
+		}
+		c := col(v)
+		axis[c] = '┬'
+		l := []rune(tickLabels[i])
+		start := minInt(maxInt(c-len(l)/2, 0), plotW-len(l))
+		if start <= end || start < 0 {
+			continue
+		}
+		copy(labels[start:], l)
+		end = start + len(l)
+	}
+	line("", axis)
+	line("", labels)
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// TermRow returns a row of n spaces.
+func termRow(n int) []rune {
+	row := make([]rune, n)
+	for i := range row {
+		row[i] = ' '
+	}
+	return row
+}
+
+// TermPad returns s padded with spaces to n runes.
+func termPad(s string, n int) string {
+	return s + strings.Repeat(" ", maxInt(0, n-utf8.RuneCountInString(s)))
+}
+
+// TermShorten returns s, or, if it is longer than n runes,
+// its first n-1 runes followed by an ellipsis.
+func termShorten(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n || n < 1 {
+		return s
+	}
+	return string([]rune(s)[:n-1]) + "…"
+}
+
+// TermColumns returns the width in columns of -o term output written to w:
+// the width of the terminal, if w is one or a termRefresher of one,
+// or else $COLUMNS, or else 80.
+func termColumns(w io.Writer) int {
+	if r, ok := w.(*termRefresher); ok {
+		w = r.w
+	}
+	if f, ok := w.(*os.File); ok {
+		if n := termWidth(f); n > 0 {
+			return n
+		}
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	return 80
+}
+
+// TermRefreshEvery is the interval between the redrawings of -o term plots
+// of -consume measurements by a termRefresher, unless -consume-every is set.
+const termRefreshEvery = 250 * time.Millisecond
+
+// A termRefresher draws each -o term plot of -consume measurements
+// over the one before it, so that the plot is redrawn in place,
+// giving a live view of a long benchmark run over ssh.
+// The output of a plot is buffered until flush,
+// which moves the cursor up over the lines of the plot before,
+// clears them, and writes the new plot, with ANSI escape sequences.
+type termRefresher struct {
+	w   io.Writer
+	buf bytes.Buffer
+	// Lines is the number of lines of the plot on the terminal.
+	lines int
+}
+
+// NewTermRefresher returns a termRefresher of out,
+// or nil if the output is not -o term or out is not a terminal
+// that interprets escape sequences, which they would otherwise litter.
+func newTermRefresher(out io.Writer) *termRefresher {
+	f, ok := out.(*os.File)
+	if !ok || *outFormat != "term" || termWidth(f) == 0 || !enableTermEscapes(f) {
+		return nil
+	}
+	return &termRefresher{w: out}
+}
+
+func (r *termRefresher) Write(p []byte) (int, error) {
+	return r.buf.Write(p)
+}
+
+// Flush writes the buffered plot over the plot before it.
+func (r *termRefresher) flush() error {
+	var frame bytes.Buffer
+	if r.lines > 0 {
+		// Cursor up to the first line of the plot, and erase to the end of the screen.
+		fmt.Fprintf(&frame, "\x1b[%dA\r\x1b[J", r.lines)
+	}
+	frame.Write(r.buf.Bytes())
+	r.lines = bytes.Count(r.buf.Bytes(), []byte("\n"))
+	r.buf.Reset()
+	_, err := r.w.Write(frame.Bytes())
+	return err
+}
@@ -0,0 +1,13 @@
+//go:build (!unix && !windows) || aix || solaris
+
+package main
+
+import "os"
+
+// TermWidth returns 0 on platforms whose syscall package has no ioctl,
+// AIX and Solaris among the Unix systems, or no terminals,
+// so the width of -o term output is from $COLUMNS.
+func termWidth(f *os.File) int { return 0 }
+
+// EnableTermEscapes reports false, as no file is known to be a terminal.
+func enableTermEscapes(f *os.File) bool { return false }
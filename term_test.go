@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+// TestTermNonFinite tests that -o term draws data with NaN and infinite values
+// instead of indexing out of its rows.
+func TestTermNonFinite(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+	if err := flag.Set("o", "term"); err != nil {
+		t.Fatal(err)
+	}
+	for _, input := range []string{"a NaN 1 2", "a Inf 1 2", "a -Inf 1 2 b 3 4", "a NaN NaN", "a Inf -Inf"} {
+		var out bytes.Buffer
+		if err := run(strings.NewReader(input), &out); err != nil {
+			t.Errorf("%q: %v", input, err)
+			continue
+		}
+		if !strings.Contains(out.String(), "a") {
+			t.Errorf("%q: no box a in\n%s", input, out.String())
+		}
+	}
+}
+
+// TestCheckFormat tests that the flags that a format cannot draw are usage errors
+// instead of being left out of the plot.
+func TestCheckFormat(t *testing.T) {
+	defer resetFlags()
+	for _, test := range []struct {
+		format, flag, value string
+		ok                  bool
+	}{
+		{"term", "notch", "true", false},
+		{"term", "text", "top:x", false},
+		{"term", "horizontal", "true", true},
+		{"gnuplot", "mean-ci", "true", false},
+		{"vega", "horizontal", "true", false},
+		{"svg", "notch", "true", true},
+		{"term", "all-metrics", "true", false},
+	} {
+		resetFlags()
+		if err := flag.Set("o", test.format); err != nil {
+			t.Fatal(err)
+		}
+		if err := flag.Set(test.flag, test.value); err != nil {
+			t.Fatal(err)
+		}
+		err := run(strings.NewReader("a 1 2 3 4"), ioutil.Discard)
+		if test.ok && err != nil {
+			t.Errorf("-o %s -%s: %v", test.format, test.flag, err)
+		}
+		if !test.ok && exitStatus(err) != exitUsage {
+			t.Errorf("-o %s -%s: exit status %d, want %d", test.format, test.flag, exitStatus(err), exitUsage)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+//go:build unix && !aix && !solaris
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// TermWidth returns the width in columns of the terminal of a file,
+// from the TIOCGWINSZ ioctl, or 0 if the file is not a terminal.
+func termWidth(f *os.File) int {
+	var ws struct{ rows, cols, xpixel, ypixel uint16 }
+	_, _, errno := syscall.Syscall(syscall.SYS_IOCTL, f.Fd(), uintptr(syscall.TIOCGWINSZ), uintptr(unsafe.Pointer(&ws)))
+	if errno != 0 {
+		return 0
+	}
+	return int(ws.cols)
+}
+
+// EnableTermEscapes reports whether the terminal of a file
+// interprets ANSI escape sequences, which every Unix terminal does.
+func enableTermEscapes(f *os.File) bool { return true }
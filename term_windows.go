@@ -0,0 +1,51 @@
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	kernel32                       = syscall.NewLazyDLL("kernel32.dll")
+	procGetConsoleScreenBufferInfo = kernel32.NewProc("GetConsoleScreenBufferInfo")
+	procSetConsoleMode             = kernel32.NewProc("SetConsoleMode")
+)
+
+// EnableVirtualTerminalProcessing is the console mode
+// in which the console interprets ANSI escape sequences.
+const enableVirtualTerminalProcessing = 0x4
+
+// A consoleScreenBufferInfo is a CONSOLE_SCREEN_BUFFER_INFO.
+type consoleScreenBufferInfo struct {
+	size, cursorPosition     struct{ x, y int16 }
+	attributes               uint16
+	left, top, right, bottom int16
+	maximumWindowSize        struct{ x, y int16 }
+}
+
+// TermWidth returns the width in columns of the console window of a file,
+// from GetConsoleScreenBufferInfo, or 0 if the file is not a console.
+func termWidth(f *os.File) int {
+	var info consoleScreenBufferInfo
+	r, _, _ := procGetConsoleScreenBufferInfo.Call(f.Fd(), uintptr(unsafe.Pointer(&info)))
+	if r == 0 {
+		return 0
+	}
+	return int(info.right-info.left) + 1
+}
+
+// EnableTermEscapes reports whether the console of a file
+// interprets ANSI escape sequences, setting its mode so that it does
+// if it is a console of Windows 10 or later; older consoles do not.
+func enableTermEscapes(f *os.File) bool {
+	var mode uint32
+	if err := syscall.GetConsoleMode(syscall.Handle(f.Fd()), &mode); err != nil {
+		return false
+	}
+	if mode&enableVirtualTerminalProcessing != 0 {
+		return true
+	}
+	r, _, _ := procSetConsoleMode.Call(f.Fd(), uintptr(mode|enableVirtualTerminalProcessing))
+	return r != 0
+}
@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("uniform" 1, "skewed" 2)
+$boxes << EOD
+1 239.13301618505938 0 1000 738.421042583089 483.45562130177507 0
+2 11.945538567712479 1.6 320 35.36528536711799 20.589036817882977 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
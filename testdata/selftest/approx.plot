@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cuniform"
+bo 0.166667 0.280437 0.416667 0.719811
+m 0.166667 0.280437
+t "\R239"
+m 0.166667 0.719811
+t "\R738"
+li 0.166667 0.495441 0.416667 0.495441
+m 0.166667 0.495441
+t "\R483"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.280437 0.291667 0.070000
+m 0.229167 0.070000
+t "\R0"
+li 0.229167 0.950000 0.354167 0.950000
+li 0.291667 0.719811 0.291667 0.950000
+m 0.229167 0.950000
+t "\R1e+03"
+m 0.708333 0.020000
+t "\Cskewed"
+bo 0.583333 0.080512 0.833333 0.101121
+m 0.583333 0.080512
+t "\R11.9"
+m 0.583333 0.101121
+t "\R35.4"
+li 0.583333 0.088118 0.833333 0.088118
+m 0.583333 0.088118
+t "\R20.6"
+li 0.645833 0.071408 0.770833 0.071408
+li 0.708333 0.080512 0.708333 0.071408
+m 0.645833 0.071408
+t "\R1.6"
+li 0.645833 0.351600 0.770833 0.351600
+li 0.708333 0.101121 0.708333 0.351600
+m 0.645833 0.351600
+t "\R320"
+cl
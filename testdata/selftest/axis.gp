@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("fast" 1, "slow" 2)
+$boxes << EOD
+1 0.013 0.012 0.019 0.015 0.014 0
+2 0.031 0.029 0.041 0.035 0.033 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,47 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.312759 0.080000 0.312759
+m 0.070000 0.312759
+t "\R0.02"
+li 0.070000 0.616207 0.080000 0.616207
+m 0.070000 0.616207
+t "\R0.03"
+li 0.070000 0.919655 0.080000 0.919655
+m 0.070000 0.919655
+t "\R0.04"
+m 0.348333 0.020000
+t "\Cfast"
+bo 0.233333 0.100345 0.463333 0.161034
+m 0.233333 0.100345
+t "\R0.013"
+m 0.233333 0.161034
+t "\R0.015"
+li 0.233333 0.130690 0.463333 0.130690
+m 0.233333 0.130690
+t "\R0.014"
+li 0.290833 0.070000 0.405833 0.070000
+li 0.348333 0.100345 0.348333 0.070000
+m 0.290833 0.070000
+t "\R0.012"
+li 0.290833 0.282414 0.405833 0.282414
+li 0.348333 0.161034 0.348333 0.282414
+m 0.290833 0.282414
+t "\R0.019"
+m 0.731667 0.020000
+t "\Cslow"
+bo 0.616667 0.646552 0.846667 0.767931
+m 0.616667 0.646552
+t "\R0.031"
+m 0.616667 0.767931
+t "\R0.035"
+li 0.616667 0.707241 0.846667 0.707241
+m 0.616667 0.707241
+t "\R0.033"
+li 0.674167 0.585862 0.789167 0.585862
+li 0.731667 0.646552 0.731667 0.585862
+m 0.674167 0.585862
+t "\R0.029"
+li 0.674167 0.950000 0.789167 0.950000
+li 0.731667 0.767931 0.731667 0.950000
+m 0.674167 0.950000
+t "\R0.041"
+cl
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Clinear"
+bo 0.166667 0.083968 0.416667 0.125873
+m 0.166667 0.083968
+t "\R2"
+m 0.166667 0.125873
+t "\R5"
+li 0.166667 0.104921 0.416667 0.104921
+m 0.166667 0.104921
+t "\R3.5"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.083968 0.291667 0.070000
+m 0.229167 0.070000
+t "\R1"
+li 0.229167 0.139841 0.354167 0.139841
+li 0.291667 0.125873 0.291667 0.139841
+m 0.229167 0.139841
+t "\R6"
+m 0.708333 0.020000
+t "\Cexponential"
+bo 0.583333 0.111905 0.833333 0.503016
+m 0.583333 0.111905
+t "\R4"
+m 0.583333 0.503016
+t "\R32"
+li 0.583333 0.223651 0.833333 0.223651
+m 0.583333 0.223651
+t "\R12"
+li 0.645833 0.083968 0.770833 0.083968
+li 0.708333 0.111905 0.708333 0.083968
+m 0.645833 0.083968
+t "\R2"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.503016 0.708333 0.950000
+m 0.645833 0.950000
+t "\R64"
+cl
@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("fast" 1, "slow" 2, "lost" 3)
+$boxes << EOD
+1 120 100 150 140 130 0
+2 1850 900 5000 5000 3000 0
+3 20 10 20 20 20 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
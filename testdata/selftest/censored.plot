@@ -0,0 +1,77 @@
+m 0.203704 0.060000
+t "\Cfast"
+bo 0.111111 0.126561 0.296296 0.129936
+m 0.111111 0.126561
+t "\R120"
+m 0.111111 0.129936
+t "\R140"
+li 0.111111 0.128248 0.296296 0.128248
+m 0.111111 0.128248
+t "\R130"
+li 0.157407 0.123186 0.250000 0.123186
+li 0.203704 0.126561 0.203704 0.123186
+m 0.157407 0.123186
+t "\R100"
+li 0.157407 0.131623 0.250000 0.131623
+li 0.203704 0.129936 0.203704 0.131623
+m 0.157407 0.131623
+t "\R150"
+m 0.500000 0.060000
+t "\Cslow"
+bo 0.407407 0.418477 0.592593 0.950000
+m 0.407407 0.418477
+t "\R1.85e+03"
+m 0.407407 0.950000
+t "\R5e+03"
+li 0.407407 0.612525 0.592593 0.612525
+m 0.407407 0.612525
+t "\R3e+03"
+li 0.453704 0.258176 0.546296 0.258176
+li 0.500000 0.418477 0.500000 0.258176
+m 0.453704 0.258176
+t "\R900"
+li 0.453704 0.950000 0.546296 0.950000
+li 0.500000 0.950000 0.500000 0.950000
+m 0.453704 0.950000
+t "\R5e+03"
+m 0.488426 0.926852
+v 0.500000 0.950000
+v 0.511574 0.926852
+v 0.488426 0.926852
+m 0.500000 0.970000
+t "\Ccensored=3"
+m 0.500000 0.990000
+t "\Ckm=3e+03"
+m 0.796296 0.060000
+t "\Clost"
+bo 0.703704 0.109687 0.888889 0.109687
+m 0.703704 0.109687
+t "\R20"
+m 0.703704 0.109687
+t "\R20"
+li 0.703704 0.109687 0.888889 0.109687
+m 0.703704 0.109687
+t "\R20"
+li 0.750000 0.108000 0.842593 0.108000
+li 0.796296 0.109687 0.796296 0.108000
+m 0.750000 0.108000
+t "\R10"
+li 0.750000 0.109687 0.842593 0.109687
+li 0.796296 0.109687 0.796296 0.109687
+m 0.750000 0.109687
+t "\R20"
+m 0.784722 0.086539
+v 0.796296 0.109687
+v 0.807870 0.086539
+v 0.784722 0.086539
+m 0.796296 0.129687
+t "\Ccensored=4"
+m 0.796296 0.149687
+t "\Ckm>20"
+m 0.045000 0.015000
+v 0.050000 0.025000
+v 0.055000 0.015000
+v 0.045000 0.015000
+m 0.070000 0.020000
+t "\Lcensored at a limit; km: Kaplan-Meier median"
+cl
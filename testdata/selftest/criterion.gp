@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("parse/small" 1, "parse/large" 2)
+$boxes << EOD
+1 119.875 119 123 121.11111111111111 120.625 0
+2 4975 4900 5150 5075 4999.20634920635 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cparse/small"
+bo 0.166667 0.070153 0.416667 0.070369
+m 0.166667 0.070153
+t "\R120"
+m 0.166667 0.070369
+t "\R121"
+li 0.166667 0.070284 0.416667 0.070284
+m 0.166667 0.070284
+t "\R121"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.070153 0.291667 0.070000
+m 0.229167 0.070000
+t "\R119"
+li 0.229167 0.070700 0.354167 0.070700
+li 0.291667 0.070369 0.291667 0.070700
+m 0.229167 0.070700
+t "\R123"
+m 0.708333 0.020000
+t "\Cparse/large"
+bo 0.583333 0.919390 0.833333 0.936881
+m 0.583333 0.919390
+t "\R4.98e+03"
+m 0.583333 0.936881
+t "\R5.08e+03"
+li 0.583333 0.923624 0.833333 0.923624
+m 0.583333 0.923624
+t "\R5e+03"
+li 0.645833 0.906271 0.770833 0.906271
+li 0.708333 0.919390 0.708333 0.906271
+m 0.645833 0.906271
+t "\R4.9e+03"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.936881 0.708333 0.950000
+m 0.645833 0.950000
+t "\R5.15e+03"
+cl
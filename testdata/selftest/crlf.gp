@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set title "CRLF" noenhanced
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("windows" 1, "line-endings" 2)
+$boxes << EOD
+1 1.5 1 4 3.5 2.5 0
+2 2.5 2 8 6.5 4 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
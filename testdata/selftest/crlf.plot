@@ -0,0 +1,39 @@
+m 0.500000 0.980000
+t "\CCRLF"
+m 0.291667 0.020000
+t "\Cwindows"
+bo 0.166667 0.128286 0.416667 0.369429
+m 0.166667 0.128286
+t "\R1.5"
+m 0.166667 0.369429
+t "\R3.5"
+li 0.166667 0.248857 0.416667 0.248857
+m 0.166667 0.248857
+t "\R2.5"
+li 0.229167 0.068000 0.354167 0.068000
+li 0.291667 0.128286 0.291667 0.068000
+m 0.229167 0.068000
+t "\R1"
+li 0.229167 0.429714 0.354167 0.429714
+li 0.291667 0.369429 0.291667 0.429714
+m 0.229167 0.429714
+t "\R4"
+m 0.708333 0.020000
+t "\Cline-endings"
+bo 0.583333 0.248857 0.833333 0.731143
+m 0.583333 0.248857
+t "\R2.5"
+m 0.583333 0.731143
+t "\R6.5"
+li 0.583333 0.429714 0.833333 0.429714
+m 0.583333 0.429714
+t "\R4"
+li 0.645833 0.188571 0.770833 0.188571
+li 0.708333 0.248857 0.708333 0.188571
+m 0.645833 0.188571
+t "\R2"
+li 0.645833 0.912000 0.770833 0.912000
+li 0.708333 0.731143 0.708333 0.912000
+m 0.645833 0.912000
+t "\R8"
+cl
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cread"
+bo 0.166667 0.093158 0.416667 0.139474
+m 0.166667 0.093158
+t "\R1.5"
+m 0.166667 0.139474
+t "\R2.5"
+li 0.166667 0.116316 0.416667 0.116316
+m 0.166667 0.116316
+t "\R2"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.093158 0.291667 0.070000
+m 0.229167 0.070000
+t "\R1"
+li 0.229167 0.162632 0.354167 0.162632
+li 0.291667 0.139474 0.291667 0.162632
+m 0.229167 0.162632
+t "\R3"
+m 0.708333 0.020000
+t "\Cwrite"
+bo 0.583333 0.486842 0.833333 0.950000
+m 0.583333 0.486842
+t "\R10"
+m 0.583333 0.950000
+t "\R20"
+li 0.583333 0.718421 0.833333 0.718421
+m 0.583333 0.718421
+t "\R15"
+li 0.645833 0.486842 0.770833 0.486842
+li 0.708333 0.486842 0.708333 0.486842
+m 0.645833 0.486842
+t "\R10"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.950000 0.708333 0.950000
+m 0.645833 0.950000
+t "\R20"
+cl
@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("fast" 1, "slow" 2)
+$boxes << EOD
+1 7.608712195976195 7.172916948764684 8.572299740813696 7.971128688075173 7.777897508680824 0
+2 24.636933653299966 23.534561351285003 29.262268943525502 26.222244592516887 25.315633847690016 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
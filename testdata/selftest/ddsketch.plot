@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cfast"
+bo 0.166667 0.087361 0.416667 0.101799
+m 0.166667 0.087361
+t "\R7.61"
+m 0.166667 0.101799
+t "\R7.97"
+li 0.166667 0.094101 0.416667 0.094101
+m 0.166667 0.094101
+t "\R7.78"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.087361 0.291667 0.070000
+m 0.229167 0.070000
+t "\R7.17"
+li 0.229167 0.125749 0.354167 0.125749
+li 0.291667 0.101799 0.291667 0.125749
+m 0.229167 0.125749
+t "\R8.57"
+m 0.708333 0.020000
+t "\Cslow"
+bo 0.583333 0.765735 0.833333 0.828891
+m 0.583333 0.765735
+t "\R24.6"
+m 0.583333 0.828891
+t "\R26.2"
+li 0.583333 0.792773 0.833333 0.792773
+m 0.583333 0.792773
+t "\R25.3"
+li 0.645833 0.721818 0.770833 0.721818
+li 0.708333 0.765735 0.708333 0.721818
+m 0.645833 0.721818
+t "\R23.5"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.828891 0.708333 0.950000
+m 0.645833 0.950000
+t "\R29.3"
+cl
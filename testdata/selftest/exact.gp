@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("counter" 1, "small" 2)
+$boxes << EOD
+1 9.007199254740994e+15 9.007199254740992e+15 9.007199254740998e+15 9.007199254740998e+15 9.007199254740996e+15 0
+2 1.5 1 3 2.5 2 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Ccounter"
+bo 0.166667 0.950000 0.416667 0.950000
+m 0.166667 0.950000
+t "\R9.007199254740994e+15"
+m 0.166667 0.950000
+t "\R9.0071992547409975e+15"
+li 0.166667 0.950000 0.416667 0.950000
+m 0.166667 0.950000
+t "\R9.007199254740996e+15"
+li 0.229167 0.950000 0.354167 0.950000
+li 0.291667 0.950000 0.291667 0.950000
+m 0.229167 0.950000
+t "\R9.007199254740993e+15"
+li 0.229167 0.950000 0.354167 0.950000
+li 0.291667 0.950000 0.291667 0.950000
+m 0.229167 0.950000
+t "\R9.007199254740998e+15"
+m 0.708333 0.020000
+t "\Csmall"
+bo 0.583333 0.070000 0.833333 0.070000
+m 0.583333 0.070000
+t "\R1.5"
+m 0.583333 0.070000
+t "\R2.5"
+li 0.583333 0.070000 0.833333 0.070000
+m 0.583333 0.070000
+t "\R2"
+li 0.645833 0.070000 0.770833 0.070000
+li 0.708333 0.070000 0.708333 0.070000
+m 0.645833 0.070000
+t "\R1"
+li 0.645833 0.070000 0.770833 0.070000
+li 0.708333 0.070000 0.708333 0.070000
+m 0.645833 0.070000
+t "\R3"
+cl
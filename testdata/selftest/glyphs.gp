@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set title "latency-µs" noenhanced
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("µs" 1, "±1°" 2, "2×2" 3)
+$boxes << EOD
+1 2 1 5 4 3 0
+2 3 2 9 5 4 0
+3 4 4 6 5.5 4.5 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,57 @@
+m 0.500000 0.980000
+t "\Clatency-µs"
+m 0.203704 0.020000
+t "\Cµs"
+bo 0.111111 0.173500 0.296296 0.384500
+m 0.111111 0.173500
+t "\R2"
+m 0.111111 0.384500
+t "\R4"
+li 0.111111 0.279000 0.296296 0.279000
+m 0.111111 0.279000
+t "\R3"
+li 0.157407 0.068000 0.250000 0.068000
+li 0.203704 0.173500 0.203704 0.068000
+m 0.157407 0.068000
+t "\R1"
+li 0.157407 0.490000 0.250000 0.490000
+li 0.203704 0.384500 0.203704 0.490000
+m 0.157407 0.490000
+t "\R5"
+m 0.500000 0.020000
+t "\C±1°"
+bo 0.407407 0.279000 0.592593 0.490000
+m 0.407407 0.279000
+t "\R3"
+m 0.407407 0.490000
+t "\R5"
+li 0.407407 0.384500 0.592593 0.384500
+m 0.407407 0.384500
+t "\R4"
+li 0.453704 0.173500 0.546296 0.173500
+li 0.500000 0.279000 0.500000 0.173500
+m 0.453704 0.173500
+t "\R2"
+li 0.453704 0.912000 0.546296 0.912000
+li 0.500000 0.490000 0.500000 0.912000
+m 0.453704 0.912000
+t "\R9"
+m 0.796296 0.020000
+t "\C2×2"
+bo 0.703704 0.384500 0.888889 0.542750
+m 0.703704 0.384500
+t "\R4"
+m 0.703704 0.542750
+t "\R5.5"
+li 0.703704 0.437250 0.888889 0.437250
+m 0.703704 0.437250
+t "\R4.5"
+li 0.750000 0.384500 0.842593 0.384500
+li 0.796296 0.384500 0.796296 0.384500
+m 0.750000 0.384500
+t "\R4"
+li 0.750000 0.595500 0.842593 0.595500
+li 0.796296 0.542750 0.796296 0.595500
+m 0.750000 0.595500
+t "\R6"
+cl
@@ -0,0 +1,16 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:6.5]
+set xtics noenhanced ("Encode-8 ns/op" 1, "Encode-8 B/op" 2, "Encode-8 allocs/op" 3, "Decode-8 ns/op" 4, "Decode-8 B/op" 5, "Decode-8 allocs/op" 6)
+$boxes << EOD
+1 2400 2388 2501 2456.5 2412 0
+2 512 512 512 512 512 0
+3 3 3 3 3 3 0
+4 4065.5 4011 4305 4212.5 4120 0
+5 1024 1024 1032 1028 1024 0
+6 9 9 10 9.5 9 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,109 @@
+m 0.106481 0.020000
+t "\CEncode-8 ns/op"
+bo 0.055556 0.560321 0.157407 0.571878
+m 0.055556 0.560321
+t "\R2.4e+03"
+m 0.055556 0.571878
+t "\R2.46e+03"
+li 0.055556 0.562775 0.157407 0.562775
+m 0.055556 0.562775
+t "\R2.41e+03"
+li 0.081019 0.557866 0.131944 0.557866
+li 0.106481 0.560321 0.106481 0.557866
+m 0.081019 0.557866
+t "\R2.39e+03"
+li 0.081019 0.580981 0.131944 0.580981
+li 0.106481 0.571878 0.106481 0.580981
+m 0.081019 0.580981
+t "\R2.5e+03"
+m 0.263889 0.020000
+t "\CEncode-8 B/op"
+bo 0.212963 0.174119 0.314815 0.174119
+m 0.212963 0.174119
+t "\R512"
+m 0.212963 0.174119
+t "\R512"
+li 0.212963 0.174119 0.314815 0.174119
+m 0.212963 0.174119
+t "\R512"
+li 0.238426 0.174119 0.289352 0.174119
+li 0.263889 0.174119 0.263889 0.174119
+m 0.238426 0.174119
+t "\R512"
+li 0.238426 0.174119 0.289352 0.174119
+li 0.263889 0.174119 0.263889 0.174119
+m 0.238426 0.174119
+t "\R512"
+m 0.421296 0.020000
+t "\CEncode-8 allocs/op"
+bo 0.370370 0.070000 0.472222 0.070000
+m 0.370370 0.070000
+t "\R3"
+m 0.370370 0.070000
+t "\R3"
+li 0.370370 0.070000 0.472222 0.070000
+m 0.370370 0.070000
+t "\R3"
+li 0.395833 0.070000 0.446759 0.070000
+li 0.421296 0.070000 0.421296 0.070000
+m 0.395833 0.070000
+t "\R3"
+li 0.395833 0.070000 0.446759 0.070000
+li 0.421296 0.070000 0.421296 0.070000
+m 0.395833 0.070000
+t "\R3"
+m 0.578704 0.020000
+t "\CDecode-8 ns/op"
+bo 0.527778 0.901009 0.629630 0.931079
+m 0.527778 0.901009
+t "\R4.07e+03"
+m 0.527778 0.931079
+t "\R4.21e+03"
+li 0.527778 0.912157 0.629630 0.912157
+m 0.527778 0.912157
+t "\R4.12e+03"
+li 0.553241 0.889861 0.604167 0.889861
+li 0.578704 0.901009 0.578704 0.889861
+m 0.553241 0.889861
+t "\R4.01e+03"
+li 0.553241 0.950000 0.604167 0.950000
+li 0.578704 0.931079 0.578704 0.950000
+m 0.553241 0.950000
+t "\R4.3e+03"
+m 0.736111 0.020000
+t "\CDecode-8 B/op"
+bo 0.685185 0.278852 0.787037 0.279670
+m 0.685185 0.278852
+t "\R1.02e+03"
+m 0.685185 0.279670
+t "\R1.03e+03"
+li 0.685185 0.278852 0.787037 0.278852
+m 0.685185 0.278852
+t "\R1.02e+03"
+li 0.710648 0.278852 0.761574 0.278852
+li 0.736111 0.278852 0.736111 0.278852
+m 0.710648 0.278852
+t "\R1.02e+03"
+li 0.710648 0.280488 0.761574 0.280488
+li 0.736111 0.279670 0.736111 0.280488
+m 0.710648 0.280488
+t "\R1.03e+03"
+m 0.893519 0.020000
+t "\CDecode-8 allocs/op"
+bo 0.842593 0.071227 0.944444 0.071330
+m 0.842593 0.071227
+t "\R9"
+m 0.842593 0.071330
+t "\R9.5"
+li 0.842593 0.071227 0.944444 0.071227
+m 0.842593 0.071227
+t "\R9"
+li 0.868056 0.071227 0.918981 0.071227
+li 0.893519 0.071227 0.893519 0.071227
+m 0.868056 0.071227
+t "\R9"
+li 0.868056 0.071432 0.918981 0.071432
+li 0.893519 0.071330 0.893519 0.071432
+m 0.868056 0.071432
+t "\R10"
+cl
@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("a/small" 1, "b/large" 2, "a/large" 3)
+$boxes << EOD
+1 12.5 12 14 13.5 13 0
+2 30.5 30 34 32.5 31 0
+3 20 20 22 22 21 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,55 @@
+m 0.203704 0.020000
+t "\Ca/small"
+bo 0.111111 0.090000 0.296296 0.130000
+m 0.111111 0.090000
+t "\R12.5"
+m 0.111111 0.130000
+t "\R13.5"
+li 0.111111 0.110000 0.296296 0.110000
+m 0.111111 0.110000
+t "\R13"
+li 0.157407 0.070000 0.250000 0.070000
+li 0.203704 0.090000 0.203704 0.070000
+m 0.157407 0.070000
+t "\R12"
+li 0.157407 0.150000 0.250000 0.150000
+li 0.203704 0.130000 0.203704 0.150000
+m 0.157407 0.150000
+t "\R14"
+m 0.500000 0.020000
+t "\Cb/large"
+bo 0.407407 0.810000 0.592593 0.890000
+m 0.407407 0.810000
+t "\R30.5"
+m 0.407407 0.890000
+t "\R32.5"
+li 0.407407 0.830000 0.592593 0.830000
+m 0.407407 0.830000
+t "\R31"
+li 0.453704 0.790000 0.546296 0.790000
+li 0.500000 0.810000 0.500000 0.790000
+m 0.453704 0.790000
+t "\R30"
+li 0.453704 0.950000 0.546296 0.950000
+li 0.500000 0.890000 0.500000 0.950000
+m 0.453704 0.950000
+t "\R34"
+m 0.796296 0.020000
+t "\Ca/large"
+bo 0.703704 0.390000 0.888889 0.470000
+m 0.703704 0.390000
+t "\R20"
+m 0.703704 0.470000
+t "\R22"
+li 0.703704 0.430000 0.888889 0.430000
+m 0.703704 0.430000
+t "\R21"
+li 0.750000 0.390000 0.842593 0.390000
+li 0.796296 0.390000 0.796296 0.390000
+m 0.750000 0.390000
+t "\R20"
+li 0.750000 0.470000 0.842593 0.470000
+li 0.796296 0.470000 0.796296 0.470000
+m 0.750000 0.470000
+t "\R22"
+cl
@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:4.5]
+set xtics noenhanced ("r/a" 1, "r/b" 2, "w/a" 3, "w/b" 4)
+$boxes << EOD
+1 1.5 1 3 2.5 2 0
+2 2.5 2 4 3.5 3 0
+3 3.5 3 5 4.5 4 0
+4 1 1 9 9 5 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("hdr1" 1, "read" 2, "write" 3)
+$boxes << EOD
+1 1355.8 812 3105 2006 1595 0
+2 740.9090909090909 600 1900 900 820.5882352941177 0
+3 1407.142857142857 1200 2047 1569.2307692307693 1485.7142857142858 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
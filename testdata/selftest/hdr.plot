@@ -0,0 +1,55 @@
+m 0.203704 0.020000
+t "\Chdr1"
+bo 0.111111 0.335511 0.296296 0.563924
+m 0.111111 0.335511
+t "\R1.36e+03"
+m 0.111111 0.563924
+t "\R2.01e+03"
+li 0.111111 0.419541 0.296296 0.419541
+m 0.111111 0.419541
+t "\R1.6e+03"
+li 0.157407 0.144475 0.250000 0.144475
+li 0.203704 0.335511 0.203704 0.144475
+m 0.157407 0.144475
+t "\R812"
+li 0.157407 0.950000 0.250000 0.950000
+li 0.203704 0.563924 0.203704 0.950000
+m 0.157407 0.950000
+t "\R3.1e+03"
+m 0.500000 0.020000
+t "\Cread"
+bo 0.407407 0.119501 0.592593 0.175389
+m 0.407407 0.119501
+t "\R741"
+m 0.407407 0.175389
+t "\R900"
+li 0.407407 0.147492 0.592593 0.147492
+m 0.407407 0.147492
+t "\R821"
+li 0.453704 0.070000 0.546296 0.070000
+li 0.500000 0.119501 0.500000 0.070000
+m 0.453704 0.070000
+t "\R600"
+li 0.453704 0.526687 0.546296 0.526687
+li 0.500000 0.175389 0.500000 0.526687
+m 0.453704 0.526687
+t "\R1.9e+03"
+m 0.796296 0.020000
+t "\Cwrite"
+bo 0.703704 0.353547 0.888889 0.410488
+m 0.703704 0.353547
+t "\R1.41e+03"
+m 0.703704 0.410488
+t "\R1.57e+03"
+li 0.703704 0.381149 0.888889 0.381149
+m 0.703704 0.381149
+t "\R1.49e+03"
+li 0.750000 0.280778 0.842593 0.280778
+li 0.796296 0.353547 0.796296 0.280778
+m 0.750000 0.280778
+t "\R1.2e+03"
+li 0.750000 0.578327 0.842593 0.578327
+li 0.796296 0.410488 0.796296 0.578327
+m 0.750000 0.578327
+t "\R2.05e+03"
+cl
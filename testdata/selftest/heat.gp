@@ -0,0 +1,15 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:5.5]
+set xtics noenhanced ("base" 1, "a" 2, "b" 3, "c" 4, "d" 5)
+$boxes << EOD
+1 11 10 14 13 12 0
+2 12 10 15 14 13 0
+3 15 14 18 17 16 0
+4 21 20 24 23 22 0
+5 6 5 9 8 7 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
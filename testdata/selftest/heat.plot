@@ -0,0 +1,137 @@
+m 0.126667 0.020000
+t "\Cbase"
+bo 0.066667 0.347895 0.186667 0.440526
+m 0.066667 0.347895
+t "\R11"
+m 0.066667 0.440526
+t "\R13"
+li 0.066667 0.394211 0.186667 0.394211
+m 0.066667 0.394211
+t "\R12"
+li 0.096667 0.301579 0.156667 0.301579
+li 0.126667 0.347895 0.126667 0.301579
+m 0.096667 0.301579
+t "\R10"
+li 0.096667 0.486842 0.156667 0.486842
+li 0.126667 0.440526 0.126667 0.486842
+m 0.096667 0.486842
+t "\R14"
+m 0.313333 0.020000
+t "\Ca"
+li 0.253333 0.414211 0.373333 0.414211
+li 0.253333 0.454211 0.373333 0.454211
+bo 0.253333 0.394211 0.373333 0.486842
+m 0.253333 0.394211
+t "\R12"
+m 0.253333 0.486842
+t "\R14"
+li 0.253333 0.440526 0.373333 0.440526
+m 0.253333 0.440526
+t "\R13"
+li 0.283333 0.301579 0.343333 0.301579
+li 0.313333 0.394211 0.313333 0.301579
+m 0.283333 0.301579
+t "\R10"
+li 0.283333 0.533158 0.343333 0.533158
+li 0.313333 0.486842 0.313333 0.533158
+m 0.283333 0.533158
+t "\R15"
+m 0.500000 0.020000
+t "\Cb"
+li 0.440000 0.538158 0.560000 0.538158
+li 0.440000 0.548158 0.560000 0.548158
+li 0.440000 0.558158 0.560000 0.558158
+li 0.440000 0.568158 0.560000 0.568158
+li 0.440000 0.578158 0.560000 0.578158
+li 0.440000 0.588158 0.560000 0.588158
+li 0.440000 0.598158 0.560000 0.598158
+li 0.440000 0.608158 0.560000 0.608158
+li 0.440000 0.618158 0.560000 0.618158
+bo 0.440000 0.533158 0.560000 0.625789
+m 0.440000 0.533158
+t "\R15"
+m 0.440000 0.625789
+t "\R17"
+li 0.440000 0.579474 0.560000 0.579474
+m 0.440000 0.579474
+t "\R16"
+li 0.470000 0.486842 0.530000 0.486842
+li 0.500000 0.533158 0.500000 0.486842
+m 0.470000 0.486842
+t "\R14"
+li 0.470000 0.672105 0.530000 0.672105
+li 0.500000 0.625789 0.500000 0.672105
+m 0.470000 0.672105
+t "\R18"
+m 0.686667 0.020000
+t "\Cc"
+li 0.626667 0.813053 0.746667 0.813053
+li 0.626667 0.817053 0.746667 0.817053
+li 0.626667 0.821053 0.746667 0.821053
+li 0.626667 0.825053 0.746667 0.825053
+li 0.626667 0.829053 0.746667 0.829053
+li 0.626667 0.833053 0.746667 0.833053
+li 0.626667 0.837053 0.746667 0.837053
+li 0.626667 0.841053 0.746667 0.841053
+li 0.626667 0.845053 0.746667 0.845053
+li 0.626667 0.849053 0.746667 0.849053
+li 0.626667 0.853053 0.746667 0.853053
+li 0.626667 0.857053 0.746667 0.857053
+li 0.626667 0.861053 0.746667 0.861053
+li 0.626667 0.865053 0.746667 0.865053
+li 0.626667 0.869053 0.746667 0.869053
+li 0.626667 0.873053 0.746667 0.873053
+li 0.626667 0.877053 0.746667 0.877053
+li 0.626667 0.881053 0.746667 0.881053
+li 0.626667 0.885053 0.746667 0.885053
+li 0.626667 0.889053 0.746667 0.889053
+li 0.626667 0.893053 0.746667 0.893053
+li 0.626667 0.897053 0.746667 0.897053
+li 0.626667 0.901053 0.746667 0.901053
+bo 0.626667 0.811053 0.746667 0.903684
+m 0.626667 0.811053
+t "\R21"
+m 0.626667 0.903684
+t "\R23"
+li 0.626667 0.857368 0.746667 0.857368
+m 0.626667 0.857368
+t "\R22"
+li 0.656667 0.764737 0.716667 0.764737
+li 0.686667 0.811053 0.686667 0.764737
+m 0.656667 0.764737
+t "\R20"
+li 0.656667 0.950000 0.716667 0.950000
+li 0.686667 0.903684 0.686667 0.950000
+m 0.656667 0.950000
+t "\R24"
+m 0.873333 0.020000
+t "\Cd"
+li 0.813333 0.120316 0.933333 0.120316
+li 0.813333 0.128316 0.933333 0.128316
+li 0.813333 0.136316 0.933333 0.136316
+li 0.813333 0.144316 0.933333 0.144316
+li 0.813333 0.152316 0.933333 0.152316
+li 0.813333 0.160316 0.933333 0.160316
+li 0.813333 0.168316 0.933333 0.168316
+li 0.813333 0.176316 0.933333 0.176316
+li 0.813333 0.184316 0.933333 0.184316
+li 0.813333 0.192316 0.933333 0.192316
+li 0.813333 0.200316 0.933333 0.200316
+li 0.813333 0.208316 0.933333 0.208316
+bo 0.813333 0.116316 0.933333 0.208947
+m 0.813333 0.116316
+t "\R6"
+m 0.813333 0.208947
+t "\R8"
+li 0.813333 0.162632 0.933333 0.162632
+m 0.813333 0.162632
+t "\R7"
+li 0.843333 0.070000 0.903333 0.070000
+li 0.873333 0.116316 0.873333 0.070000
+m 0.843333 0.070000
+t "\R5"
+li 0.843333 0.255263 0.903333 0.255263
+li 0.873333 0.208947 0.873333 0.255263
+m 0.843333 0.255263
+t "\R9"
+cl
@@ -0,0 +1,64 @@
+li 0.220000 0.120000 0.880000 0.120000
+li 0.302500 0.110000 0.302500 0.120000
+m 0.302500 0.100000
+t "\C20"
+li 0.467500 0.110000 0.467500 0.120000
+m 0.467500 0.100000
+t "\C40"
+li 0.632500 0.110000 0.632500 0.120000
+m 0.632500 0.100000
+t "\C60"
+li 0.797500 0.110000 0.797500 0.120000
+m 0.797500 0.100000
+t "\C80"
+m 0.170000 0.376667
+t "\Rread_latency_p99"
+bo 0.236500 0.266667 0.261250 0.486667
+m 0.236500 0.256667
+t "\C12"
+m 0.261250 0.256667
+t "\C15"
+li 0.253000 0.266667 0.253000 0.486667
+m 0.253000 0.256667
+t "\C14"
+li 0.220000 0.321667 0.220000 0.431667
+li 0.236500 0.376667 0.220000 0.376667
+m 0.220000 0.311667
+t "\C10"
+li 0.385000 0.321667 0.385000 0.431667
+li 0.261250 0.376667 0.385000 0.376667
+m 0.385000 0.311667
+t "\C30"
+m 0.170000 0.743333
+t "\Rwrite_latency"
+bo 0.319000 0.633333 0.368500 0.853333
+m 0.319000 0.623333
+t "\C22"
+m 0.368500 0.623333
+t "\C28"
+li 0.347875 0.633333 0.347875 0.853333
+m 0.347875 0.623333
+t "\C25.5"
+li 0.302500 0.688333 0.302500 0.798333
+li 0.319000 0.743333 0.302500 0.743333
+m 0.302500 0.678333
+t "\C20"
+li 0.880000 0.688333 0.880000 0.798333
+li 0.368500 0.743333 0.880000 0.743333
+m 0.880000 0.678333
+t "\C90"
+m 0.852500 0.729583
+v 0.880000 0.743333
+v 0.852500 0.757083
+v 0.852500 0.729583
+m 0.900000 0.743333
+t "\Lcensored=1"
+m 0.900000 0.723333
+t "\Lkm=25"
+m 0.045000 0.015000
+v 0.050000 0.025000
+v 0.055000 0.015000
+v 0.045000 0.015000
+m 0.070000 0.020000
+t "\Lcensored at a limit; km: Kaplan-Meier median"
+cl
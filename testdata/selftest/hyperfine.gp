@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("gzip -1 data.bin" 1, "gzip -9 data.bin" 2)
+$boxes << EOD
+1 0.213 0.2071 0.2403 0.222 0.2169 0
+2 0.8833 0.8604 0.9801 0.9086 0.8951 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
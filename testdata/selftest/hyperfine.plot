@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cgzip -1 data.bin"
+bo 0.166667 0.076717 0.416667 0.086962
+m 0.166667 0.076717
+t "\R0.213"
+m 0.166667 0.086962
+t "\R0.222"
+li 0.166667 0.081157 0.416667 0.081157
+m 0.166667 0.081157
+t "\R0.217"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.076717 0.291667 0.070000
+m 0.229167 0.070000
+t "\R0.207"
+li 0.229167 0.107796 0.354167 0.107796
+li 0.291667 0.086962 0.291667 0.107796
+m 0.229167 0.107796
+t "\R0.24"
+m 0.708333 0.020000
+t "\Cgzip -9 data.bin"
+bo 0.583333 0.839801 0.833333 0.868603
+m 0.583333 0.839801
+t "\R0.883"
+m 0.583333 0.868603
+t "\R0.909"
+li 0.583333 0.853234 0.833333 0.853234
+m 0.583333 0.853234
+t "\R0.895"
+li 0.645833 0.813731 0.770833 0.813731
+li 0.708333 0.839801 0.708333 0.813731
+m 0.645833 0.813731
+t "\R0.86"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.868603 0.708333 0.950000
+m 0.645833 0.950000
+t "\R0.98"
+cl
@@ -0,0 +1,17 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("get" 1, "put" 2)
+$boxes << EOD
+1 10.5 10 12 12 11 0
+2 20.5 20 22 22 21 0
+EOD
+$outliers << EOD
+1 95 0
+2 90 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable, \
+	$outliers using 1:2:3 with points pt 6 lc rgb variable
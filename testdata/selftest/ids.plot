@@ -0,0 +1,39 @@
+m 0.291667 0.020000
+t "\Cget"
+bo 0.166667 0.075176 0.416667 0.090706
+m 0.166667 0.075176
+t "\R10.5"
+m 0.166667 0.090706
+t "\R12"
+li 0.166667 0.080353 0.416667 0.080353
+m 0.166667 0.080353
+t "\R11"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.075176 0.291667 0.070000
+m 0.229167 0.070000
+t "\R10"
+li 0.229167 0.090706 0.354167 0.090706
+li 0.291667 0.090706 0.291667 0.090706
+m 0.229167 0.090706
+t "\R12"
+ci 0.291667 0.950000 0.007812
+m 0.708333 0.020000
+t "\Cput"
+bo 0.583333 0.178706 0.833333 0.194235
+m 0.583333 0.178706
+t "\R20.5"
+m 0.583333 0.194235
+t "\R22"
+li 0.583333 0.183882 0.833333 0.183882
+m 0.583333 0.183882
+t "\R21"
+li 0.645833 0.173529 0.770833 0.173529
+li 0.708333 0.178706 0.708333 0.173529
+m 0.645833 0.173529
+t "\R20"
+li 0.645833 0.194235 0.770833 0.194235
+li 0.708333 0.194235 0.708333 0.194235
+m 0.645833 0.194235
+t "\R22"
+ci 0.708333 0.898235 0.007812
+cl
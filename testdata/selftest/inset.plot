@@ -0,0 +1,113 @@
+m 0.104167 0.020000
+t "\Cbig"
+bo 0.055556 0.420942 0.152778 0.773647
+m 0.055556 0.420942
+t "\R200"
+m 0.055556 0.773647
+t "\R400"
+li 0.055556 0.597295 0.152778 0.597295
+m 0.055556 0.597295
+t "\R300"
+li 0.079861 0.244589 0.128472 0.244589
+li 0.104167 0.420942 0.104167 0.244589
+m 0.079861 0.244589
+t "\R100"
+li 0.079861 0.950000 0.128472 0.950000
+li 0.104167 0.773647 0.104167 0.950000
+m 0.079861 0.950000
+t "\R500"
+m 0.256944 0.020000
+t "\Ca"
+bo 0.208333 0.071764 0.305556 0.075291
+m 0.208333 0.071764
+t "\R2"
+m 0.208333 0.075291
+t "\R4"
+li 0.208333 0.073527 0.305556 0.073527
+m 0.208333 0.073527
+t "\R3"
+li 0.232639 0.070000 0.281250 0.070000
+li 0.256944 0.071764 0.256944 0.070000
+m 0.232639 0.070000
+t "\R1"
+li 0.232639 0.077054 0.281250 0.077054
+li 0.256944 0.075291 0.256944 0.077054
+m 0.232639 0.077054
+t "\R5"
+m 0.409722 0.020000
+t "\Cb"
+bo 0.361111 0.073527 0.458333 0.077054
+m 0.361111 0.073527
+t "\R3"
+m 0.361111 0.077054
+t "\R5"
+li 0.361111 0.075291 0.458333 0.075291
+m 0.361111 0.075291
+t "\R4"
+li 0.385417 0.071764 0.434028 0.071764
+li 0.409722 0.073527 0.409722 0.071764
+m 0.385417 0.071764
+t "\R2"
+li 0.385417 0.078818 0.434028 0.078818
+li 0.409722 0.077054 0.409722 0.078818
+m 0.385417 0.078818
+t "\R6"
+m 0.562500 0.020000
+t "\Cc"
+bo 0.513889 0.420942 0.611111 0.597295
+m 0.513889 0.420942
+t "\R200"
+m 0.513889 0.597295
+t "\R300"
+li 0.513889 0.509118 0.611111 0.509118
+m 0.513889 0.509118
+t "\R250"
+li 0.538194 0.332766 0.586806 0.332766
+li 0.562500 0.420942 0.562500 0.332766
+m 0.538194 0.332766
+t "\R150"
+li 0.538194 0.685471 0.586806 0.685471
+li 0.562500 0.597295 0.562500 0.685471
+m 0.538194 0.685471
+t "\R350"
+bo 0.686667 0.000000 0.990000 0.980000
+bo 0.203333 0.070000 0.463333 0.078818
+li 0.463333 0.078818 0.686667 0.980000
+li 0.463333 0.070000 0.686667 0.000000
+m 0.775139 0.020000
+t "\Ca"
+bo 0.737222 0.241400 0.813056 0.586200
+m 0.737222 0.241400
+t "\R2"
+m 0.737222 0.586200
+t "\R4"
+li 0.737222 0.413800 0.813056 0.413800
+m 0.737222 0.413800
+t "\R3"
+li 0.756181 0.069000 0.794097 0.069000
+li 0.775139 0.241400 0.775139 0.069000
+m 0.756181 0.069000
+t "\R1"
+li 0.756181 0.758600 0.794097 0.758600
+li 0.775139 0.586200 0.775139 0.758600
+m 0.756181 0.758600
+t "\R5"
+m 0.901528 0.020000
+t "\Cb"
+bo 0.863611 0.413800 0.939444 0.758600
+m 0.863611 0.413800
+t "\R3"
+m 0.863611 0.758600
+t "\R5"
+li 0.863611 0.586200 0.939444 0.586200
+m 0.863611 0.586200
+t "\R4"
+li 0.882569 0.241400 0.920486 0.241400
+li 0.901528 0.413800 0.901528 0.241400
+m 0.882569 0.241400
+t "\R2"
+li 0.882569 0.931000 0.920486 0.931000
+li 0.901528 0.758600 0.901528 0.931000
+m 0.882569 0.931000
+t "\R6"
+cl
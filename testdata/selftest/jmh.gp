@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("CodecBench.decode:size=1024" 1, "CodecBench.encode:size=1024" 2)
+$boxes << EOD
+1 405.1 398.2 431.2 418.4 412.35 0
+2 272.6923076923077 250 610 302.1 287.10526315789474 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
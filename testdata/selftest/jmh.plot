@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\CCodecBench.decode:size=1024"
+bo 0.166667 0.449133 0.416667 0.481644
+m 0.166667 0.449133
+t "\R405"
+m 0.166667 0.481644
+t "\R418"
+li 0.166667 0.466856 0.416667 0.466856
+m 0.166667 0.466856
+t "\R412"
+li 0.229167 0.432267 0.354167 0.432267
+li 0.291667 0.449133 0.291667 0.432267
+m 0.229167 0.432267
+t "\R398"
+li 0.229167 0.512933 0.354167 0.512933
+li 0.291667 0.481644 0.291667 0.512933
+m 0.229167 0.512933
+t "\R431"
+m 0.708333 0.020000
+t "\CCodecBench.encode:size=1024"
+bo 0.583333 0.125470 0.833333 0.197356
+m 0.583333 0.125470
+t "\R273"
+m 0.583333 0.197356
+t "\R302"
+li 0.583333 0.160702 0.833333 0.160702
+m 0.583333 0.160702
+t "\R287"
+li 0.645833 0.070000 0.770833 0.070000
+li 0.708333 0.125470 0.708333 0.070000
+m 0.645833 0.070000
+t "\R250"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.197356 0.708333 0.950000
+m 0.645833 0.950000
+t "\R610"
+cl
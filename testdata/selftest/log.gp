@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("fast" 1, "slow" 2, "huge" 3)
+set logscale y 10
+$boxes << EOD
+1 0.25 0.2 1.2 0.4 0.3 0
+2 55 40 2000 120 70 0
+3 3750 3000 40000 24500 6750 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
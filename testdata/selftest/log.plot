@@ -0,0 +1,71 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.186033 0.080000 0.186033
+m 0.070000 0.186033
+t "\R1"
+li 0.070000 0.352038 0.080000 0.352038
+m 0.070000 0.352038
+t "\R10"
+li 0.070000 0.518044 0.080000 0.518044
+m 0.070000 0.518044
+t "\R100"
+li 0.070000 0.684049 0.080000 0.684049
+m 0.070000 0.684049
+t "\R1e+03"
+li 0.070000 0.850055 0.080000 0.850055
+m 0.070000 0.850055
+t "\R1e+04"
+m 0.267407 0.020000
+t "\Cfast"
+bo 0.182222 0.086088 0.352593 0.119973
+m 0.182222 0.086088
+t "\R0.25"
+m 0.182222 0.119973
+t "\R0.4"
+li 0.182222 0.099232 0.352593 0.099232
+m 0.182222 0.099232
+t "\R0.3"
+li 0.224815 0.070000 0.310000 0.070000
+li 0.267407 0.086088 0.267407 0.070000
+m 0.224815 0.070000
+t "\R0.2"
+li 0.224815 0.199177 0.310000 0.199177
+li 0.267407 0.119973 0.267407 0.199177
+m 0.224815 0.199177
+t "\R1.2"
+m 0.540000 0.020000
+t "\Cslow"
+bo 0.454815 0.474943 0.625185 0.531188
+m 0.454815 0.474943
+t "\R55"
+m 0.454815 0.531188
+t "\R120"
+li 0.454815 0.492329 0.625185 0.492329
+m 0.454815 0.492329
+t "\R70"
+li 0.497407 0.451984 0.582593 0.451984
+li 0.540000 0.474943 0.540000 0.451984
+m 0.497407 0.451984
+t "\R40"
+li 0.497407 0.734022 0.582593 0.734022
+li 0.540000 0.531188 0.540000 0.734022
+m 0.497407 0.734022
+t "\R2e+03"
+m 0.812593 0.020000
+t "\Chuge"
+bo 0.727407 0.779342 0.897778 0.914658
+m 0.727407 0.779342
+t "\R3.75e+03"
+m 0.727407 0.914658
+t "\R2.45e+04"
+li 0.727407 0.821718 0.897778 0.821718
+m 0.727407 0.821718
+t "\R6.75e+03"
+li 0.770000 0.763254 0.855185 0.763254
+li 0.812593 0.779342 0.812593 0.763254
+m 0.770000 0.763254
+t "\R3e+03"
+li 0.770000 0.950000 0.855185 0.950000
+li 0.812593 0.914658 0.812593 0.950000
+m 0.770000 0.950000
+t "\R4e+04"
+cl
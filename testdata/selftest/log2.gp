@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("batch-16" 1, "batch-256" 2, "batch-4096" 3)
+set logscale y 2
+$boxes << EOD
+1 2100 1800 3900 2600 2400 0
+2 7200 5000 12000 9900 8100 0
+3 81000 60000 700000 130000 95000 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,83 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.089048 0.080000 0.089048
+m 0.070000 0.089048
+t "\R2Ki"
+li 0.070000 0.191335 0.080000 0.191335
+m 0.070000 0.191335
+t "\R4Ki"
+li 0.070000 0.293623 0.080000 0.293623
+m 0.070000 0.293623
+t "\R8Ki"
+li 0.070000 0.395910 0.080000 0.395910
+m 0.070000 0.395910
+t "\R16Ki"
+li 0.070000 0.498197 0.080000 0.498197
+m 0.070000 0.498197
+t "\R32Ki"
+li 0.070000 0.600485 0.080000 0.600485
+m 0.070000 0.600485
+t "\R64Ki"
+li 0.070000 0.702772 0.080000 0.702772
+m 0.070000 0.702772
+t "\R128Ki"
+li 0.070000 0.805059 0.080000 0.805059
+m 0.070000 0.805059
+t "\R256Ki"
+li 0.070000 0.907347 0.080000 0.907347
+m 0.070000 0.907347
+t "\R512Ki"
+m 0.267407 0.020000
+t "\Cbatch-16"
+bo 0.182222 0.092748 0.352593 0.124265
+m 0.182222 0.092748
+t "\R2.1e+03"
+m 0.182222 0.124265
+t "\R2.6e+03"
+li 0.182222 0.112453 0.352593 0.112453
+m 0.182222 0.112453
+t "\R2.4e+03"
+li 0.224815 0.070000 0.310000 0.070000
+li 0.267407 0.092748 0.267407 0.070000
+m 0.224815 0.070000
+t "\R1.8e+03"
+li 0.224815 0.184099 0.310000 0.184099
+li 0.267407 0.124265 0.267407 0.184099
+m 0.224815 0.184099
+t "\R3.9e+03"
+m 0.540000 0.020000
+t "\Cbatch-256"
+bo 0.454815 0.274575 0.625185 0.321569
+m 0.454815 0.274575
+t "\R7.2e+03"
+m 0.454815 0.321569
+t "\R9.9e+03"
+li 0.454815 0.291956 0.625185 0.291956
+m 0.454815 0.291956
+t "\R8.1e+03"
+li 0.497407 0.220765 0.582593 0.220765
+li 0.540000 0.274575 0.540000 0.220765
+m 0.497407 0.220765
+t "\R5e+03"
+li 0.497407 0.349957 0.582593 0.349957
+li 0.540000 0.321569 0.540000 0.349957
+m 0.497407 0.349957
+t "\R1.2e+04"
+m 0.812593 0.020000
+t "\Cbatch-4096"
+bo 0.727407 0.631747 0.897778 0.701560
+m 0.727407 0.631747
+t "\R8.1e+04"
+m 0.727407 0.701560
+t "\R1.3e+05"
+li 0.727407 0.655274 0.897778 0.655274
+m 0.727407 0.655274
+t "\R9.5e+04"
+li 0.770000 0.587461 0.855185 0.587461
+li 0.812593 0.631747 0.812593 0.587461
+m 0.770000 0.587461
+t "\R6e+04"
+li 0.770000 0.950000 0.855185 0.950000
+li 0.812593 0.701560 0.812593 0.950000
+m 0.770000 0.950000
+t "\R7e+05"
+cl
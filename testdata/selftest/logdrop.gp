@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("idle" 1, "busy" 2)
+set logscale y 10
+$boxes << EOD
+1 0.005 0 40 8.5 0.3 0
+2 3 0.5 300 80 20 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cidle"
+bo 0.166667 0.182302 0.416667 0.701333
+m 0.166667 0.182302
+t "\R0.005"
+m 0.166667 0.701333
+t "\R8.5"
+li 0.166667 0.467995 0.416667 0.467995
+m 0.166667 0.467995
+t "\R0.3"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.182302 0.291667 0.070000
+m 0.229167 0.070000
+t "\R0"
+li 0.229167 0.809405 0.354167 0.809405
+li 0.291667 0.701333 0.291667 0.809405
+m 0.229167 0.809405
+t "\R40"
+m 0.708333 0.020000
+t "\Cbusy"
+bo 0.583333 0.628663 0.833333 0.857771
+m 0.583333 0.628663
+t "\R3"
+m 0.583333 0.857771
+t "\R80"
+li 0.583333 0.761039 0.833333 0.761039
+m 0.583333 0.761039
+t "\R20"
+li 0.645833 0.503639 0.770833 0.503639
+li 0.708333 0.628663 0.708333 0.503639
+m 0.645833 0.503639
+t "\R0.5"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.857771 0.708333 0.950000
+m 0.645833 0.950000
+t "\R300"
+cl
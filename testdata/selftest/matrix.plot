@@ -0,0 +1,85 @@
+m 0.325000 0.980000
+t "\Cx"
+m 0.775000 0.980000
+t "\Cy"
+m 0.080000 0.720000
+t "\Ra"
+m 0.080000 0.240000
+t "\Rb"
+bo 0.100000 0.480000 0.550000 0.960000
+bo 0.250000 0.549375 0.400000 0.600125
+m 0.250000 0.549375
+t "\R1.5"
+m 0.250000 0.600125
+t "\R2.5"
+li 0.250000 0.574750 0.400000 0.574750
+m 0.250000 0.574750
+t "\R2"
+li 0.287500 0.524000 0.362500 0.524000
+li 0.325000 0.549375 0.325000 0.524000
+m 0.287500 0.524000
+t "\R1"
+li 0.287500 0.625500 0.362500 0.625500
+li 0.325000 0.600125 0.325000 0.625500
+m 0.287500 0.625500
+t "\R3"
+m 0.540000 0.940000
+t "\Rn=3"
+bo 0.550000 0.480000 1.000000 0.960000
+bo 0.700000 0.600125 0.850000 0.650875
+m 0.700000 0.600125
+t "\R2.5"
+m 0.700000 0.650875
+t "\R3.5"
+li 0.700000 0.625500 0.850000 0.625500
+m 0.700000 0.625500
+t "\R3"
+li 0.737500 0.574750 0.812500 0.574750
+li 0.775000 0.600125 0.775000 0.574750
+m 0.737500 0.574750
+t "\R2"
+li 0.737500 0.676250 0.812500 0.676250
+li 0.775000 0.650875 0.775000 0.676250
+m 0.737500 0.676250
+t "\R4"
+m 0.990000 0.940000
+t "\Rn=3"
+bo 0.100000 0.000000 0.550000 0.480000
+bo 0.250000 0.170875 0.400000 0.221625
+m 0.250000 0.170875
+t "\R3.5"
+m 0.250000 0.221625
+t "\R4.5"
+li 0.250000 0.196250 0.400000 0.196250
+m 0.250000 0.196250
+t "\R4"
+li 0.287500 0.145500 0.362500 0.145500
+li 0.325000 0.170875 0.325000 0.145500
+m 0.287500 0.145500
+t "\R3"
+li 0.287500 0.247000 0.362500 0.247000
+li 0.325000 0.221625 0.325000 0.247000
+m 0.287500 0.247000
+t "\R5"
+m 0.540000 0.460000
+t "\Rn=3"
+bo 0.550000 0.000000 1.000000 0.480000
+bo 0.700000 0.044000 0.850000 0.450000
+m 0.700000 0.044000
+t "\R1"
+m 0.700000 0.450000
+t "\R9"
+li 0.700000 0.247000 0.850000 0.247000
+m 0.700000 0.247000
+t "\R5"
+li 0.737500 0.044000 0.812500 0.044000
+li 0.775000 0.044000 0.775000 0.044000
+m 0.737500 0.044000
+t "\R1"
+li 0.737500 0.450000 0.812500 0.450000
+li 0.775000 0.450000 0.775000 0.450000
+m 0.737500 0.450000
+t "\R9"
+m 0.990000 0.460000
+t "\Rn=2"
+cl
@@ -0,0 +1,73 @@
+m 0.203704 0.060000
+t "\Cc"
+bo 0.111111 0.282540 0.296296 0.836576
+m 0.111111 0.282540
+t "\R2"
+m 0.111111 0.836576
+t "\R50"
+li 0.111111 0.294082 0.296296 0.294082
+m 0.111111 0.294082
+t "\R3"
+li 0.157407 0.270997 0.250000 0.270997
+li 0.203704 0.282540 0.203704 0.270997
+m 0.157407 0.270997
+t "\R1"
+li 0.157407 0.952000 0.250000 0.952000
+li 0.203704 0.836576 0.203704 0.952000
+m 0.157407 0.952000
+t "\R60"
+li 0.250000 0.108000 0.250000 0.946478
+li 0.226852 0.108000 0.273148 0.108000
+li 0.226852 0.946478 0.273148 0.946478
+ci 0.250000 0.527239 0.011574
+m 0.500000 0.060000
+t "\Ca"
+bo 0.407407 0.294082 0.592593 0.351794
+m 0.407407 0.294082
+t "\R3"
+m 0.407407 0.351794
+t "\R8"
+li 0.407407 0.322938 0.592593 0.322938
+m 0.407407 0.322938
+t "\R5.5"
+li 0.453704 0.270997 0.546296 0.270997
+li 0.500000 0.294082 0.500000 0.270997
+m 0.453704 0.270997
+t "\R1"
+li 0.453704 0.374879 0.546296 0.374879
+li 0.500000 0.351794 0.500000 0.374879
+m 0.453704 0.374879
+t "\R10"
+li 0.546296 0.297939 0.546296 0.347937
+li 0.523148 0.297939 0.569444 0.297939
+li 0.523148 0.347937 0.569444 0.347937
+ci 0.546296 0.322938 0.011574
+m 0.796296 0.060000
+t "\Cb"
+bo 0.703704 0.317167 0.888889 0.340252
+m 0.703704 0.317167
+t "\R5"
+m 0.703704 0.340252
+t "\R7"
+li 0.703704 0.328709 0.888889 0.328709
+m 0.703704 0.328709
+t "\R6"
+li 0.750000 0.305625 0.842593 0.305625
+li 0.796296 0.317167 0.796296 0.305625
+m 0.750000 0.305625
+t "\R4"
+li 0.750000 0.351794 0.842593 0.351794
+li 0.796296 0.340252 0.796296 0.351794
+m 0.750000 0.351794
+t "\R8"
+li 0.842593 0.317843 0.842593 0.339576
+li 0.819444 0.317843 0.865741 0.317843
+li 0.819444 0.339576 0.865741 0.339576
+ci 0.842593 0.328709 0.011574
+li 0.050000 0.010000 0.050000 0.030000
+li 0.045000 0.010000 0.055000 0.010000
+li 0.045000 0.030000 0.055000 0.030000
+ci 0.050000 0.020000 0.002500
+m 0.070000 0.020000
+t "\Lmean and 95% confidence interval"
+cl
@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:4.5]
+set xtics noenhanced ("a/f00d/latency" 1, "a/f00d/ttfb" 2, "b/f00d/latency" 3, "b/f00d/ttfb" 4)
+$boxes << EOD
+1 12.5 12 90 52.5 14 0
+2 3 3 4 4 3.5 0
+3 21.5 21 25 24.5 23 0
+4 5.5 5 7 6.5 6 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
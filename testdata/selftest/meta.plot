@@ -0,0 +1,163 @@
+li 0.500000 0.108000 0.958333 0.108000
+li 0.500000 0.118000 0.958333 0.118000
+li 0.500000 0.128000 0.958333 0.128000
+li 0.500000 0.138000 0.958333 0.138000
+li 0.500000 0.148000 0.958333 0.148000
+li 0.500000 0.158000 0.958333 0.158000
+li 0.500000 0.168000 0.958333 0.168000
+li 0.500000 0.178000 0.958333 0.178000
+li 0.500000 0.188000 0.958333 0.188000
+li 0.500000 0.198000 0.958333 0.198000
+li 0.500000 0.208000 0.958333 0.208000
+li 0.500000 0.218000 0.958333 0.218000
+li 0.500000 0.228000 0.958333 0.228000
+li 0.500000 0.238000 0.958333 0.238000
+li 0.500000 0.248000 0.958333 0.248000
+li 0.500000 0.258000 0.958333 0.258000
+li 0.500000 0.268000 0.958333 0.268000
+li 0.500000 0.278000 0.958333 0.278000
+li 0.500000 0.288000 0.958333 0.288000
+li 0.500000 0.298000 0.958333 0.298000
+li 0.500000 0.308000 0.958333 0.308000
+li 0.500000 0.318000 0.958333 0.318000
+li 0.500000 0.328000 0.958333 0.328000
+li 0.500000 0.338000 0.958333 0.338000
+li 0.500000 0.348000 0.958333 0.348000
+li 0.500000 0.358000 0.958333 0.358000
+li 0.500000 0.368000 0.958333 0.368000
+li 0.500000 0.378000 0.958333 0.378000
+li 0.500000 0.388000 0.958333 0.388000
+li 0.500000 0.398000 0.958333 0.398000
+li 0.500000 0.408000 0.958333 0.408000
+li 0.500000 0.418000 0.958333 0.418000
+li 0.500000 0.428000 0.958333 0.428000
+li 0.500000 0.438000 0.958333 0.438000
+li 0.500000 0.448000 0.958333 0.448000
+li 0.500000 0.458000 0.958333 0.458000
+li 0.500000 0.468000 0.958333 0.468000
+li 0.500000 0.478000 0.958333 0.478000
+li 0.500000 0.488000 0.958333 0.488000
+li 0.500000 0.498000 0.958333 0.498000
+li 0.500000 0.508000 0.958333 0.508000
+li 0.500000 0.518000 0.958333 0.518000
+li 0.500000 0.528000 0.958333 0.528000
+li 0.500000 0.538000 0.958333 0.538000
+li 0.500000 0.548000 0.958333 0.548000
+li 0.500000 0.558000 0.958333 0.558000
+li 0.500000 0.568000 0.958333 0.568000
+li 0.500000 0.578000 0.958333 0.578000
+li 0.500000 0.588000 0.958333 0.588000
+li 0.500000 0.598000 0.958333 0.598000
+li 0.500000 0.608000 0.958333 0.608000
+li 0.500000 0.618000 0.958333 0.618000
+li 0.500000 0.628000 0.958333 0.628000
+li 0.500000 0.638000 0.958333 0.638000
+li 0.500000 0.648000 0.958333 0.648000
+li 0.500000 0.658000 0.958333 0.658000
+li 0.500000 0.668000 0.958333 0.668000
+li 0.500000 0.678000 0.958333 0.678000
+li 0.500000 0.688000 0.958333 0.688000
+li 0.500000 0.698000 0.958333 0.698000
+li 0.500000 0.708000 0.958333 0.708000
+li 0.500000 0.718000 0.958333 0.718000
+li 0.500000 0.728000 0.958333 0.728000
+li 0.500000 0.738000 0.958333 0.738000
+li 0.500000 0.748000 0.958333 0.748000
+li 0.500000 0.758000 0.958333 0.758000
+li 0.500000 0.768000 0.958333 0.768000
+li 0.500000 0.778000 0.958333 0.778000
+li 0.500000 0.788000 0.958333 0.788000
+li 0.500000 0.798000 0.958333 0.798000
+li 0.500000 0.808000 0.958333 0.808000
+li 0.500000 0.818000 0.958333 0.818000
+li 0.500000 0.828000 0.958333 0.828000
+li 0.500000 0.838000 0.958333 0.838000
+li 0.500000 0.848000 0.958333 0.848000
+li 0.500000 0.858000 0.958333 0.858000
+li 0.500000 0.868000 0.958333 0.868000
+li 0.500000 0.878000 0.958333 0.878000
+li 0.500000 0.888000 0.958333 0.888000
+li 0.500000 0.898000 0.958333 0.898000
+li 0.500000 0.908000 0.958333 0.908000
+li 0.500000 0.918000 0.958333 0.918000
+li 0.500000 0.928000 0.958333 0.928000
+li 0.500000 0.938000 0.958333 0.938000
+li 0.500000 0.948000 0.958333 0.948000
+m 0.156250 0.060000
+t "\Ca/f00d/latency"
+bo 0.083333 0.200161 0.229167 0.588207
+m 0.083333 0.200161
+t "\R12.5"
+m 0.083333 0.588207
+t "\R52.5"
+li 0.083333 0.214713 0.229167 0.214713
+m 0.083333 0.214713
+t "\R14"
+li 0.119792 0.195310 0.192708 0.195310
+li 0.156250 0.200161 0.156250 0.195310
+m 0.119792 0.195310
+t "\R12"
+li 0.119792 0.952000 0.192708 0.952000
+li 0.156250 0.588207 0.156250 0.952000
+m 0.119792 0.952000
+t "\R90"
+m 0.385417 0.060000
+t "\Ca/f00d/ttfb"
+bo 0.312500 0.108000 0.458333 0.117701
+m 0.312500 0.108000
+t "\R3"
+m 0.312500 0.117701
+t "\R4"
+li 0.312500 0.112851 0.458333 0.112851
+m 0.312500 0.112851
+t "\R3.5"
+li 0.348958 0.108000 0.421875 0.108000
+li 0.385417 0.108000 0.385417 0.108000
+m 0.348958 0.108000
+t "\R3"
+li 0.348958 0.117701 0.421875 0.117701
+li 0.385417 0.117701 0.385417 0.117701
+m 0.348958 0.117701
+t "\R4"
+m 0.614583 0.060000
+t "\Cb/f00d/latency"
+bo 0.541667 0.287471 0.687500 0.316575
+m 0.541667 0.287471
+t "\R21.5"
+m 0.541667 0.316575
+t "\R24.5"
+li 0.541667 0.302023 0.687500 0.302023
+m 0.541667 0.302023
+t "\R23"
+li 0.578125 0.282621 0.651042 0.282621
+li 0.614583 0.287471 0.614583 0.282621
+m 0.578125 0.282621
+t "\R21"
+li 0.578125 0.321425 0.651042 0.321425
+li 0.614583 0.316575 0.614583 0.321425
+m 0.578125 0.321425
+t "\R25"
+m 0.843750 0.060000
+t "\Cb/f00d/ttfb"
+bo 0.770833 0.132253 0.916667 0.141954
+m 0.770833 0.132253
+t "\R5.5"
+m 0.770833 0.141954
+t "\R6.5"
+li 0.770833 0.137103 0.916667 0.137103
+m 0.770833 0.137103
+t "\R6"
+li 0.807292 0.127402 0.880208 0.127402
+li 0.843750 0.132253 0.843750 0.127402
+m 0.807292 0.127402
+t "\R5"
+li 0.807292 0.146805 0.880208 0.146805
+li 0.843750 0.141954 0.843750 0.146805
+m 0.807292 0.146805
+t "\R7"
+li 0.045000 0.010000 0.055000 0.010000
+li 0.045000 0.020000 0.055000 0.020000
+li 0.045000 0.030000 0.055000 0.030000
+m 0.070000 0.020000
+t "\Lalternate groups"
+cl
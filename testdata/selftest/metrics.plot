@@ -0,0 +1,115 @@
+m 0.010000 0.980000
+t "\Lns/op"
+m 0.010000 0.646667
+t "\LB/op"
+m 0.010000 0.313333
+t "\Lallocs/op"
+m 0.291667 0.686667
+t "\CEncode-8"
+bo 0.166667 0.702169 0.416667 0.733922
+m 0.166667 0.702169
+t "\R1.2e+03"
+m 0.166667 0.733922
+t "\R1.38e+03"
+li 0.166667 0.713032 0.416667 0.713032
+m 0.166667 0.713032
+t "\R1.26e+03"
+li 0.229167 0.701333 0.354167 0.701333
+li 0.291667 0.702169 0.291667 0.701333
+m 0.229167 0.701333
+t "\R1.19e+03"
+li 0.229167 0.744785 0.354167 0.744785
+li 0.291667 0.733922 0.291667 0.744785
+m 0.229167 0.744785
+t "\R1.45e+03"
+m 0.708333 0.686667
+t "\CDecode-8"
+bo 0.583333 0.861772 0.833333 0.924443
+m 0.583333 0.861772
+t "\R2.15e+03"
+m 0.583333 0.924443
+t "\R2.52e+03"
+li 0.583333 0.886840 0.833333 0.886840
+m 0.583333 0.886840
+t "\R2.3e+03"
+li 0.645833 0.853416 0.770833 0.853416
+li 0.708333 0.861772 0.708333 0.853416
+m 0.645833 0.853416
+t "\R2.1e+03"
+li 0.645833 0.945333 0.770833 0.945333
+li 0.708333 0.924443 0.708333 0.945333
+m 0.645833 0.945333
+t "\R2.65e+03"
+m 0.291667 0.353333
+t "\CEncode-8"
+bo 0.166667 0.369193 0.416667 0.400215
+m 0.166667 0.369193
+t "\R516"
+m 0.166667 0.400215
+t "\R620"
+li 0.166667 0.382318 0.416667 0.382318
+m 0.166667 0.382318
+t "\R560"
+li 0.229167 0.368000 0.354167 0.368000
+li 0.291667 0.369193 0.291667 0.368000
+m 0.229167 0.368000
+t "\R512"
+li 0.229167 0.406181 0.354167 0.406181
+li 0.291667 0.400215 0.291667 0.406181
+m 0.229167 0.406181
+t "\R640"
+m 0.708333 0.353333
+t "\CDecode-8"
+bo 0.583333 0.532059 0.833333 0.592611
+m 0.583333 0.532059
+t "\R1.06e+03"
+m 0.583333 0.592611
+t "\R1.26e+03"
+li 0.583333 0.558308 0.833333 0.558308
+m 0.583333 0.558308
+t "\R1.15e+03"
+li 0.645833 0.520724 0.770833 0.520724
+li 0.708333 0.532059 0.708333 0.520724
+m 0.645833 0.520724
+t "\R1.02e+03"
+li 0.645833 0.612000 0.770833 0.612000
+li 0.708333 0.592611 0.708333 0.612000
+m 0.645833 0.612000
+t "\R1.33e+03"
+m 0.291667 0.020000
+t "\CEncode-8"
+bo 0.166667 0.034667 0.416667 0.080417
+m 0.166667 0.034667
+t "\R4"
+m 0.166667 0.080417
+t "\R5.5"
+li 0.166667 0.049917 0.416667 0.049917
+m 0.166667 0.049917
+t "\R4.5"
+li 0.229167 0.034667 0.354167 0.034667
+li 0.291667 0.034667 0.291667 0.034667
+m 0.229167 0.034667
+t "\R4"
+li 0.229167 0.095667 0.354167 0.095667
+li 0.291667 0.080417 0.291667 0.095667
+m 0.229167 0.095667
+t "\R6"
+m 0.708333 0.020000
+t "\CDecode-8"
+bo 0.583333 0.171917 0.833333 0.248167
+m 0.583333 0.171917
+t "\R8.5"
+m 0.583333 0.248167
+t "\R11"
+li 0.583333 0.202417 0.833333 0.202417
+m 0.583333 0.202417
+t "\R9.5"
+li 0.645833 0.156667 0.770833 0.156667
+li 0.708333 0.171917 0.708333 0.156667
+m 0.645833 0.156667
+t "\R8"
+li 0.645833 0.278667 0.770833 0.278667
+li 0.708333 0.248167 0.708333 0.278667
+m 0.645833 0.278667
+t "\R12"
+cl
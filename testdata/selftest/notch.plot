@@ -0,0 +1,85 @@
+m 0.203704 0.020000
+t "\Cbefore"
+m 0.111111 0.193200
+v 0.296296 0.193200
+v 0.296296 0.199787
+v 0.259259 0.263600
+v 0.296296 0.327413
+v 0.296296 0.334000
+v 0.111111 0.334000
+v 0.111111 0.327413
+v 0.148148 0.263600
+v 0.111111 0.199787
+v 0.111111 0.193200
+m 0.111111 0.193200
+t "\R98.5"
+m 0.111111 0.334000
+t "\R102"
+li 0.148148 0.263600 0.259259 0.263600
+m 0.111111 0.263600
+t "\R100"
+li 0.157407 0.105200 0.250000 0.105200
+li 0.203704 0.193200 0.203704 0.105200
+m 0.157407 0.105200
+t "\R96"
+li 0.157407 0.422000 0.250000 0.422000
+li 0.203704 0.334000 0.203704 0.422000
+m 0.157407 0.422000
+t "\R105"
+m 0.500000 0.020000
+t "\Cafter"
+m 0.407407 0.510000
+v 0.592593 0.510000
+v 0.592593 0.510000
+v 0.555556 0.562800
+v 0.592593 0.626613
+v 0.592593 0.650800
+v 0.407407 0.650800
+v 0.407407 0.626613
+v 0.444444 0.562800
+v 0.407407 0.510000
+v 0.407407 0.510000
+m 0.407407 0.510000
+t "\R108"
+m 0.407407 0.650800
+t "\R112"
+li 0.444444 0.562800 0.555556 0.562800
+m 0.407407 0.562800
+t "\R109"
+li 0.453704 0.386800 0.546296 0.386800
+li 0.500000 0.510000 0.500000 0.386800
+m 0.453704 0.386800
+t "\R104"
+li 0.453704 0.774000 0.546296 0.774000
+li 0.500000 0.650800 0.500000 0.774000
+m 0.453704 0.774000
+t "\R115"
+m 0.796296 0.020000
+t "\Csmall"
+m 0.703704 0.175600
+v 0.888889 0.175600
+v 0.888889 0.175600
+v 0.851852 0.281200
+v 0.888889 0.615600
+v 0.888889 0.615600
+v 0.703704 0.615600
+v 0.703704 0.615600
+v 0.740741 0.281200
+v 0.703704 0.175600
+v 0.703704 0.175600
+m 0.703704 0.175600
+t "\R98"
+m 0.703704 0.615600
+t "\R110"
+li 0.740741 0.281200 0.851852 0.281200
+m 0.703704 0.281200
+t "\R101"
+li 0.750000 0.070000 0.842593 0.070000
+li 0.796296 0.175600 0.796296 0.070000
+m 0.750000 0.070000
+t "\R95"
+li 0.750000 0.950000 0.842593 0.950000
+li 0.796296 0.615600 0.796296 0.950000
+m 0.750000 0.950000
+t "\R120"
+cl
@@ -0,0 +1,14 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:4.5]
+set xtics noenhanced ("GET /users" 1, "db.query" 2, "http.server.duration" 3, "rpc.latency" 4)
+$boxes << EOD
+1 13.5 12 31 23 15 0
+2 2.5 2.5 4 4 3.25 0
+3 4 1 40 14.038461538461538 7.166666666666667 0
+4 2.564203134732626 0 6 3.376218911817282 2.9442094960976344 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,73 @@
+m 0.156250 0.020000
+t "\CGET /users"
+bo 0.083333 0.367000 0.229167 0.576000
+m 0.083333 0.367000
+t "\R13.5"
+m 0.083333 0.576000
+t "\R23"
+li 0.083333 0.400000 0.229167 0.400000
+m 0.083333 0.400000
+t "\R15"
+li 0.119792 0.334000 0.192708 0.334000
+li 0.156250 0.367000 0.156250 0.334000
+m 0.119792 0.334000
+t "\R12"
+li 0.119792 0.752000 0.192708 0.752000
+li 0.156250 0.576000 0.156250 0.752000
+m 0.119792 0.752000
+t "\R31"
+m 0.385417 0.020000
+t "\Cdb.query"
+bo 0.312500 0.125000 0.458333 0.158000
+m 0.312500 0.125000
+t "\R2.5"
+m 0.312500 0.158000
+t "\R4"
+li 0.312500 0.141500 0.458333 0.141500
+m 0.312500 0.141500
+t "\R3.25"
+li 0.348958 0.125000 0.421875 0.125000
+li 0.385417 0.125000 0.385417 0.125000
+m 0.348958 0.125000
+t "\R2.5"
+li 0.348958 0.158000 0.421875 0.158000
+li 0.385417 0.158000 0.385417 0.158000
+m 0.348958 0.158000
+t "\R4"
+m 0.614583 0.020000
+t "\Chttp.server.duration"
+bo 0.541667 0.158000 0.687500 0.378846
+m 0.541667 0.158000
+t "\R4"
+m 0.541667 0.378846
+t "\R14"
+li 0.541667 0.227667 0.687500 0.227667
+m 0.541667 0.227667
+t "\R7.17"
+li 0.578125 0.092000 0.651042 0.092000
+li 0.614583 0.158000 0.614583 0.092000
+m 0.578125 0.092000
+t "\R1"
+li 0.578125 0.950000 0.651042 0.950000
+li 0.614583 0.378846 0.614583 0.950000
+m 0.578125 0.950000
+t "\R40"
+m 0.843750 0.020000
+t "\Crpc.latency"
+bo 0.770833 0.126412 0.916667 0.144277
+m 0.770833 0.126412
+t "\R2.56"
+m 0.770833 0.144277
+t "\R3.38"
+li 0.770833 0.134773 0.916667 0.134773
+m 0.770833 0.134773
+t "\R2.94"
+li 0.807292 0.070000 0.880208 0.070000
+li 0.843750 0.126412 0.843750 0.070000
+m 0.807292 0.070000
+t "\R0"
+li 0.807292 0.202000 0.880208 0.202000
+li 0.843750 0.144277 0.843750 0.202000
+m 0.807292 0.202000
+t "\R6"
+cl
@@ -0,0 +1,41 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set title "a\"quoted\"title" noenhanced
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:30.5]
+set xtics noenhanced ("n0" 1, "q\"1" 2, "p2" 3, "n3" 4, "q\"4" 5, "p5" 6, "n6" 7, "q\"7" 8, "p8" 9, "n9" 10, "q\"10" 11, "p11" 12, "n12" 13, "q\"13" 14, "p14" 15, "n15" 16, "q\"16" 17, "p17" 18, "n18" 19, "q\"19" 20, "p20" 21, "n21" 22, "q\"22" 23, "p23" 24, "n24" 25, "q\"25" 26, "p26" 27, "n27" 28, "q\"28" 29, "p29" 30)
+$boxes << EOD
+1 -268 -436 47 -57 -142.5 16711680
+2 -328 -478 -94 -151 -257 0
+3 -265 -435 75 -17 -42 0
+4 -375 -406 80 -13 -225 16711680
+5 -160 -160 -34 -97 -160 0
+6 -36 -36 -34 -34 -35 0
+7 -426 -480 66 -167.5 -275 16711680
+8 -306 -476 91 14 -176 0
+9 -420 -468 -80 -160.5 -287.5 0
+10 -203 -203 -203 -203 -203 16711680
+11 -320 -369 54 -79 -241.5 0
+12 -255 -484 92 -4 -77 0
+13 -342 -484 -144 -251 -285 16711680
+14 -274.5 -449 13 -118 -227 0
+15 -304 -304 -304 -304 -304 0
+16 -175 -476 57 -46 -83 16711680
+17 -324.5 -360 9 -99.5 -256.5 0
+18 -380 -380 -380 -380 -380 0
+19 -315.5 -486 84 -45.5 -189.5 16711680
+20 -360 -364 12 -193 -200 0
+21 -169 -169 79 79 -45 0
+22 -314 -355 -188 -230.5 -273 16711680
+23 -218.5 -476 67 7.5 -141 0
+24 -11 -11 34 34 11.5 0
+25 -259 -381 -29 -106 -182 16711680
+26 -187.5 -219 -77 -116.5 -156 0
+27 -318.5 -460 10 -85.5 -239.5 0
+28 -410 -410 -410 -410 -410 16711680
+29 -458 -458 -52 -52 -255 0
+30 -393 -496 83 -130 -223.5 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
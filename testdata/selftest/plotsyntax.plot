@@ -0,0 +1,673 @@
+m 0.500000 0.980000
+t "\Ca'quoted'title"
+li 0.080000 0.068000 0.080000 0.912000
+li 0.070000 0.205796 0.080000 0.205796
+m 0.070000 0.205796
+t "\R-400"
+li 0.070000 0.492871 0.080000 0.492871
+m 0.070000 0.492871
+t "\R-200"
+li 0.070000 0.779946 0.080000 0.779946
+m 0.070000 0.779946
+t "\R0"
+co red
+pe dashed
+m 0.100274 0.020000
+t "\Cn0"
+bo 0.090222 0.395265 0.110326 0.698129
+m 0.090222 0.395265
+t "\R-268"
+m 0.090222 0.698129
+t "\R-57"
+li 0.090222 0.575405 0.110326 0.575405
+m 0.090222 0.575405
+t "\R-142"
+li 0.095248 0.154122 0.105300 0.154122
+li 0.100274 0.395265 0.100274 0.154122
+m 0.095248 0.154122
+t "\R-436"
+li 0.095248 0.847408 0.105300 0.847408
+li 0.100274 0.698129 0.100274 0.847408
+m 0.095248 0.847408
+t "\R47"
+co black
+pe solid
+co black
+pe solid
+m 0.130600 0.020000
+t "\Cq'1"
+bo 0.120548 0.309143 0.140652 0.563204
+m 0.120548 0.309143
+t "\R-328"
+m 0.120548 0.563204
+t "\R-151"
+li 0.120548 0.411054 0.140652 0.411054
+m 0.120548 0.411054
+t "\R-257"
+li 0.125574 0.093837 0.135626 0.093837
+li 0.130600 0.309143 0.130600 0.093837
+m 0.125574 0.093837
+t "\R-478"
+li 0.125574 0.645020 0.135626 0.645020
+li 0.130600 0.563204 0.130600 0.645020
+m 0.125574 0.645020
+t "\R-94"
+co black
+pe solid
+co black
+pe solid
+m 0.160926 0.020000
+t "\Cp2"
+bo 0.150874 0.399571 0.170978 0.755544
+m 0.150874 0.399571
+t "\R-265"
+m 0.150874 0.755544
+t "\R-17"
+li 0.150874 0.719660 0.170978 0.719660
+m 0.150874 0.719660
+t "\R-42"
+li 0.155900 0.155558 0.165952 0.155558
+li 0.160926 0.399571 0.160926 0.155558
+m 0.155900 0.155558
+t "\R-435"
+li 0.155900 0.887599 0.165952 0.887599
+li 0.160926 0.755544 0.160926 0.887599
+m 0.155900 0.887599
+t "\R75"
+co black
+pe solid
+co red
+pe dashed
+m 0.191252 0.020000
+t "\Cn3"
+bo 0.181200 0.241680 0.201304 0.761286
+m 0.181200 0.241680
+t "\R-375"
+m 0.181200 0.761286
+t "\R-13"
+li 0.181200 0.456986 0.201304 0.456986
+m 0.181200 0.456986
+t "\R-225"
+li 0.186226 0.197184 0.196278 0.197184
+li 0.191252 0.241680 0.191252 0.197184
+m 0.186226 0.197184
+t "\R-406"
+li 0.186226 0.894776 0.196278 0.894776
+li 0.191252 0.761286 0.191252 0.894776
+m 0.186226 0.894776
+t "\R80"
+co black
+pe solid
+co black
+pe solid
+m 0.221578 0.020000
+t "\Cq'4"
+bo 0.211526 0.550286 0.231630 0.640714
+m 0.211526 0.550286
+t "\R-160"
+m 0.211526 0.640714
+t "\R-97"
+li 0.211526 0.550286 0.231630 0.550286
+m 0.211526 0.550286
+t "\R-160"
+li 0.216552 0.550286 0.226604 0.550286
+li 0.221578 0.550286 0.221578 0.550286
+m 0.216552 0.550286
+t "\R-160"
+li 0.216552 0.731143 0.226604 0.731143
+li 0.221578 0.640714 0.221578 0.731143
+m 0.216552 0.731143
+t "\R-34"
+co black
+pe solid
+co black
+pe solid
+m 0.251904 0.020000
+t "\Cp5"
+bo 0.241852 0.728272 0.261956 0.731143
+m 0.241852 0.728272
+t "\R-36"
+m 0.241852 0.731143
+t "\R-34"
+li 0.241852 0.729707 0.261956 0.729707
+m 0.241852 0.729707
+t "\R-35"
+li 0.246878 0.728272 0.256930 0.728272
+li 0.251904 0.728272 0.251904 0.728272
+m 0.246878 0.728272
+t "\R-36"
+li 0.246878 0.731143 0.256930 0.731143
+li 0.251904 0.731143 0.251904 0.731143
+m 0.246878 0.731143
+t "\R-34"
+co black
+pe solid
+co red
+pe dashed
+m 0.282230 0.020000
+t "\Cn6"
+bo 0.272178 0.168476 0.292281 0.539520
+m 0.272178 0.168476
+t "\R-426"
+m 0.272178 0.539520
+t "\R-168"
+li 0.272178 0.385218 0.292281 0.385218
+m 0.272178 0.385218
+t "\R-275"
+li 0.277204 0.090966 0.287256 0.090966
+li 0.282230 0.168476 0.282230 0.090966
+m 0.277204 0.090966
+t "\R-480"
+li 0.277204 0.874680 0.287256 0.874680
+li 0.282230 0.539520 0.282230 0.874680
+m 0.277204 0.874680
+t "\R66"
+co black
+pe solid
+co black
+pe solid
+m 0.312556 0.020000
+t "\Cq'7"
+bo 0.302504 0.340721 0.322607 0.800041
+m 0.302504 0.340721
+t "\R-306"
+m 0.302504 0.800041
+t "\R14"
+li 0.302504 0.527320 0.322607 0.527320
+m 0.302504 0.527320
+t "\R-176"
+li 0.307530 0.096707 0.317581 0.096707
+li 0.312556 0.340721 0.312556 0.096707
+m 0.307530 0.096707
+t "\R-476"
+li 0.307530 0.910565 0.317581 0.910565
+li 0.312556 0.800041 0.312556 0.910565
+m 0.307530 0.910565
+t "\R91"
+co black
+pe solid
+co black
+pe solid
+m 0.342881 0.020000
+t "\Cp8"
+bo 0.332830 0.177088 0.352933 0.549568
+m 0.332830 0.177088
+t "\R-420"
+m 0.332830 0.549568
+t "\R-160"
+li 0.332830 0.367276 0.352933 0.367276
+m 0.332830 0.367276
+t "\R-288"
+li 0.337856 0.108190 0.347907 0.108190
+li 0.342881 0.177088 0.342881 0.108190
+m 0.337856 0.108190
+t "\R-468"
+li 0.337856 0.665116 0.347907 0.665116
+li 0.342881 0.549568 0.342881 0.665116
+m 0.337856 0.665116
+t "\R-80"
+co black
+pe solid
+co red
+pe dashed
+m 0.373207 0.020000
+t "\Cn9"
+bo 0.363156 0.488565 0.383259 0.488565
+m 0.363156 0.488565
+t "\R-203"
+m 0.363156 0.488565
+t "\R-203"
+li 0.363156 0.488565 0.383259 0.488565
+m 0.363156 0.488565
+t "\R-203"
+li 0.368181 0.488565 0.378233 0.488565
+li 0.373207 0.488565 0.373207 0.488565
+m 0.368181 0.488565
+t "\R-203"
+li 0.368181 0.488565 0.378233 0.488565
+li 0.373207 0.488565 0.373207 0.488565
+m 0.368181 0.488565
+t "\R-203"
+co black
+pe solid
+co black
+pe solid
+m 0.403533 0.020000
+t "\Cq'10"
+bo 0.393481 0.320626 0.413585 0.666551
+m 0.393481 0.320626
+t "\R-320"
+m 0.393481 0.666551
+t "\R-79"
+li 0.393481 0.433303 0.413585 0.433303
+m 0.393481 0.433303
+t "\R-242"
+li 0.398507 0.250293 0.408559 0.250293
+li 0.403533 0.320626 0.403533 0.250293
+m 0.398507 0.250293
+t "\R-369"
+li 0.398507 0.857456 0.408559 0.857456
+li 0.403533 0.666551 0.403533 0.857456
+m 0.398507 0.857456
+t "\R54"
+co black
+pe solid
+co black
+pe solid
+m 0.433859 0.020000
+t "\Cp11"
+bo 0.423807 0.413925 0.443911 0.774204
+m 0.423807 0.413925
+t "\R-255"
+m 0.423807 0.774204
+t "\R-4"
+li 0.423807 0.669422 0.443911 0.669422
+m 0.423807 0.669422
+t "\R-77"
+li 0.428833 0.085224 0.438885 0.085224
+li 0.433859 0.413925 0.433859 0.085224
+m 0.428833 0.085224
+t "\R-484"
+li 0.428833 0.912000 0.438885 0.912000
+li 0.433859 0.774204 0.433859 0.912000
+m 0.428833 0.912000
+t "\R92"
+co black
+pe solid
+co red
+pe dashed
+m 0.464185 0.020000
+t "\Cn12"
+bo 0.454133 0.289048 0.474237 0.419667
+m 0.454133 0.289048
+t "\R-342"
+m 0.454133 0.419667
+t "\R-251"
+li 0.454133 0.370864 0.474237 0.370864
+m 0.454133 0.370864
+t "\R-285"
+li 0.459159 0.085224 0.469211 0.085224
+li 0.464185 0.289048 0.464185 0.085224
+m 0.459159 0.085224
+t "\R-484"
+li 0.459159 0.573252 0.469211 0.573252
+li 0.464185 0.419667 0.464185 0.573252
+m 0.459159 0.573252
+t "\R-144"
+co black
+pe solid
+co black
+pe solid
+m 0.494511 0.020000
+t "\Cq'13"
+bo 0.484459 0.385935 0.504563 0.610571
+m 0.484459 0.385935
+t "\R-274"
+m 0.484459 0.610571
+t "\R-118"
+li 0.484459 0.454116 0.504563 0.454116
+m 0.484459 0.454116
+t "\R-227"
+li 0.489485 0.135463 0.499537 0.135463
+li 0.494511 0.385935 0.494511 0.135463
+m 0.489485 0.135463
+t "\R-449"
+li 0.489485 0.798605 0.499537 0.798605
+li 0.494511 0.610571 0.494511 0.798605
+m 0.489485 0.798605
+t "\R13"
+co black
+pe solid
+co black
+pe solid
+m 0.524837 0.020000
+t "\Cp14"
+bo 0.514785 0.343592 0.534889 0.343592
+m 0.514785 0.343592
+t "\R-304"
+m 0.514785 0.343592
+t "\R-304"
+li 0.514785 0.343592 0.534889 0.343592
+m 0.514785 0.343592
+t "\R-304"
+li 0.519811 0.343592 0.529863 0.343592
+li 0.524837 0.343592 0.524837 0.343592
+m 0.519811 0.343592
+t "\R-304"
+li 0.519811 0.343592 0.529863 0.343592
+li 0.524837 0.343592 0.524837 0.343592
+m 0.519811 0.343592
+t "\R-304"
+co black
+pe solid
+co red
+pe dashed
+m 0.555163 0.020000
+t "\Cn15"
+bo 0.545111 0.528755 0.565215 0.713918
+m 0.545111 0.528755
+t "\R-175"
+m 0.545111 0.713918
+t "\R-46"
+li 0.545111 0.660810 0.565215 0.660810
+m 0.545111 0.660810
+t "\R-83"
+li 0.550137 0.096707 0.560189 0.096707
+li 0.555163 0.528755 0.555163 0.096707
+m 0.550137 0.096707
+t "\R-476"
+li 0.550137 0.861762 0.560189 0.861762
+li 0.555163 0.713918 0.555163 0.861762
+m 0.550137 0.861762
+t "\R57"
+co black
+pe solid
+co black
+pe solid
+m 0.585489 0.020000
+t "\Cq'16"
+bo 0.575437 0.314167 0.595541 0.637126
+m 0.575437 0.314167
+t "\R-324"
+m 0.575437 0.637126
+t "\R-99.5"
+li 0.575437 0.411772 0.595541 0.411772
+m 0.575437 0.411772
+t "\R-256"
+li 0.580463 0.263211 0.590515 0.263211
+li 0.585489 0.314167 0.585489 0.263211
+m 0.580463 0.263211
+t "\R-360"
+li 0.580463 0.792864 0.590515 0.792864
+li 0.585489 0.637126 0.585489 0.792864
+m 0.580463 0.792864
+t "\R9"
+co black
+pe solid
+co black
+pe solid
+m 0.615815 0.020000
+t "\Cp17"
+bo 0.605763 0.234503 0.625867 0.234503
+m 0.605763 0.234503
+t "\R-380"
+m 0.605763 0.234503
+t "\R-380"
+li 0.605763 0.234503 0.625867 0.234503
+m 0.605763 0.234503
+t "\R-380"
+li 0.610789 0.234503 0.620841 0.234503
+li 0.615815 0.234503 0.615815 0.234503
+m 0.610789 0.234503
+t "\R-380"
+li 0.610789 0.234503 0.620841 0.234503
+li 0.615815 0.234503 0.615815 0.234503
+m 0.610789 0.234503
+t "\R-380"
+co black
+pe solid
+co red
+pe dashed
+m 0.646141 0.020000
+t "\Cn18"
+bo 0.636089 0.327085 0.656193 0.714636
+m 0.636089 0.327085
+t "\R-316"
+m 0.636089 0.714636
+t "\R-45.5"
+li 0.636089 0.507942 0.656193 0.507942
+m 0.636089 0.507942
+t "\R-190"
+li 0.641115 0.082354 0.651167 0.082354
+li 0.646141 0.327085 0.646141 0.082354
+m 0.641115 0.082354
+t "\R-486"
+li 0.641115 0.900517 0.651167 0.900517
+li 0.646141 0.714636 0.646141 0.900517
+m 0.641115 0.900517
+t "\R84"
+co black
+pe solid
+co black
+pe solid
+m 0.676467 0.020000
+t "\Cq'19"
+bo 0.666415 0.263211 0.686519 0.502918
+m 0.666415 0.263211
+t "\R-360"
+m 0.666415 0.502918
+t "\R-193"
+li 0.666415 0.492871 0.686519 0.492871
+m 0.666415 0.492871
+t "\R-200"
+li 0.671441 0.257469 0.681493 0.257469
+li 0.676467 0.263211 0.676467 0.257469
+m 0.671441 0.257469
+t "\R-364"
+li 0.671441 0.797170 0.681493 0.797170
+li 0.676467 0.502918 0.676467 0.797170
+m 0.671441 0.797170
+t "\R12"
+co black
+pe solid
+co black
+pe solid
+m 0.706793 0.020000
+t "\Cp20"
+bo 0.696741 0.537367 0.716844 0.893340
+m 0.696741 0.537367
+t "\R-169"
+m 0.696741 0.893340
+t "\R79"
+li 0.696741 0.715354 0.716844 0.715354
+m 0.696741 0.715354
+t "\R-45"
+li 0.701767 0.537367 0.711819 0.537367
+li 0.706793 0.537367 0.706793 0.537367
+m 0.701767 0.537367
+t "\R-169"
+li 0.701767 0.893340 0.711819 0.893340
+li 0.706793 0.893340 0.706793 0.893340
+m 0.701767 0.893340
+t "\R79"
+co black
+pe solid
+co red
+pe dashed
+m 0.737119 0.020000
+t "\Cn21"
+bo 0.727067 0.329238 0.747170 0.449092
+m 0.727067 0.329238
+t "\R-314"
+m 0.727067 0.449092
+t "\R-230"
+li 0.727067 0.388088 0.747170 0.388088
+m 0.727067 0.388088
+t "\R-273"
+li 0.732093 0.270388 0.742144 0.270388
+li 0.737119 0.329238 0.737119 0.270388
+m 0.732093 0.270388
+t "\R-355"
+li 0.732093 0.510095 0.742144 0.510095
+li 0.737119 0.449092 0.737119 0.510095
+m 0.732093 0.510095
+t "\R-188"
+co black
+pe solid
+co black
+pe solid
+m 0.767444 0.020000
+t "\Cq'22"
+bo 0.757393 0.466316 0.777496 0.790711
+m 0.757393 0.466316
+t "\R-218"
+m 0.757393 0.790711
+t "\R7.5"
+li 0.757393 0.577558 0.777496 0.577558
+m 0.757393 0.577558
+t "\R-141"
+li 0.762419 0.096707 0.772470 0.096707
+li 0.767444 0.466316 0.767444 0.096707
+m 0.762419 0.096707
+t "\R-476"
+li 0.762419 0.876116 0.772470 0.876116
+li 0.767444 0.790711 0.767444 0.876116
+m 0.762419 0.876116
+t "\R67"
+co black
+pe solid
+co black
+pe solid
+m 0.797770 0.020000
+t "\Cp23"
+bo 0.787719 0.764156 0.807822 0.828748
+m 0.787719 0.764156
+t "\R-11"
+m 0.787719 0.828748
+t "\R34"
+li 0.787719 0.796452 0.807822 0.796452
+m 0.787719 0.796452
+t "\R11.5"
+li 0.792744 0.764156 0.802796 0.764156
+li 0.797770 0.764156 0.797770 0.764156
+m 0.792744 0.764156
+t "\R-11"
+li 0.792744 0.828748 0.802796 0.828748
+li 0.797770 0.828748 0.797770 0.828748
+m 0.792744 0.828748
+t "\R34"
+co black
+pe solid
+co red
+pe dashed
+m 0.828096 0.020000
+t "\Cn24"
+bo 0.818044 0.408184 0.838148 0.627796
+m 0.818044 0.408184
+t "\R-259"
+m 0.818044 0.627796
+t "\R-106"
+li 0.818044 0.518707 0.838148 0.518707
+m 0.818044 0.518707
+t "\R-182"
+li 0.823070 0.233068 0.833122 0.233068
+li 0.828096 0.408184 0.828096 0.233068
+m 0.823070 0.233068
+t "\R-381"
+li 0.823070 0.738320 0.833122 0.738320
+li 0.828096 0.627796 0.828096 0.738320
+m 0.823070 0.738320
+t "\R-29"
+co black
+pe solid
+co black
+pe solid
+m 0.858422 0.020000
+t "\Cq'25"
+bo 0.848370 0.510813 0.868474 0.612724
+m 0.848370 0.510813
+t "\R-188"
+m 0.848370 0.612724
+t "\R-116"
+li 0.848370 0.556027 0.868474 0.556027
+m 0.848370 0.556027
+t "\R-156"
+li 0.853396 0.465599 0.863448 0.465599
+li 0.858422 0.510813 0.858422 0.465599
+m 0.853396 0.465599
+t "\R-219"
+li 0.853396 0.669422 0.863448 0.669422
+li 0.858422 0.612724 0.858422 0.669422
+m 0.853396 0.669422
+t "\R-77"
+co black
+pe solid
+co black
+pe solid
+m 0.888748 0.020000
+t "\Cp26"
+bo 0.878696 0.322779 0.898800 0.657221
+m 0.878696 0.322779
+t "\R-318"
+m 0.878696 0.657221
+t "\R-85.5"
+li 0.878696 0.436173 0.898800 0.436173
+m 0.878696 0.436173
+t "\R-240"
+li 0.883722 0.119673 0.893774 0.119673
+li 0.888748 0.322779 0.888748 0.119673
+m 0.883722 0.119673
+t "\R-460"
+li 0.883722 0.794299 0.893774 0.794299
+li 0.888748 0.657221 0.888748 0.794299
+m 0.883722 0.794299
+t "\R10"
+co black
+pe solid
+co red
+pe dashed
+m 0.919074 0.020000
+t "\Cn27"
+bo 0.909022 0.191442 0.929126 0.191442
+m 0.909022 0.191442
+t "\R-410"
+m 0.909022 0.191442
+t "\R-410"
+li 0.909022 0.191442 0.929126 0.191442
+m 0.909022 0.191442
+t "\R-410"
+li 0.914048 0.191442 0.924100 0.191442
+li 0.919074 0.191442 0.919074 0.191442
+m 0.914048 0.191442
+t "\R-410"
+li 0.914048 0.191442 0.924100 0.191442
+li 0.919074 0.191442 0.919074 0.191442
+m 0.914048 0.191442
+t "\R-410"
+co black
+pe solid
+co black
+pe solid
+m 0.949400 0.020000
+t "\Cq'28"
+bo 0.939348 0.122544 0.959452 0.705306
+m 0.939348 0.122544
+t "\R-458"
+m 0.939348 0.705306
+t "\R-52"
+li 0.939348 0.413925 0.959452 0.413925
+m 0.939348 0.413925
+t "\R-255"
+li 0.944374 0.122544 0.954426 0.122544
+li 0.949400 0.122544 0.949400 0.122544
+m 0.944374 0.122544
+t "\R-458"
+li 0.944374 0.705306 0.954426 0.705306
+li 0.949400 0.705306 0.949400 0.705306
+m 0.944374 0.705306
+t "\R-52"
+co black
+pe solid
+co black
+pe solid
+m 0.979726 0.020000
+t "\Cp29"
+bo 0.969674 0.215844 0.989778 0.593347
+m 0.969674 0.215844
+t "\R-393"
+m 0.969674 0.593347
+t "\R-130"
+li 0.969674 0.459139 0.989778 0.459139
+m 0.969674 0.459139
+t "\R-224"
+li 0.974700 0.068000 0.984752 0.068000
+li 0.979726 0.215844 0.979726 0.068000
+m 0.974700 0.068000
+t "\R-496"
+li 0.974700 0.899082 0.984752 0.899082
+li 0.979726 0.593347 0.979726 0.899082
+m 0.974700 0.899082
+t "\R83"
+co black
+pe solid
+cl
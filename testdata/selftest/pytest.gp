@@ -0,0 +1,12 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("test_parse" 1, "test_encode" 2)
+$boxes << EOD
+1 0.0013 0.00121 0.00188 0.00148 0.0013700000000000001 0
+2 0.00099 0.00092 0.00161 0.00111 0.00105 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
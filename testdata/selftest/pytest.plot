@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Ctest_parse"
+bo 0.166667 0.418333 0.416667 0.583333
+m 0.166667 0.418333
+t "\R0.0013"
+m 0.166667 0.583333
+t "\R0.00148"
+li 0.166667 0.482500 0.416667 0.482500
+m 0.166667 0.482500
+t "\R0.00137"
+li 0.229167 0.335833 0.354167 0.335833
+li 0.291667 0.418333 0.291667 0.335833
+m 0.229167 0.335833
+t "\R0.00121"
+li 0.229167 0.950000 0.354167 0.950000
+li 0.291667 0.583333 0.291667 0.950000
+m 0.229167 0.950000
+t "\R0.00188"
+m 0.708333 0.020000
+t "\Ctest_encode"
+bo 0.583333 0.134167 0.833333 0.244167
+m 0.583333 0.134167
+t "\R0.00099"
+m 0.583333 0.244167
+t "\R0.00111"
+li 0.583333 0.189167 0.833333 0.189167
+m 0.583333 0.189167
+t "\R0.00105"
+li 0.645833 0.070000 0.770833 0.070000
+li 0.708333 0.134167 0.708333 0.070000
+m 0.645833 0.070000
+t "\R0.00092"
+li 0.645833 0.702500 0.770833 0.702500
+li 0.708333 0.244167 0.708333 0.702500
+m 0.645833 0.702500
+t "\R0.00161"
+cl
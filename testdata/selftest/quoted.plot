@@ -0,0 +1,57 @@
+m 0.500000 0.980000
+t "\CQuoted"
+m 0.203704 0.020000
+t "\Cread latency"
+bo 0.111111 0.128286 0.296296 0.369429
+m 0.111111 0.128286
+t "\R1.5"
+m 0.111111 0.369429
+t "\R3.5"
+li 0.111111 0.248857 0.296296 0.248857
+m 0.111111 0.248857
+t "\R2.5"
+li 0.157407 0.068000 0.250000 0.068000
+li 0.203704 0.128286 0.203704 0.068000
+m 0.157407 0.068000
+t "\R1"
+li 0.157407 0.429714 0.250000 0.429714
+li 0.203704 0.369429 0.203704 0.429714
+m 0.157407 0.429714
+t "\R4"
+m 0.500000 0.020000
+t "\C2018"
+bo 0.407407 0.610571 0.592593 0.851714
+m 0.407407 0.610571
+t "\R5.5"
+m 0.407407 0.851714
+t "\R7.5"
+li 0.407407 0.731143 0.592593 0.731143
+m 0.407407 0.731143
+t "\R6.5"
+li 0.453704 0.550286 0.546296 0.550286
+li 0.500000 0.610571 0.500000 0.550286
+m 0.453704 0.550286
+t "\R5"
+li 0.453704 0.912000 0.546296 0.912000
+li 0.500000 0.851714 0.500000 0.912000
+m 0.453704 0.912000
+t "\R8"
+m 0.796296 0.020000
+t "\Csay 'hi'"
+bo 0.703704 0.309143 0.888889 0.550286
+m 0.703704 0.309143
+t "\R3"
+m 0.703704 0.550286
+t "\R5"
+li 0.703704 0.429714 0.888889 0.429714
+m 0.703704 0.429714
+t "\R4"
+li 0.750000 0.188571 0.842593 0.188571
+li 0.796296 0.309143 0.796296 0.188571
+m 0.750000 0.188571
+t "\R2"
+li 0.750000 0.670857 0.842593 0.670857
+li 0.796296 0.550286 0.796296 0.670857
+m 0.750000 0.670857
+t "\R6"
+cl
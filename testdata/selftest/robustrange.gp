@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("gc-pause" 1, "steady" 2)
+set yrange [*:19.554499999999997]
+$boxes << EOD
+1 10.8 8.2 10400 12.8 11.9 0
+2 13.6 9.2 20.1 16 14.8 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
@@ -0,0 +1,48 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.209504 0.080000 0.209504
+m 0.070000 0.209504
+t "\R10"
+li 0.070000 0.597016 0.080000 0.597016
+m 0.070000 0.597016
+t "\R15"
+m 0.348333 0.020000
+t "\Cgc-pause"
+bo 0.233333 0.271506 0.463333 0.426511
+m 0.233333 0.271506
+t "\R10.8"
+m 0.233333 0.426511
+t "\R12.8"
+li 0.233333 0.356759 0.463333 0.356759
+m 0.233333 0.356759
+t "\R11.9"
+li 0.290833 0.070000 0.405833 0.070000
+li 0.348333 0.271506 0.348333 0.070000
+m 0.290833 0.070000
+t "\R8.2"
+li 0.290833 0.950000 0.405833 0.950000
+li 0.348333 0.426511 0.348333 0.950000
+m 0.290833 0.950000
+t "\R1.04e+04"
+m 0.348333 0.970000
+t "\C1 above"
+m 0.731667 0.020000
+t "\Csteady"
+bo 0.616667 0.488512 0.846667 0.674518
+m 0.616667 0.488512
+t "\R13.6"
+m 0.616667 0.674518
+t "\R16"
+li 0.616667 0.581515 0.846667 0.581515
+m 0.616667 0.581515
+t "\R14.8"
+li 0.674167 0.147502 0.789167 0.147502
+li 0.731667 0.488512 0.731667 0.147502
+m 0.674167 0.147502
+t "\R9.2"
+li 0.674167 0.950000 0.789167 0.950000
+li 0.731667 0.674518 0.731667 0.950000
+m 0.674167 0.950000
+t "\R20.1"
+m 0.731667 0.970000
+t "\C2 above"
+cl
@@ -0,0 +1,33 @@
+m 0.291667 0.060000
+t "\Cwarmup"
+bo 0.166667 0.108000 0.416667 0.952000
+m 0.166667 0.108000
+t "\R3"
+m 0.166667 0.952000
+t "\R9"
+m 0.166667 0.952000
+v 0.202381 0.670667
+v 0.238095 0.389333
+v 0.273810 0.248667
+v 0.309524 0.108000
+v 0.345238 0.108000
+v 0.380952 0.108000
+v 0.416667 0.108000
+m 0.708333 0.060000
+t "\Csteady"
+bo 0.583333 0.108000 0.833333 0.952000
+m 0.583333 0.108000
+t "\R3"
+m 0.583333 0.248667
+t "\R4"
+m 0.583333 0.108000
+v 0.619048 0.248667
+v 0.654762 0.108000
+v 0.690476 0.248667
+v 0.726190 0.108000
+v 0.761905 0.248667
+v 0.797619 0.108000
+v 0.833333 0.248667
+m 0.050000 0.020000
+t "\Lr1: lag-1 autocorrelation"
+cl
@@ -0,0 +1,13 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:3.5]
+set xtics noenhanced ("baseline" 1, "new-slow" 2, "new-fast" 3)
+$boxes << EOD
+1 2 1 5 4 3 8421504
+2 3 2 6 5 4 16711680
+3 2 1 4 3 2 16711680
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable
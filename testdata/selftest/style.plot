@@ -0,0 +1,67 @@
+co gray
+pe dashed
+m 0.203704 0.020000
+t "\Cbaseline"
+bo 0.111111 0.246000 0.296296 0.598000
+m 0.111111 0.246000
+t "\R2"
+m 0.111111 0.598000
+t "\R4"
+li 0.111111 0.422000 0.296296 0.422000
+m 0.111111 0.422000
+t "\R3"
+li 0.157407 0.070000 0.250000 0.070000
+li 0.203704 0.246000 0.203704 0.070000
+m 0.157407 0.070000
+t "\R1"
+li 0.157407 0.774000 0.250000 0.774000
+li 0.203704 0.598000 0.203704 0.774000
+m 0.157407 0.774000
+t "\R5"
+co black
+pe solid
+co red
+pe solid
+m 0.500000 0.020000
+t "\Cnew-slow"
+bo 0.407407 0.422000 0.592593 0.774000
+m 0.407407 0.422000
+t "\R3"
+m 0.407407 0.774000
+t "\R5"
+li 0.407407 0.598000 0.592593 0.598000
+m 0.407407 0.598000
+t "\R4"
+li 0.453704 0.246000 0.546296 0.246000
+li 0.500000 0.422000 0.500000 0.246000
+m 0.453704 0.246000
+t "\R2"
+li 0.453704 0.950000 0.546296 0.950000
+li 0.500000 0.774000 0.500000 0.950000
+m 0.453704 0.950000
+t "\R6"
+co black
+pe solid
+co red
+pe dotted
+m 0.796296 0.020000
+t "\Cnew-fast"
+bo 0.703704 0.246000 0.888889 0.422000
+m 0.703704 0.246000
+t "\R2"
+m 0.703704 0.422000
+t "\R3"
+li 0.703704 0.246000 0.888889 0.246000
+m 0.703704 0.246000
+t "\R2"
+li 0.750000 0.070000 0.842593 0.070000
+li 0.796296 0.246000 0.796296 0.070000
+m 0.750000 0.070000
+t "\R1"
+li 0.750000 0.598000 0.842593 0.598000
+li 0.796296 0.422000 0.796296 0.598000
+m 0.750000 0.598000
+t "\R4"
+co black
+pe solid
+cl
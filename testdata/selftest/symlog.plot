@@ -0,0 +1,59 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.210720 0.080000 0.210720
+m 0.070000 0.210720
+t "\R-100"
+li 0.070000 0.355676 0.080000 0.355676
+m 0.070000 0.355676
+t "\R-10"
+li 0.070000 0.480553 0.080000 0.480553
+m 0.070000 0.480553
+t "\R-1"
+li 0.070000 0.551055 0.080000 0.551055
+m 0.070000 0.551055
+t "\R0"
+li 0.070000 0.621558 0.080000 0.621558
+m 0.070000 0.621558
+t "\R1"
+li 0.070000 0.746434 0.080000 0.746434
+m 0.070000 0.746434
+t "\R10"
+li 0.070000 0.891390 0.080000 0.891390
+m 0.070000 0.891390
+t "\R100"
+m 0.348333 0.020000
+t "\Cgains"
+bo 0.233333 0.462091 0.463333 0.786161
+m 0.233333 0.462091
+t "\R-1.5"
+m 0.233333 0.786161
+t "\R19"
+li 0.233333 0.631450 0.463333 0.631450
+m 0.233333 0.631450
+t "\R1.25"
+li 0.290833 0.269045 0.405833 0.269045
+li 0.348333 0.462091 0.348333 0.269045
+m 0.290833 0.269045
+t "\R-40"
+li 0.290833 0.950000 0.405833 0.950000
+li 0.348333 0.786161 0.348333 0.950000
+m 0.290833 0.950000
+t "\R250"
+m 0.731667 0.020000
+t "\Closses"
+bo 0.616667 0.199073 0.846667 0.551055
+m 0.616667 0.199073
+t "\R-120"
+m 0.616667 0.551055
+t "\R0"
+li 0.616667 0.365568 0.846667 0.365568
+m 0.616667 0.365568
+t "\R-8.5"
+li 0.674167 0.070000 0.789167 0.070000
+li 0.731667 0.199073 0.731667 0.070000
+m 0.674167 0.070000
+t "\R-900"
+li 0.674167 0.621558 0.789167 0.621558
+li 0.731667 0.551055 0.731667 0.621558
+m 0.674167 0.621558
+t "\R1"
+cl
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Cget"
+bo 0.166667 0.128095 0.416667 0.170952
+m 0.166667 0.128095
+t "\R9.2"
+m 0.166667 0.170952
+t "\R13.7"
+li 0.166667 0.145238 0.416667 0.145238
+m 0.166667 0.145238
+t "\R11"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.128095 0.291667 0.070000
+m 0.229167 0.070000
+t "\R3.1"
+li 0.229167 0.497619 0.354167 0.497619
+li 0.291667 0.170952 0.291667 0.497619
+m 0.229167 0.497619
+t "\R48"
+m 0.708333 0.020000
+t "\Cput"
+bo 0.583333 0.244617 0.833333 0.348965
+m 0.583333 0.244617
+t "\R21.4"
+m 0.583333 0.348965
+t "\R32.4"
+li 0.583333 0.288095 0.833333 0.288095
+m 0.583333 0.288095
+t "\R26"
+li 0.645833 0.118571 0.770833 0.118571
+li 0.708333 0.244617 0.708333 0.118571
+m 0.645833 0.118571
+t "\R8.2"
+li 0.645833 0.950000 0.770833 0.950000
+li 0.708333 0.348965 0.708333 0.950000
+m 0.645833 0.950000
+t "\R95.5"
+cl
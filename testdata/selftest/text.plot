@@ -0,0 +1,45 @@
+m 0.500000 0.920000
+t "\CTitle"
+m 0.990000 0.980000
+t "\Rrun-42"
+m 0.990000 0.960000
+t "\Rsecond"
+m 0.010000 0.020000
+t "\Lfootnote"
+m 0.291667 0.060000
+t "\Ca"
+bo 0.166667 0.228667 0.416667 0.480000
+m 0.166667 0.228667
+t "\R1.5"
+m 0.166667 0.480000
+t "\R2.5"
+li 0.166667 0.354333 0.416667 0.354333
+m 0.166667 0.354333
+t "\R2"
+li 0.229167 0.103000 0.354167 0.103000
+li 0.291667 0.228667 0.291667 0.103000
+m 0.229167 0.103000
+t "\R1"
+li 0.229167 0.605667 0.354167 0.605667
+li 0.291667 0.480000 0.291667 0.605667
+m 0.229167 0.605667
+t "\R3"
+m 0.708333 0.060000
+t "\Cb"
+bo 0.583333 0.480000 0.833333 0.731333
+m 0.583333 0.480000
+t "\R2.5"
+m 0.583333 0.731333
+t "\R3.5"
+li 0.583333 0.605667 0.833333 0.605667
+m 0.583333 0.605667
+t "\R3"
+li 0.645833 0.354333 0.770833 0.354333
+li 0.708333 0.480000 0.708333 0.354333
+m 0.645833 0.354333
+t "\R2"
+li 0.645833 0.857000 0.770833 0.857000
+li 0.708333 0.731333 0.708333 0.857000
+m 0.645833 0.857000
+t "\R4"
+cl
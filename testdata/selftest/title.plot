@@ -0,0 +1,39 @@
+m 0.500000 0.980000
+t "\CTitle"
+m 0.291667 0.020000
+t "\Clinear"
+bo 0.166667 0.081397 0.416667 0.121587
+m 0.166667 0.081397
+t "\R2"
+m 0.166667 0.121587
+t "\R5"
+li 0.166667 0.101492 0.416667 0.101492
+m 0.166667 0.101492
+t "\R3.5"
+li 0.229167 0.068000 0.354167 0.068000
+li 0.291667 0.081397 0.291667 0.068000
+m 0.229167 0.068000
+t "\R1"
+li 0.229167 0.134984 0.354167 0.134984
+li 0.291667 0.121587 0.291667 0.134984
+m 0.229167 0.134984
+t "\R6"
+m 0.708333 0.020000
+t "\Cexponential"
+bo 0.583333 0.108190 0.833333 0.483302
+m 0.583333 0.108190
+t "\R4"
+m 0.583333 0.483302
+t "\R32"
+li 0.583333 0.215365 0.833333 0.215365
+m 0.583333 0.215365
+t "\R12"
+li 0.645833 0.081397 0.770833 0.081397
+li 0.708333 0.108190 0.708333 0.081397
+m 0.645833 0.081397
+t "\R2"
+li 0.645833 0.912000 0.770833 0.912000
+li 0.708333 0.483302 0.708333 0.912000
+m 0.645833 0.912000
+t "\R64"
+cl
@@ -0,0 +1,17 @@
+# Box plots written by box; run with gnuplot -p FILE.
+set key off
+set style fill empty
+set boxwidth 0.5
+set xrange [0.5:2.5]
+set xtics noenhanced ("latency" 1, "steady" 2)
+$boxes << EOD
+1 11.5 10 16 14.5 13 0
+2 6 5 9 8 7 0
+EOD
+$outliers << EOD
+1 48 0
+1 -20 0
+EOD
+plot $boxes using 1:2:3:4:5:7 with candlesticks whiskerbars 0.5 lc rgb variable, \
+	$boxes using 1:6:6:6:6:7 with candlesticks lw 2 lc rgb variable, \
+	$outliers using 1:2:3 with points pt 6 lc rgb variable
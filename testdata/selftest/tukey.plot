@@ -0,0 +1,39 @@
+m 0.291667 0.020000
+t "\Clatency"
+bo 0.166667 0.477647 0.416667 0.516471
+m 0.166667 0.477647
+t "\R11.5"
+m 0.166667 0.516471
+t "\R14.5"
+li 0.166667 0.497059 0.416667 0.497059
+m 0.166667 0.497059
+t "\R13"
+li 0.229167 0.458235 0.354167 0.458235
+li 0.291667 0.477647 0.291667 0.458235
+m 0.229167 0.458235
+t "\R10"
+li 0.229167 0.535882 0.354167 0.535882
+li 0.291667 0.516471 0.291667 0.535882
+m 0.229167 0.535882
+t "\R16"
+ci 0.291667 0.950000 0.007812
+ci 0.291667 0.070000 0.007812
+m 0.708333 0.020000
+t "\Csteady"
+bo 0.583333 0.406471 0.833333 0.432353
+m 0.583333 0.406471
+t "\R6"
+m 0.583333 0.432353
+t "\R8"
+li 0.583333 0.419412 0.833333 0.419412
+m 0.583333 0.419412
+t "\R7"
+li 0.645833 0.393529 0.770833 0.393529
+li 0.708333 0.406471 0.708333 0.393529
+m 0.645833 0.393529
+t "\R5"
+li 0.645833 0.445294 0.770833 0.445294
+li 0.708333 0.432353 0.708333 0.445294
+m 0.645833 0.445294
+t "\R9"
+cl
@@ -0,0 +1,37 @@
+m 0.291667 0.020000
+t "\Csmall"
+bo 0.166667 0.290000 0.416667 0.730000
+m 0.166667 0.290000
+t "\R3.25"
+m 0.166667 0.730000
+t "\R7.75"
+li 0.166667 0.510000 0.416667 0.510000
+m 0.166667 0.510000
+t "\R5.5"
+li 0.229167 0.070000 0.354167 0.070000
+li 0.291667 0.290000 0.291667 0.070000
+m 0.229167 0.070000
+t "\R1"
+li 0.229167 0.950000 0.354167 0.950000
+li 0.291667 0.730000 0.291667 0.950000
+m 0.229167 0.950000
+t "\R10"
+m 0.708333 0.020000
+t "\Cties"
+bo 0.583333 0.265556 0.833333 0.363333
+m 0.583333 0.265556
+t "\R3"
+m 0.583333 0.363333
+t "\R4"
+li 0.583333 0.314444 0.833333 0.314444
+m 0.583333 0.314444
+t "\R3.5"
+li 0.645833 0.265556 0.770833 0.265556
+li 0.708333 0.265556 0.708333 0.265556
+m 0.645833 0.265556
+t "\R3"
+li 0.645833 0.852222 0.770833 0.852222
+li 0.708333 0.363333 0.708333 0.852222
+m 0.645833 0.852222
+t "\R9"
+cl
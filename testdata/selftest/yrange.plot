@@ -0,0 +1,56 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.070000 0.080000 0.070000
+m 0.070000 0.070000
+t "\R0"
+li 0.070000 0.246000 0.080000 0.246000
+m 0.070000 0.246000
+t "\R20"
+li 0.070000 0.422000 0.080000 0.422000
+m 0.070000 0.422000
+t "\R40"
+li 0.070000 0.598000 0.080000 0.598000
+m 0.070000 0.598000
+t "\R60"
+li 0.070000 0.774000 0.080000 0.774000
+m 0.070000 0.774000
+t "\R80"
+li 0.070000 0.950000 0.080000 0.950000
+m 0.070000 0.950000
+t "\R100"
+m 0.348333 0.020000
+t "\Cmonday"
+bo 0.233333 0.246000 0.463333 0.382400
+m 0.233333 0.246000
+t "\R20"
+m 0.233333 0.382400
+t "\R35.5"
+li 0.233333 0.290000 0.463333 0.290000
+m 0.233333 0.290000
+t "\R25"
+li 0.290833 0.175600 0.405833 0.175600
+li 0.348333 0.246000 0.348333 0.175600
+m 0.290833 0.175600
+t "\R12"
+li 0.290833 0.492400 0.405833 0.492400
+li 0.348333 0.382400 0.348333 0.492400
+m 0.290833 0.492400
+t "\R48"
+m 0.731667 0.020000
+t "\Ctuesday"
+bo 0.616667 0.272400 0.846667 0.470400
+m 0.616667 0.272400
+t "\R23"
+m 0.616667 0.470400
+t "\R45.5"
+li 0.616667 0.360400 0.846667 0.360400
+m 0.616667 0.360400
+t "\R33"
+li 0.674167 0.202000 0.789167 0.202000
+li 0.731667 0.272400 0.731667 0.202000
+m 0.674167 0.202000
+t "\R15"
+li 0.674167 0.950000 0.789167 0.950000
+li 0.731667 0.470400 0.731667 0.950000
+m 0.674167 0.950000
+t "\R140"
+cl
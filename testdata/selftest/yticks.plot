@@ -0,0 +1,44 @@
+li 0.080000 0.070000 0.080000 0.950000
+li 0.070000 0.255263 0.080000 0.255263
+m 0.070000 0.255263
+t "\Rp50-SLO"
+li 0.070000 0.602632 0.080000 0.602632
+m 0.070000 0.602632
+t "\Rp99-SLO"
+m 0.348333 0.020000
+t "\Capi"
+bo 0.233333 0.180000 0.463333 0.533158
+m 0.233333 0.180000
+t "\R67.5"
+m 0.233333 0.533158
+t "\R220"
+li 0.233333 0.266842 0.463333 0.266842
+m 0.233333 0.266842
+t "\R105"
+li 0.290833 0.116316 0.405833 0.116316
+li 0.348333 0.180000 0.348333 0.116316
+m 0.290833 0.116316
+t "\R40"
+li 0.290833 0.741579 0.405833 0.741579
+li 0.348333 0.533158 0.348333 0.741579
+m 0.290833 0.741579
+t "\R310"
+m 0.731667 0.020000
+t "\Cdb"
+bo 0.616667 0.087368 0.846667 0.226316
+m 0.616667 0.087368
+t "\R27.5"
+m 0.616667 0.226316
+t "\R87.5"
+li 0.616667 0.127895 0.846667 0.127895
+m 0.616667 0.127895
+t "\R45"
+li 0.674167 0.070000 0.789167 0.070000
+li 0.731667 0.087368 0.731667 0.070000
+m 0.674167 0.070000
+t "\R20"
+li 0.674167 0.950000 0.789167 0.950000
+li 0.731667 0.226316 0.731667 0.950000
+m 0.674167 0.950000
+t "\R400"
+cl
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+)
+
+// TextRegions are the named regions of a figure in which -text may be placed,
+// and the alignment of text in each.
+// The top regions are in a strip above the title,
+// and the bottom regions are in a strip below the legend.
+var textRegions = map[string]byte{
+	"topleft":     'L',
+	"top":         'C',
+	"topright":    'R',
+	"bottomleft":  'L',
+	"bottom":      'C',
+	"bottomright": 'R',
+}
+
+// A regionText is a string to place in a named region of the figure.
+type regionText struct {
+	region, text string
+}
+
+// TextFlag is the value of the repeatable -text flag.
+type textFlag []regionText
+
+// Texts are the -text flags, in order.
+var texts textFlag
+
+func init() {
+	flag.Var(&texts, "text", "place text in a region of the figure, as `region:text`; may be repeated")
+}
+
+func (t *textFlag) String() string {
+	var ss []string
+	for _, rt := range *t {
+		ss = append(ss, rt.region+":"+rt.text)
+	}
+	return strings.Join(ss, " ")
+}
+
+func (t *textFlag) values() []string {
+	var vs []string
+	for _, rt := range *t {
+		vs = append(vs, rt.region+":"+rt.text)
+	}
+	return vs
+}
+
+// Set adds a region:text flag to the list.
+// An empty value clears the list.
+func (t *textFlag) Set(s string) error {
+	if s == "" {
+		*t = nil
+		return nil
+	}
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return fmt.Errorf("missing ':' in %q", s)
+	}
+	region := s[:i]
+	if _, ok := textRegions[region]; !ok {
+		return fmt.Errorf("unknown region %q", region)
+	}
+	*t = append(*t, regionText{region: region, text: s[i+1:]})
+	return nil
+}
+
+// LayoutTexts allocates strips at the top and bottom of a layout
+// for the -text flags in the top and bottom regions,
+// and returns a label for each.
+// Texts in the same region are stacked one line apart, in order.
+func layoutTexts(l *layout) []label {
+	lines := make(map[string]int)
+	var top, bottom int
+	for _, rt := range texts {
+		lines[rt.region]++
+		n := lines[rt.region]
+		if strings.HasPrefix(rt.region, "top") && n > top {
+			top = n
+		} else if !strings.HasPrefix(rt.region, "top") && n > bottom {
+			bottom = n
+		}
+	}
+	var topArea, bottomArea rect
+	if top > 0 {
+		topArea = l.top(float64(top+1) * textHeight)
+	}
+	if bottom > 0 {
+		bottomArea = l.bottom(float64(bottom+1) * textHeight)
+	}
+	var labels []label
+	line := make(map[string]int)
+	for _, rt := range texts {
+		r, i := topArea, line[rt.region]
+		y := r.y1 - float64(i+1)*textHeight
+		if !strings.HasPrefix(rt.region, "top") {
+			r = bottomArea
+			y = r.y0 + float64(lines[rt.region]-i)*textHeight
+		}
+		line[rt.region]++
+		a := textRegions[rt.region]
+		x := (r.x0 + r.x1) / 2
+		switch a {
+		case 'L':
+			x = r.x0 + charWidth
+		case 'R':
+			x = r.x1 - charWidth
+		}
+		labels = append(labels, label{x: x, y: y, align: a, text: rt.text})
+	}
+	return labels
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// A tokenScanner scans the tokens of the default input format.
+// It is a *bufio.Scanner split by scanTokens,
+// or, for -mmap input, a byteTokens of the mapping.
+type tokenScanner interface {
+	Scan() bool
+	Text() string
+	Bytes() []byte
+	Err() error
+}
+
+var _ tokenScanner = (*bufio.Scanner)(nil)
+
+// NewTokenScanner returns a *bufio.Scanner of the tokens of r,
+// with a buffer large enough to read a large input in few reads.
+func newTokenScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), bufio.MaxScanTokenSize)
+	scanner.Split(scanTokens)
+	return scanner
+}
+
+// InputSize returns the size in bytes of the rest of an input
+// that is a regular file or in memory, or 0 if it is unknown.
+func inputSize(r io.Reader) int64 {
+	switch r := r.(type) {
+	case *os.File:
+		info, err := r.Stat()
+		if err != nil || !info.Mode().IsRegular() {
+			return 0
+		}
+		off, err := r.Seek(0, io.SeekCurrent)
+		if err != nil || off > info.Size() {
+			return 0
+		}
+		return info.Size() - off
+	case interface{ Len() int }:
+		return int64(r.Len())
+	}
+	return 0
+}
+
+// ScanTokens is a bufio.SplitFunc that splits the tokens of the default input format,
+// as boxplot.ScanTokens does.
+// White space and tokens of ASCII are split directly,
+// and the rest, quoted names and Unicode, by boxplot.ScanTokens,
+// which decodes every rune.
+func scanTokens(data []byte, atEOF bool) (int, []byte, error) {
+	start := 0
+	for start < len(data) && asciiSpace(data[start]) {
+		start++
+	}
+	for i := start; i < len(data); i++ {
+		c := data[i]
+		if asciiSpace(c) {
+			return i, data[start:i], nil
+		}
+		if c >= 0x80 || c == '"' {
+			n, tok, err := boxplot.ScanTokens(data[start:], atEOF)
+			if n == 0 && tok == nil && err == nil {
+				// Request more data, keeping the white space skipped so far.
+				return start, nil, nil
+			}
+			return start + n, tok, err
+		}
+	}
+	if atEOF && start < len(data) {
+		return len(data), data[start:], nil
+	}
+	return start, nil, nil
+}
+
+// ByteTokens scans the tokens of input that is entirely in memory
+// with scanTokens, splitting them in place,
+// without copying the input into a buffer.
+type byteTokens struct {
+	data []byte
+	tok  []byte
+	err  error
+}
+
+func (t *byteTokens) Scan() bool {
+	if t.err != nil {
+		return false
+	}
+	n, tok, err := scanTokens(t.data, true)
+	t.data, t.tok, t.err = t.data[n:], tok, err
+	return err == nil && tok != nil
+}
+
+func (t *byteTokens) Text() string { return string(t.tok) }
+
+func (t *byteTokens) Bytes() []byte { return t.tok }
+
+func (t *byteTokens) Err() error { return t.err }
+
+// AsciiSpace returns whether c is an ASCII white space character,
+// as unicode.IsSpace reports for the bytes below 0x80.
+func asciiSpace(c byte) bool { return asciiSpaces[c] }
+
+// AsciiSpaces is whether each byte is an ASCII white space character,
+// looked up in place of comparing each byte to each of them.
+var asciiSpaces = [256]bool{' ': true, '\t': true, '\n': true, '\v': true, '\f': true, '\r': true}
+
+// ParseFloat returns strconv.ParseFloat(s, 64),
+// parsing plain decimals, such as 12.5, -0.003, or 6.02e23, directly.
+// A decimal of at most 19 digits whose mantissa is at most 2⁵³
+// and whose exponent is within ±22 is exactly a float64
+// multiplied or divided by an exact power of ten,
+// so the one rounding of that operation is the correctly rounded value,
+// as strconv.ParseFloat returns; see Clinger, How to Read Floating Point Numbers Accurately.
+// Anything else, including every malformed token, is parsed by strconv.ParseFloat.
+// A token of a scanner's buffer is parsed as a []byte, which needs no copy to a string.
+func parseFloat[T string | []byte](s T) (float64, error) {
+	i := 0
+	neg := false
+	if len(s) > 0 && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		i++
+	}
+	var mant uint64
+	start := i
+	for ; i < len(s); i++ {
+		d := s[i] - '0'
+		if d > 9 {
+			break
+		}
+		mant = mant*10 + uint64(d)
+	}
+	digits, exp := i-start, 0
+	if i < len(s) && s[i] == '.' {
+		i++
+		frac := i
+		for ; i < len(s); i++ {
+			d := s[i] - '0'
+			if d > 9 {
+				break
+			}
+			mant = mant*10 + uint64(d)
+		}
+		digits += i - frac
+		exp = frac - i
+	}
+	// Leading zeros are counted as digits, so such decimals as 0.000000000000000000001
+	// are left to strconv.ParseFloat, as is any mantissa that may have overflowed.
+	if digits == 0 || digits > 19 {
+		return strconv.ParseFloat(string(s), 64)
+	}
+	if i < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		i++
+		eneg := false
+		if i < len(s) && (s[i] == '+' || s[i] == '-') {
+			eneg = s[i] == '-'
+			i++
+		}
+		e, estart := 0, i
+		for ; i < len(s) && i-estart < 4; i++ {
+			d := s[i] - '0'
+			if d > 9 {
+				break
+			}
+			e = e*10 + int(d)
+		}
+		if i == estart {
+			return strconv.ParseFloat(string(s), 64)
+		}
+		if eneg {
+			e = -e
+		}
+		exp += e
+	}
+	if i != len(s) || mant > 1<<53 || exp < -22 || exp > 22 {
+		return strconv.ParseFloat(string(s), 64)
+	}
+	f := float64(mant)
+	if exp < 0 {
+		f /= float64pow10[-exp]
+	} else if exp > 0 {
+		f *= float64pow10[exp]
+	}
+	if neg {
+		f = -f
+	}
+	return f, nil
+}
+
+// Float64pow10 are the powers of ten that are exactly float64s.
+var float64pow10 = [...]float64{
+	1e0, 1e1, 1e2, 1e3, 1e4, 1e5, 1e6, 1e7, 1e8, 1e9, 1e10,
+	1e11, 1e12, 1e13, 1e14, 1e15, 1e16, 1e17, 1e18, 1e19, 1e20, 1e21, 1e22,
+}
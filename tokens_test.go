@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bufio"
+	"math"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/eaburns/box/boxplot"
+)
+
+// TestByteTokens tests that scanTokens and byteTokens split the same tokens
+// as a *bufio.Scanner split by boxplot.ScanTokens.
+func TestByteTokens(t *testing.T) {
+	for _, input := range []string{
+		"",
+		"   \n\t ",
+		"a 1 2 3",
+		"  a 1\n2\r\n3  ",
+		"\"a b\" 1 \"c \\\" d\" 2",
+		"é 1 2  3\u0085x",
+		"a 1 \"unterminated",
+		"x\u00a0y  \"q\" 1e3 -2",
+	} {
+		scanner := bufio.NewScanner(strings.NewReader(input))
+		scanner.Split(boxplot.ScanTokens)
+		var want []string
+		for scanner.Scan() {
+			want = append(want, scanner.Text())
+		}
+		// One byte at a time, scanTokens must ask for more data at every boundary.
+		one := bufio.NewScanner(iotest.OneByteReader(strings.NewReader(input)))
+		one.Split(scanTokens)
+		var got []string
+		for one.Scan() {
+			got = append(got, one.Text())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: scanTokens one byte at a time: tokens %q, want %q", input, got, want)
+		}
+		bt := &byteTokens{data: []byte(input)}
+		got = nil
+		for bt.Scan() {
+			got = append(got, bt.Text())
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("%q: byteTokens: tokens %q, want %q", input, got, want)
+		}
+		if (bt.Err() == nil) != (scanner.Err() == nil) {
+			t.Errorf("%q: error %v, want %v", input, bt.Err(), scanner.Err())
+		}
+	}
+}
+
+// TestParseFloat tests that parseFloat returns what strconv.ParseFloat does,
+// for plain decimals and for tokens that it leaves to strconv.ParseFloat.
+func TestParseFloat(t *testing.T) {
+	tests := []string{
+		"0", "-0", "+0", "0.0", "-0.0", "1", "-1", "+1", "12.5", ".5", "5.", "-.5",
+		"0.05", "0.1", "0.3", "1e3", "1E3", "1e+3", "1e-3", "6.02e23", "1.7976931348623157e308",
+		"9007199254740992", "9007199254740993", "1234567890123456789", "12345678901234567890",
+		"1e22", "1e23", "1e-22", "1e-23", "123456789e-30", "0.000000000000000000000001",
+		"1e0001", "1e00001", "4.9e-324", "1e400", "-1e400",
+		"0e23", "-0e99", "0.0e-30", "0e500", "0e-500", "-0.0e9999",
+		"", ".", "-", "+", "e5", ".e5", "1e", "1e+", "1.2.3", "1_000", "0x1p-2",
+		"inf", "-Inf", "NaN", "a", "1a", "--1", "1 ",
+	}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10000; i++ {
+		v := math.Exp(rng.NormFloat64() * 10)
+		tests = append(tests,
+			strconv.FormatFloat(v, 'g', 1+rng.Intn(17), 64),
+			strconv.FormatFloat(-v, 'f', rng.Intn(12), 64),
+			strconv.FormatFloat(v, 'e', rng.Intn(17), 64))
+	}
+	for _, s := range tests {
+		got, gotErr := parseFloat(s)
+		want, wantErr := strconv.ParseFloat(s, 64)
+		if math.Float64bits(got) != math.Float64bits(want) && !(math.IsNaN(got) && math.IsNaN(want)) || (gotErr == nil) != (wantErr == nil) {
+			t.Errorf("parseFloat(%q) = %v, %v, want %v, %v", s, got, gotErr, want, wantErr)
+		}
+		if got, err := parseFloat([]byte(s)); math.Float64bits(got) != math.Float64bits(want) && !(math.IsNaN(got) && math.IsNaN(want)) || (err == nil) != (wantErr == nil) {
+			t.Errorf("parseFloat([]byte(%q)) = %v, %v, want %v, %v", s, got, err, want, wantErr)
+		}
+	}
+}
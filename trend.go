@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+)
+
+// CheckTrend returns an error if -trend is set with an -o output format
+// in which the trend chart cannot be drawn: gnuplot, vega, or term,
+// or with a bad size, as checkSize reports.
+// It is checked before reading, so that nothing is written if it fails.
+func checkTrend() error {
+	if *trendFile == "" {
+		return nil
+	}
+	if *outFormat == "gnuplot" || *outFormat == "vega" || *outFormat == "term" {
+		return fmt.Errorf("-trend supports -o plot, svg, png, eps, and pic, not %s", *outFormat)
+	}
+	return checkSize()
+}
+
+// WriteTrend writes, for -trend, the trend chart of the boxes
+// to the -trend file, in the -o output format, which checkTrend has checked.
+func writeTrend(boxes []box, title string) error {
+	f, err := os.Create(*trendFile)
+	if err != nil {
+		return err
+	}
+	var cv canvas
+	switch *outFormat {
+	case "plot":
+		cv = plotCanvas{w: f}
+	case "svg":
+		cv = &svgCanvas{w: f}
+	case "png":
+		cv = newPNGCanvas(f)
+	case "eps":
+		cv = &epsCanvas{w: f}
+	case "pic":
+		cv = &picCanvas{w: f}
+	}
+	drawTrend(cv, boxes, title)
+	if err := cv.close(); err != nil {
+		f.Close()
+		return fmt.Errorf("Write failed: %v", err)
+	}
+	return f.Close()
+}
+
+// DrawTrend draws the trend chart of the boxes:
+// the median of each box, in order from left to right,
+// joined by a line, within a band from the first to the third quartile,
+// on a value axis spanning the bands.
+// The order of the boxes is the ordering variable, such as time or version,
+// so the chart is the summary of the box plots across it.
+// Boxes with no values are left out of the line and the band,
+// but keep their places and names.
+func drawTrend(cv canvas, boxes []box, title string) {
+	l := layout{free: page}
+	if title != "" {
+		r := l.top(titleHeight)
+		cv.text("title", (r.x0+r.x1)/2, (r.y0+r.y1)/2, 'C', title)
+	}
+	l.top(0.05)
+	l.right(0.05)
+	names := l.bottom(0.05 + textHeight)
+	axisArea := l.left(axisWidth)
+	yBottom, yTop := l.free.y0, l.free.y1
+
+	min, max := math.Inf(1), math.Inf(-1)
+	var xs, q1s, q2s, q3s []float64
+	width := (l.free.x1 - l.free.x0) / float64(len(boxes))
+	for i, b := range boxes {
+		x := l.free.x0 + width*(float64(i)+0.5)
+		cv.group(b.name)
+		cv.text("name", x, names.y0+textHeight, 'C', b.name)
+		cv.group("")
+		if b.n == 0 {
+			continue
+		}
+		xs = append(xs, x)
+		q1s, q2s, q3s = append(q1s, b.q1), append(q2s, b.q2), append(q3s, b.q3)
+		min, max = math.Min(min, b.q1), math.Max(max, b.q3)
+	}
+	if len(xs) == 0 {
+		return
+	}
+	min, max = pinned(min, max)
+	if min == max {
+		min, max = min-0.5, max+0.5
+	}
+	tr := valueScale(min, max, yBottom, yTop)
+	if !math.IsNaN(rangeMin) || !math.IsNaN(rangeMax) {
+		tr = clampTr(tr, yBottom, yTop)
+	}
+	drawAxis(cv, axisArea, yBottom, yTop, min, max, tr)
+
+	// The band runs along the third quartiles and back along the first.
+	var bx, by []float64
+	for i := range xs {
+		bx, by = append(bx, xs[i]), append(by, tr(q3s[i]))
+	}
+	for i := len(xs) - 1; i >= 0; i-- {
+		bx, by = append(bx, xs[i]), append(by, tr(q1s[i]))
+	}
+	cv.polyline("band", append(bx, bx[0]), append(by, by[0]))
+	ys := make([]float64, len(xs))
+	for i := range xs {
+		ys[i] = tr(q2s[i])
+	}
+	if len(xs) > 1 {
+		cv.polyline("median", xs, ys)
+	}
+	for i := range xs {
+		cv.circle("median", xs[i], ys[i], math.Min(width/8, 0.005))
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTrendFormatBeforeOutput tests that -trend with an -o format
+// that cannot draw it is a usage error before anything is written.
+func TestTrendFormatBeforeOutput(t *testing.T) {
+	defer resetFlags()
+	resetFlags()
+	path := filepath.Join(t.TempDir(), "trend")
+	if err := flag.Set("trend", path); err != nil {
+		t.Fatal(err)
+	}
+	if err := flag.Set("o", "term"); err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	if err := run(strings.NewReader("a 1 2 3"), &out); exitStatus(err) != exitUsage {
+		t.Errorf("exit status %d, want %d", exitStatus(err), exitUsage)
+	}
+	if out.Len() > 0 {
+		t.Errorf("wrote the plot before failing:\n%s", out.String())
+	}
+	if _, err := os.Stat(path); err == nil {
+		t.Errorf("wrote %s", path)
+	}
+}
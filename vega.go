@@ -0,0 +1,153 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// VegaSchema is the Vega-Lite version of -o vega specifications.
+const vegaSchema = "https://vega.github.io/schema/vega-lite/v5.json"
+
+// WriteVega writes the boxes as a Vega-Lite specification, for -o vega,
+// to be rendered interactively in notebooks, such as Jupyter or Observable,
+// and in browsers with vega-embed.
+// The statistics are inline data, with a row for each box:
+// its name, quartiles, whiskers by -whiskers, and number of values,
+// and a row for each outlier, so the specification needs nothing else.
+// The boxes are layers of rules from whisker to whisker,
+// bars from the first to the third quartile, ticks at the medians,
+// and points at the outliers, in the order of the input,
+// with the statistics of a box shown in a tooltip over it.
+// As with -o gnuplot, the value axis is logarithmic with -log or -log2
+// and spans -ymin and -ymax if set, and boxes are colored and dashed by their -style.
+// The chart is -width by -height pixels.
+// The flags it cannot draw, such as -matrix and -notch, are rejected by checkFormat.
+func writeVega(boxes []box, title string, w io.Writer) error {
+	var names []string
+	var rows, outliers []map[string]interface{}
+	for _, b := range boxes {
+		names = append(names, b.name)
+		if b.n == 0 {
+			continue
+		}
+		lo, hi, out := b.whiskers()
+		st := styleOf(b.name)
+		color := st.color
+		if color == "" {
+			color = "black"
+		}
+		dash := vegaDash(styleDashes[st.line])
+		rows = append(rows, map[string]interface{}{
+			"name": b.name, "n": b.n, "color": color, "dash": dash,
+			"lower": lo, "q1": b.q1, "median": b.q2, "q3": b.q3, "upper": hi,
+		})
+		for _, v := range out {
+			outliers = append(outliers, map[string]interface{}{"name": b.name, "value": v, "color": color})
+		}
+	}
+
+	x := map[string]interface{}{
+		"field": "name", "type": "nominal", "title": nil,
+		"scale": map[string]interface{}{"domain": names},
+	}
+	y := func(field string) map[string]interface{} {
+		return map[string]interface{}{"field": field, "type": "quantitative", "scale": vegaScale(), "title": nil}
+	}
+	color := map[string]interface{}{"field": "color", "type": "nominal", "scale": nil}
+	dash := map[string]interface{}{"field": "dash", "type": "nominal", "scale": nil}
+	var tooltip []map[string]interface{}
+	for _, f := range []string{"name", "n", "lower", "q1", "median", "q3", "upper"} {
+		t := map[string]interface{}{"field": f, "type": "quantitative"}
+		if f == "name" {
+			t["type"] = "nominal"
+		}
+		tooltip = append(tooltip, t)
+	}
+	layers := []map[string]interface{}{
+		{
+			"mark": "rule",
+			"encoding": map[string]interface{}{
+				"x": x, "y": y("lower"), "y2": map[string]interface{}{"field": "upper"},
+				"color": color, "strokeDash": dash,
+			},
+		},
+		{
+			"mark": map[string]interface{}{"type": "bar", "size": 40, "fill": "white", "strokeWidth": 1},
+			"encoding": map[string]interface{}{
+				"x": x, "y": y("q1"), "y2": map[string]interface{}{"field": "q3"},
+				"stroke": color, "strokeDash": dash, "tooltip": tooltip,
+			},
+		},
+		{
+			"mark":     map[string]interface{}{"type": "tick", "size": 40, "thickness": 2},
+			"encoding": map[string]interface{}{"x": x, "y": y("median"), "color": color},
+		},
+	}
+	if len(outliers) > 0 {
+		layers = append(layers, map[string]interface{}{
+			"data": map[string]interface{}{"values": outliers},
+			"mark": map[string]interface{}{"type": "point", "size": 20},
+			"encoding": map[string]interface{}{
+				"x": x, "y": y("value"), "color": color,
+				"tooltip": []map[string]interface{}{{"field": "name", "type": "nominal"}, {"field": "value", "type": "quantitative"}},
+			},
+		})
+	}
+	spec := map[string]interface{}{
+		"$schema": vegaSchema,
+		"width":   *width,
+		"height":  *height,
+		"data":    map[string]interface{}{"values": rows},
+		"layer":   layers,
+	}
+	if title != "" {
+		spec["title"] = title
+	}
+	data, err := json.MarshalIndent(spec, "", "\t")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// VegaScale returns the Vega-Lite scale of the value axis:
+// logarithmic with -log or -log2, or symmetric logarithmic with -log-zero symlog,
+// bounded by -ymin and -ymax, and not extended to zero, which box plots do not need.
+func vegaScale() map[string]interface{} {
+	s := map[string]interface{}{"zero": false}
+	switch {
+	case *logScale && *logZero == "symlog":
+		s["type"], s["constant"] = "symlog", logLinear
+	case *logScale:
+		s["type"] = "log"
+		if *log2Scale {
+			s["base"] = 2
+		}
+		if *logZero == "epsilon" {
+			// Values at or below zero are clamped to the floor.
+			s["domainMin"], s["clamp"] = logFloor, true
+		}
+	}
+	if !math.IsNaN(rangeMin) {
+		s["domainMin"] = rangeMin
+	}
+	if !math.IsNaN(rangeMax) {
+		s["domainMax"] = rangeMax
+	}
+	return s
+}
+
+// VegaDash returns an SVG dash array of styleDashes as a Vega-Lite strokeDash,
+// which is empty for a solid line.
+func vegaDash(dash string) []float64 {
+	ds := []float64{}
+	for _, d := range strings.Fields(dash) {
+		v, _ := strconv.ParseFloat(d, 64)
+		ds = append(ds, v)
+	}
+	return ds
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseWhiskers parses a -whiskers rule, returning its kind:
+// minmax, tukey, or percentile, for a rule of the form pLO,pHI,
+// in which case lo and hi are the percentiles as fractions.
+func parseWhiskers(rule string) (kind string, lo, hi float64, err error) {
+	switch rule {
+	case "minmax", "tukey":
+		return rule, 0, 0, nil
+	}
+	ps := strings.Split(rule, ",")
+	if len(ps) != 2 || !strings.HasPrefix(ps[0], "p") || !strings.HasPrefix(ps[1], "p") {
+		return "", 0, 0, fmt.Errorf("Unknown whisker rule: %s", rule)
+	}
+	lo, err0 := strconv.ParseFloat(ps[0][1:], 64)
+	hi, err1 := strconv.ParseFloat(ps[1][1:], 64)
+	if err0 != nil || err1 != nil || lo < 0 || lo >= hi || hi > 100 {
+		return "", 0, 0, fmt.Errorf("Bad whisker percentiles: %s", rule)
+	}
+	return "percentile", lo / 100, hi / 100, nil
+}
+
+// CheckWhiskers returns an error if the -whiskers rule
+// or the -whisker-k multiple is bad.
+func checkWhiskers() error {
+	if _, _, _, err := parseWhiskers(*whiskerRule); err != nil {
+		return err
+	}
+	if !(*whiskerK > 0) {
+		return fmt.Errorf("Bad whisker multiple: %g", *whiskerK)
+	}
+	return nil
+}
+
+// Whiskers returns the values at the ends of the whiskers of a box,
+// and the values beyond them, which are drawn as outliers,
+// according to the -whiskers rule.
+// With minmax, the whiskers end at the minimum and maximum,
+// and there are no outliers.
+// With tukey, they end at the most extreme values within
+// -whisker-k times the interquartile range of the quartiles.
+// With pLO,pHI, they end at the LOth and HIth percentiles,
+// linearly interpolated between the values.
+// Boxes read from sketches have percentile whiskers estimated from the sketch,
+// but, like boxes of summaries, no outliers,
+// and whiskers ending at the minimum and maximum under the tukey rule,
+// since the values beyond the fences are unknown.
+func (b box) whiskers() (lo, hi float64, outliers []float64) {
+	kind, plo, phi, _ := parseWhiskers(*whiskerRule)
+	switch {
+	case kind == "percentile" && len(b.values) > 0:
+		vs := append([]float64(nil), b.values...)
+		sort.Float64s(vs)
+		lo, hi = quantileSorted(vs, plo), quantileSorted(vs, phi)
+	case kind == "percentile" && b.digest != nil:
+		return b.digest.quantile(plo), b.digest.quantile(phi), nil
+	case kind == "tukey" && len(b.values) > 0:
+		iqr := b.q3 - b.q1
+		loFence, hiFence := b.q1-*whiskerK*iqr, b.q3+*whiskerK*iqr
+		lo, hi = b.q1, b.q3
+		for _, v := range b.values {
+			if v >= loFence && v < lo {
+				lo = v
+			}
+			if v <= hiFence && v > hi {
+				hi = v
+			}
+		}
+	default:
+		return b.min, b.max, nil
+	}
+	for _, v := range b.values {
+		if v < lo || v > hi {
+			outliers = append(outliers, v)
+		}
+	}
+	return lo, hi, outliers
+}
+
+// OutlierIDs returns the -id-column identifiers of the values
+// beyond the whiskers lo and hi, in the order of the outliers of whiskers,
+// or nil if the values have no identifiers.
+func (b box) outlierIDs(lo, hi float64) []string {
+	if b.ids == nil {
+		return nil
+	}
+	var ids []string
+	for i, v := range b.values {
+		if v < lo || v > hi {
+			ids = append(ids, b.ids[i])
+		}
+	}
+	return ids
+}
+
+// QuantileSorted returns the q-quantile of sorted values,
+// linearly interpolated between the nearest two.
+func quantileSorted(vs []float64, q float64) float64 {
+	pos := q * float64(len(vs)-1)
+	i := int(pos)
+	if i+1 >= len(vs) {
+		return vs[len(vs)-1]
+	}
+	return interpolate(pos, float64(i), vs[i], float64(i+1), vs[i+1])
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RangeMin and rangeMax are the ends of the value range pinned by -ymin and -ymax,
+// or NaN if it is derived from the values.
+var rangeMin, rangeMax = math.NaN(), math.NaN()
+
+// CheckRange returns an error if -ymin or -ymax is malformed,
+// or if they leave no range, or a range with no place on a -log scale,
+// and otherwise sets rangeMin and rangeMax from them,
+// warning of each box that extends beyond them,
+// and then from -robust-range, if set; see setRobustRange.
+func checkRange(boxes []box) error {
+	rangeMin, rangeMax = math.NaN(), math.NaN()
+	for _, f := range []struct {
+		name, s string
+		v       *float64
+	}{{"-ymin", *yMin, &rangeMin}, {"-ymax", *yMax, &rangeMax}} {
+		if f.s == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(f.s, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("Bad %s value: %s", f.name, f.s)
+		}
+		*f.v = v
+	}
+	if math.IsNaN(rangeMin) && math.IsNaN(rangeMax) {
+		setRobustRange(boxes)
+		return nil
+	}
+	min, max := minMax(boxes)
+	lo, hi := pinned(min, max)
+	if !(hi > lo) {
+		return fmt.Errorf("-ymin must be below -ymax, but the range is %s to %s", formatValue(lo), formatValue(hi))
+	}
+	if *logScale && *logZero != "symlog" && lo <= logFloor {
+		return fmt.Errorf("-log needs a positive -ymin, but it is %s", formatValue(lo))
+	}
+	for _, b := range boxes {
+		if b.n > 0 && (b.min < lo || b.max > hi) {
+			warnf("%s: extends beyond -ymin/-ymax; it is cut off at the edge", b.name)
+		}
+	}
+	setRobustRange(boxes)
+	return nil
+}
+
+// Pinned returns the range from min to max
+// with either end replaced by -ymin or -ymax, if given.
+func pinned(min, max float64) (float64, float64) {
+	if !math.IsNaN(rangeMin) {
+		min = rangeMin
+	}
+	if !math.IsNaN(rangeMax) {
+		max = rangeMax
+	}
+	return min, max
+}
+
+// ClampTr returns a function that transforms values with tr,
+// but no further than lo and hi,
+// so that values beyond a pinned range are drawn at its edge.
+func clampTr(tr func(float64) float64, lo, hi float64) func(float64) float64 {
+	return func(v float64) float64 {
+		return math.Max(lo, math.Min(hi, tr(v)))
+	}
+}